@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/core/logger/event"
+	"github.com/cnaize/meds/src/core/metrics"
+	"github.com/cnaize/meds/src/database"
+)
+
+const (
+	// max records waiting for the database write
+	eventsQLen = 4096
+	// how often the records are written
+	eventsFlushInterval = time.Second
+	// how often the old records are removed
+	eventsPruneInterval = time.Minute
+)
+
+var _ logger.Handler = (*dbEvents)(nil)
+
+// dbEvents keeps every "sample"-th packet verdict record in database,
+// records older than "retention" or above "maxCount" are removed
+type dbEvents struct {
+	db        *database.Database
+	logger    *logger.Logger
+	sample    uint64
+	retention time.Duration
+	maxCount  uint
+
+	seen    atomic.Uint64
+	records chan event.Record
+}
+
+func newDBEvents(db *database.Database, logger *logger.Logger, sample uint, retention time.Duration, maxCount uint) *dbEvents {
+	return &dbEvents{
+		db:        db,
+		logger:    logger,
+		sample:    uint64(sample),
+		retention: retention,
+		maxCount:  maxCount,
+		records:   make(chan event.Record, eventsQLen),
+	}
+}
+
+// Wants takes every "sample"-th verdict
+func (e *dbEvents) Wants(verdict event.Verdict) bool {
+	return e.seen.Add(1)%e.sample == 0
+}
+
+func (e *dbEvents) Handle(record event.Record) {
+	select {
+	case e.records <- record:
+		// good
+	default:
+		metrics.Get().ErrorsTotal.WithLabelValues("event record dropped").Inc()
+	}
+}
+
+// Run writes the records till the context is done
+func (e *dbEvents) Run(ctx context.Context) {
+	flushTicker := time.NewTicker(eventsFlushInterval)
+	defer flushTicker.Stop()
+
+	pruneTicker := time.NewTicker(eventsPruneInterval)
+	defer pruneTicker.Stop()
+
+	batch := make([]event.Record, 0, eventsQLen)
+	for {
+		select {
+		case record := <-e.records:
+			batch = append(batch, record)
+			if len(batch) < eventsQLen {
+				continue
+			}
+		case <-flushTicker.C:
+		case <-pruneTicker.C:
+			if err := e.prune(ctx, time.Now()); err != nil {
+				e.fail("events prune failed", err)
+			}
+			continue
+		case <-ctx.Done():
+			return
+		}
+
+		if len(batch) < 1 {
+			continue
+		}
+
+		if err := e.flush(ctx, batch); err != nil {
+			e.fail("events flush failed", err)
+		}
+		batch = batch[:0]
+	}
+}
+
+func (e *dbEvents) flush(ctx context.Context, batch []event.Record) error {
+	tx, err := e.db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, record := range batch {
+		var srcIP string
+		if record.SrcIP.IsValid() {
+			srcIP = record.SrcIP.String()
+		}
+
+		if err := e.db.Q.AddEvent(ctx, tx, &database.AddEventParams{
+			CreatedAt: record.Time.Unix(),
+			Action:    string(record.Action),
+			Reason:    record.Reason,
+			Filter:    string(record.Filter),
+			Target:    record.Target,
+			SrcIp:     srcIP,
+			DstPort:   int64(record.DstPort),
+			Country:   record.Country,
+			Asn:       int64(record.ASN),
+		}); err != nil {
+			return fmt.Errorf("add: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+func (e *dbEvents) prune(ctx context.Context, now time.Time) error {
+	if e.retention > 0 {
+		if err := e.db.Q.RemoveOldEvents(ctx, e.db.DB, now.Add(-e.retention).Unix()); err != nil {
+			return fmt.Errorf("remove old: %w", err)
+		}
+	}
+
+	if e.maxCount > 0 {
+		if err := e.db.Q.TrimEvents(ctx, e.db.DB, int64(e.maxCount)); err != nil {
+			return fmt.Errorf("trim: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (e *dbEvents) fail(msg string, err error) {
+	metrics.Get().ErrorsTotal.WithLabelValues(msg).Inc()
+	e.logger.Raw().
+		Error().
+		Err(err).
+		Msg(msg)
+}
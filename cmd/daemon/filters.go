@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/cnaize/meds/src/config"
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/filter/feed"
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/database"
+	"github.com/cnaize/meds/src/types"
+
+	asnfilter "github.com/cnaize/meds/src/core/filter/asn"
+	domainfilter "github.com/cnaize/meds/src/core/filter/domain"
+	geofilter "github.com/cnaize/meds/src/core/filter/geo"
+	ipfilter "github.com/cnaize/meds/src/core/filter/ip"
+	ja3filter "github.com/cnaize/meds/src/core/filter/ja3"
+	ratefilter "github.com/cnaize/meds/src/core/filter/rate"
+)
+
+type builtFilter struct {
+	def    string
+	filter filter.Filter
+}
+
+// filterBuilder creates the filter pipeline from config and database feeds,
+// filters with unchanged definitions are reused to keep their loaded lists
+type filterBuilder struct {
+	db      *database.Database
+	fetcher *feed.Fetcher
+	logger  *logger.Logger
+
+	subnetWhiteList  *types.SubnetList
+	subnetBlackList  *types.SubnetList
+	domainWhiteList  *types.DomainList
+	domainBlackList  *types.DomainList
+	countryWhiteList *types.CountryList
+	countryBlackList *types.CountryList
+	// global policy shared by geo filters, so they are reused on change
+	geoDenyUnknown atomic.Bool
+
+	asnList      *types.ASNList
+	asnWhiteList *types.ASNumberList
+	asnBlackList *types.ASNumberList
+	ja3WhiteList *types.JA3List
+	ja3BlackList *types.JA3List
+	ja3Observed  *types.TopCounter
+
+	built map[string]builtFilter
+	// built, but not swapped in yet
+	pending map[string]builtFilter
+}
+
+func newFilterBuilder(
+	db *database.Database,
+	fetcher *feed.Fetcher,
+	logger *logger.Logger,
+	subnetWhiteList *types.SubnetList,
+	subnetBlackList *types.SubnetList,
+	domainWhiteList *types.DomainList,
+	domainBlackList *types.DomainList,
+	countryWhiteList *types.CountryList,
+	countryBlackList *types.CountryList,
+	asnList *types.ASNList,
+	asnWhiteList *types.ASNumberList,
+	asnBlackList *types.ASNumberList,
+	ja3WhiteList *types.JA3List,
+	ja3BlackList *types.JA3List,
+	ja3Observed *types.TopCounter,
+) *filterBuilder {
+	return &filterBuilder{
+		db:               db,
+		fetcher:          fetcher,
+		logger:           logger,
+		subnetWhiteList:  subnetWhiteList,
+		subnetBlackList:  subnetBlackList,
+		domainWhiteList:  domainWhiteList,
+		domainBlackList:  domainBlackList,
+		countryWhiteList: countryWhiteList,
+		countryBlackList: countryBlackList,
+		asnList:          asnList,
+		asnWhiteList:     asnWhiteList,
+		asnBlackList:     asnBlackList,
+		ja3WhiteList:     ja3WhiteList,
+		ja3BlackList:     ja3BlackList,
+		ja3Observed:      ja3Observed,
+		built:            make(map[string]builtFilter),
+	}
+}
+
+// Build creates the filters, the builder state is changed by Commit once they are swapped in.
+// NOTE: not thread safe
+func (b *filterBuilder) Build(ctx context.Context, cfg config.Config) ([]filter.Filter, error) {
+	feeds, err := b.loadFeeds(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("load feeds: %w", err)
+	}
+
+	built := make(map[string]builtFilter)
+	reuse := func(key string, def any, newFn func() filter.Filter) filter.Filter {
+		data, _ := json.Marshal(def)
+		if prev, ok := b.built[key]; ok && prev.def == string(data) {
+			built[key] = prev
+			return prev.filter
+		}
+
+		curr := builtFilter{def: string(data), filter: newFn()}
+		built[key] = curr
+
+		return curr.filter
+	}
+	feedsOf := func(typ filter.FilterType) []filter.Filter {
+		var filters []filter.Filter
+		for _, f := range feeds {
+			if f.Type != typ {
+				continue
+			}
+
+			filters = append(filters, reuse("feed/"+string(f.Type)+"/"+f.Name, f, func() filter.Filter {
+				return b.newFeed(f)
+			}))
+		}
+
+		return filters
+	}
+
+	var filters []filter.Filter
+	// ip whitelist
+	filters = append(filters, reuse("ip/whitelist", nil, func() filter.Filter {
+		return ipfilter.NewWhiteList(b.logger, b.subnetWhiteList)
+	}))
+	// rate filter
+	filters = append(filters, reuse("rate/limiter", []any{cfg.LimiterRate, cfg.LimiterBurst, cfg.LimiterCacheSize, cfg.LimiterBucketTTL}, func() filter.Filter {
+		return ratefilter.NewLimiter(cfg.LimiterRate, cfg.LimiterBurst, cfg.LimiterCacheSize, cfg.LimiterBucketTTL, b.logger)
+	}))
+	// ip blacklist
+	filters = append(filters, reuse("ip/blacklist", nil, func() filter.Filter {
+		return ipfilter.NewBlackList(b.logger, b.subnetBlackList)
+	}))
+	// asn whitelist
+	filters = append(filters, reuse("asn/whitelist", nil, func() filter.Filter {
+		return asnfilter.NewWhiteList(b.logger, b.asnList, b.asnWhiteList)
+	}))
+	// asn blacklist
+	filters = append(filters, reuse("asn/blacklist", nil, func() filter.Filter {
+		return asnfilter.NewBlackList(b.logger, b.asnList, b.asnBlackList)
+	}))
+	// ip filters
+	filters = append(filters, feedsOf(filter.FilterTypeIP)...)
+	// geo filters
+	if len(cfg.MMDB) > 0 {
+		// local databases replace iplocate
+		filters = append(filters, reuse("geo/mmdb", cfg.MMDB, func() filter.Filter {
+			return geofilter.NewMMDB(cfg.MMDB, b.logger, b.asnList, b.countryWhiteList, b.countryBlackList, &b.geoDenyUnknown)
+		}))
+	} else {
+		filters = append(filters, reuse("geo/iplocate", cfg.IPLocate, func() filter.Filter {
+			return geofilter.NewIPLocate(cfg.IPLocate, b.fetcher, b.logger, b.asnList, b.countryWhiteList, b.countryBlackList, &b.geoDenyUnknown)
+		}))
+	}
+	// asn filters
+	filters = append(filters, feedsOf(filter.FilterTypeASN)...)
+	// domain/sni whitelist
+	filters = append(filters, reuse("domain/whitelist", nil, func() filter.Filter {
+		return domainfilter.NewWhiteList(b.logger, b.domainWhiteList)
+	}))
+	// domain/sni blacklist
+	filters = append(filters, reuse("domain/blacklist", nil, func() filter.Filter {
+		return domainfilter.NewBlackList(b.logger, b.domainBlackList)
+	}))
+	// domain/sni filters
+	filters = append(filters, feedsOf(filter.FilterTypeDomain)...)
+	// ja3 whitelist
+	filters = append(filters, reuse("ja3/whitelist", nil, func() filter.Filter {
+		return ja3filter.NewWhiteList(b.logger, b.ja3WhiteList, b.ja3Observed)
+	}))
+	// ja3 blacklist
+	filters = append(filters, reuse("ja3/blacklist", nil, func() filter.Filter {
+		return ja3filter.NewBlackList(b.logger, b.ja3BlackList)
+	}))
+	// ja3 filters
+	filters = append(filters, feedsOf(filter.FilterTypeJA3)...)
+
+	b.pending = built
+
+	return filters, nil
+}
+
+// Commit applies the config shared by the last built filters
+func (b *filterBuilder) Commit(cfg config.Config) {
+	b.geoDenyUnknown.Store(cfg.GeoDenyUnknown)
+	if b.pending != nil {
+		b.built = b.pending
+		b.pending = nil
+	}
+}
+
+// loadFeeds merges config feeds with the ones added via api (api wins)
+func (b *filterBuilder) loadFeeds(ctx context.Context, cfg config.Config) ([]feed.Feed, error) {
+	rows, err := b.db.Q.GetAllFeeds(ctx, b.db.DB)
+	if err != nil {
+		return nil, fmt.Errorf("get all: %w", err)
+	}
+
+	feeds := slices.Clone(cfg.Feeds)
+	for _, row := range rows {
+		f := feed.Feed{
+			Name:     row.Name,
+			Type:     filter.FilterType(row.Type),
+			Interval: time.Duration(row.UpdateInterval),
+		}
+		if err := json.Unmarshal([]byte(row.Sources), &f.Sources); err != nil {
+			return nil, fmt.Errorf("%s (%s): unmarshal sources: %w", f.Name, f.Type, err)
+		}
+		if err := json.Unmarshal([]byte(row.Limits), &f.Limits); err != nil {
+			return nil, fmt.Errorf("%s (%s): unmarshal limits: %w", f.Name, f.Type, err)
+		}
+		if err := f.Validate(); err != nil {
+			return nil, fmt.Errorf("%s (%s): validate: %w", f.Name, f.Type, err)
+		}
+
+		i := slices.IndexFunc(feeds, func(curr feed.Feed) bool {
+			return curr.Type == f.Type && curr.Name == f.Name
+		})
+		if i < 0 {
+			feeds = append(feeds, f)
+		} else {
+			feeds[i] = f
+		}
+	}
+
+	return feeds, nil
+}
+
+func (b *filterBuilder) newFeed(f feed.Feed) filter.Filter {
+	switch f.Type {
+	case filter.FilterTypeIP:
+		return ipfilter.NewFeed(f, b.fetcher, b.logger, b.subnetWhiteList)
+	case filter.FilterTypeASN:
+		return asnfilter.NewFeed(f, b.fetcher, b.logger, b.asnList)
+	case filter.FilterTypeDomain:
+		return domainfilter.NewFeed(f, b.fetcher, b.logger)
+	case filter.FilterTypeJA3:
+		return ja3filter.NewFeed(f, b.fetcher, b.logger)
+	}
+
+	// NOTE: feeds are validated on load
+	panic(fmt.Sprintf("unsupported feed type: %s", f.Type))
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cnaize/meds/src/core"
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/database"
+)
+
+var _ core.History = (*dbHistory)(nil)
+
+// dbHistory keeps last "size" filter list changes (per filter) in database
+type dbHistory struct {
+	db   *database.Database
+	size uint
+}
+
+func newDBHistory(db *database.Database, size uint) *dbHistory {
+	return &dbHistory{
+		db:   db,
+		size: size,
+	}
+}
+
+func (h *dbHistory) Record(ctx context.Context, typ filter.FilterType, name string, added, removed []string) error {
+	addedData, err := json.Marshal(nonNil(added))
+	if err != nil {
+		return fmt.Errorf("marshal added: %w", err)
+	}
+	removedData, err := json.Marshal(nonNil(removed))
+	if err != nil {
+		return fmt.Errorf("marshal removed: %w", err)
+	}
+
+	tx, err := h.db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := h.db.Q.AddFilterHistory(ctx, tx, &database.AddFilterHistoryParams{
+		Name:      name,
+		Type:      string(typ),
+		CreatedAt: time.Now().Unix(),
+		Added:     string(addedData),
+		Removed:   string(removedData),
+	}); err != nil {
+		return fmt.Errorf("add: %w", err)
+	}
+
+	if err := h.db.Q.TrimFilterHistory(ctx, tx, &database.TrimFilterHistoryParams{
+		Type: string(typ),
+		Name: name,
+		Keep: int64(h.size),
+	}); err != nil {
+		return fmt.Errorf("trim: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+func (h *dbHistory) Last(ctx context.Context, typ filter.FilterType, name string) (core.HistoryRecord, error) {
+	row, err := h.db.Q.GetLastFilterHistory(ctx, h.db.DB, &database.GetLastFilterHistoryParams{
+		Type: string(typ),
+		Name: name,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.HistoryRecord{}, core.ErrNoHistory
+		}
+
+		return core.HistoryRecord{}, fmt.Errorf("get last: %w", err)
+	}
+
+	record := core.HistoryRecord{ID: row.ID}
+	if err := json.Unmarshal([]byte(row.Added), &record.Added); err != nil {
+		return core.HistoryRecord{}, fmt.Errorf("unmarshal added: %w", err)
+	}
+	if err := json.Unmarshal([]byte(row.Removed), &record.Removed); err != nil {
+		return core.HistoryRecord{}, fmt.Errorf("unmarshal removed: %w", err)
+	}
+
+	return record, nil
+}
+
+func (h *dbHistory) Remove(ctx context.Context, id int64) error {
+	return h.db.Q.RemoveFilterHistory(ctx, h.db.DB, id)
+}
+
+func nonNil(entries []string) []string {
+	if entries == nil {
+		return []string{}
+	}
+
+	return entries
+}
@@ -2,11 +2,13 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"net/netip"
 	"os"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/appleboy/graceful"
@@ -16,24 +18,36 @@ import (
 	"github.com/cnaize/meds/src/config"
 	"github.com/cnaize/meds/src/core"
 	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/filter/feed"
 	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/core/stats"
+	"github.com/cnaize/meds/src/core/stream"
 	"github.com/cnaize/meds/src/database"
 	"github.com/cnaize/meds/src/server"
 	"github.com/cnaize/meds/src/types"
+)
 
-	asnfilter "github.com/cnaize/meds/src/core/filter/asn"
-	domainfilter "github.com/cnaize/meds/src/core/filter/domain"
-	geofilter "github.com/cnaize/meds/src/core/filter/geo"
-	ipfilter "github.com/cnaize/meds/src/core/filter/ip"
-	ja3filter "github.com/cnaize/meds/src/core/filter/ja3"
-	ratefilter "github.com/cnaize/meds/src/core/filter/rate"
+const (
+	// max distinct ja3 hashes counted
+	ja3ObservedSize = 1024
+	// max distinct keys counted (per stats kind and window bucket)
+	statsTopSize = 256
+	// max events waiting for the slow stream client
+	streamBufferSize = 256
 )
 
 func main() {
 	var cfg config.Config
 	// parse config
+	flag.StringVar(&cfg.ConfigFilePath, "config", "", "path to yaml config file (overrides flags, reloaded on SIGHUP)")
 	flag.StringVar(&cfg.LogLevel, "log-level", "info", "zerolog level")
+	flag.StringVar(&cfg.LogFormat, "log-format", "console", "stdout log format if no log-sinks configured (console, json, cef or leef)")
+	flag.DurationVar(&cfg.LogDedupInterval, "log-dedup-interval", 10*time.Second, "collapse repeated packet verdict events into summaries per interval (0 to disable)")
+	flag.UintVar(&cfg.LogDedupSize, "log-dedup-size", 10_000, "max distinct packet verdict events deduplicated per interval")
+	flag.UintVar(&cfg.LogSampleBurst, "log-sample-burst", 0, "packet verdict events written per second before sampling (0 to disable sampling)")
+	flag.UintVar(&cfg.LogSampleRate, "log-sample-rate", 100, "write every n-th packet verdict event above the burst (0 to write none)")
 	flag.StringVar(&cfg.DBFilePath, "db-path", "meds.db", "path to database file")
+	flag.StringVar(&cfg.CacheDirPath, "cache-dir", "cache", "path to feeds cache directory")
 	flag.StringVar(&cfg.APIServerAddr, "api-addr", ":8000", "api server address")
 	flag.UintVar(&cfg.ReadersCount, "readers-count", uint(runtime.GOMAXPROCS(0)), "nfqueue readers count")
 	flag.UintVar(&cfg.WorkersCount, "workers-count", 1, "nfqueue workers count (per reader)")
@@ -42,6 +56,24 @@ func main() {
 	flag.UintVar(&cfg.LoggerQLen, "logger-queue-len", 2048, "logger queue length (all workers)")
 	flag.DurationVar(&cfg.UpdateTimeout, "update-timeout", time.Minute, "update timeout (per filter)")
 	flag.DurationVar(&cfg.UpdateInterval, "update-interval", 4*time.Hour, "update frequency")
+	flag.UintVar(&cfg.UpdateRetries, "update-retries", 3, "download retries (per source)")
+	flag.DurationVar(&cfg.UpdateBackoff, "update-backoff", 2*time.Second, "initial retry backoff (doubled per retry)")
+	flag.Float64Var(&cfg.UpdateMinRatio, "update-min-ratio", 0.5, "min ratio of new/previous list size to accept an update")
+	flag.UintVar(&cfg.UpdateParallel, "update-parallel", 4, "concurrent filter updates")
+	flag.UintVar(&cfg.UpdateMemory, "update-memory", 512, "memory budget for concurrent filter updates (MB)")
+	flag.UintVar(&cfg.HistorySize, "history-size", 10, "filter list changes kept in history (per filter, 0 to disable)")
+	flag.UintVar(&cfg.EventsSample, "events-sample", 100, "store every n-th packet verdict event (0 to disable)")
+	flag.DurationVar(&cfg.EventsRetention, "events-retention", 7*24*time.Hour, "stored events max age (0 for unlimited)")
+	flag.UintVar(&cfg.EventsMaxCount, "events-max-count", 1_000_000, "stored events max count (0 for unlimited)")
+	flag.StringVar(&cfg.HTTPProxy, "http-proxy", "", "http proxy url (environment proxy if empty)")
+	flag.StringVar(&cfg.HTTPCABundle, "http-ca-bundle", "", "path to pem file with extra root certificates")
+	flag.StringVar(&cfg.HTTPUserAgent, "http-user-agent", "meds", "http user agent")
+	flag.DurationVar(&cfg.HTTPTimeout, "http-timeout", 5*time.Minute, "http request timeout")
+	flag.BoolVar(&cfg.GeoDenyUnknown, "geo-deny-unknown", false, "drop packets from ips with unknown country")
+	flag.Func("mmdb", "comma separated mmdb files (GeoLite2/GeoIP2/DB-IP country and asn), replace iplocate", func(value string) error {
+		cfg.MMDB = append(cfg.MMDB, strings.Split(value, ",")...)
+		return nil
+	})
 	flag.UintVar(&cfg.LimiterRate, "rate-limiter-rate", 3000, "max packets per second (per ip)")
 	flag.UintVar(&cfg.LimiterBurst, "rate-limiter-burst", 1500, "max packets at once (per ip)")
 	flag.UintVar(&cfg.LimiterCacheSize, "rate-limiter-cache-size", 100_000, "rate limiter cache size (all buckets)")
@@ -50,28 +82,56 @@ func main() {
 	// flag.StringVar(&cfg.Username, "username", "admin", "admin username")
 	// flag.StringVar(&cfg.Password, "password", "admin", "admin password")
 	flag.Parse()
+	cfg.IPLocate = config.DefaultIPLocate()
+	cfg.Feeds = config.DefaultFeeds()
 
-	// set "debug" for invalid log level
-	logLevel, err := zerolog.ParseLevel(cfg.LogLevel)
+	// load config file
+	flags := cfg
+	cfg, err := config.Load(flags.ConfigFilePath, flags)
 	if err != nil {
-		logLevel = zerolog.DebugLevel
+		fmt.Fprintf(os.Stderr, "config load failed: %s\n", err)
+		os.Exit(1)
 	}
 
 	// main context
 	mainCtx, mainCancel := context.WithCancel(context.Background())
 	defer mainCancel()
 
+	// create log sinks
+	sinks := cfg.LogSinks
+	if len(sinks) < 1 {
+		sinks = []logger.Sink{{Type: logger.SinkTypeStdout, Format: logger.SinkFormat(cfg.LogFormat)}}
+	}
+	writer, err := logger.NewWriter(sinks)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "log sinks create failed: %s\n", err)
+		os.Exit(1)
+	}
+
 	// create logger
+	setLogLevel(cfg.LogLevel)
 	logger := logger.NewLogger(get.Ptr(
-		zerolog.New(zerolog.NewConsoleWriter()).
+		zerolog.New(writer).
 			With().
 			Timestamp().
-			Logger().
-			Level(logLevel)),
+			Logger()),
 		cfg.LoggerQLen,
 	)
+	if cfg.LogDedupInterval > 0 {
+		logger.SetDedup(cfg.LogDedupInterval, cfg.LogDedupSize)
+	}
+	if cfg.LogSampleBurst > 0 {
+		logger.SetSampler(cfg.LogSampleBurst, cfg.LogSampleRate)
+	}
 	logger.Run(mainCtx, cfg.LoggersCount)
 
+	// count top dropped packets parts
+	top := stats.NewTop(statsTopSize)
+	logger.AddHandler(top)
+	// stream live events to api clients
+	hub := stream.NewHub(streamBufferSize)
+	logger.AddHandler(hub)
+
 	// check username/password
 	cfg.Username = os.Getenv("MEDS_USERNAME")
 	cfg.Password = os.Getenv("MEDS_PASSWORD")
@@ -87,29 +147,84 @@ func main() {
 		logger.Raw().Fatal().Err(err).Msg("database init failed")
 	}
 
+	// store packet verdict events
+	if cfg.EventsSample > 0 {
+		events := newDBEvents(db, logger, cfg.EventsSample, cfg.EventsRetention, cfg.EventsMaxCount)
+		go events.Run(mainCtx)
+
+		logger.AddHandler(events)
+	}
+
 	// load white/black lists
-	subnetWhiteList, subnetBlackList, domainWhiteList, domainBlackList, countryBlackList, err := loadWhiteBlackLists(mainCtx, db)
+	subnetWhiteList, subnetBlackList, domainWhiteList, domainBlackList, countryWhiteList, countryBlackList, asnWhiteList, asnBlackList, ja3WhiteList, ja3BlackList, err := loadWhiteBlackLists(mainCtx, db)
 	if err != nil {
 		logger.Raw().Fatal().Err(err).Msg("white/black lists load")
 	}
+	// geofilter.IPLocate (or geofilter.MMDB) is responsible for the ASNList updates
+	asnList := types.NewASNList()
+	// ja3.WhiteList counts the observed hashes
+	ja3Observed := types.NewTopCounter(ja3ObservedSize)
+
+	// create http client
+	client, err := feed.NewClient(feed.ClientConfig{
+		Proxy:     cfg.HTTPProxy,
+		CABundle:  cfg.HTTPCABundle,
+		UserAgent: cfg.HTTPUserAgent,
+		Timeout:   cfg.HTTPTimeout,
+	})
+	if err != nil {
+		logger.Raw().Fatal().Err(err).Msg("http client create failed")
+	}
 
 	// create filters
-	filters := newFilters(
-		cfg,
+	builder := newFilterBuilder(
+		db,
+		feed.NewFetcher(client, feed.NewCache(cfg.CacheDirPath), cfg.UpdateRetries, cfg.UpdateBackoff, cfg.UpdateMinRatio),
 		logger,
 		subnetWhiteList,
 		subnetBlackList,
 		domainWhiteList,
 		domainBlackList,
+		countryWhiteList,
 		countryBlackList,
+		asnList,
+		asnWhiteList,
+		asnBlackList,
+		ja3WhiteList,
+		ja3BlackList,
+		ja3Observed,
 	)
+	filters, err := builder.Build(mainCtx, cfg)
+	if err != nil {
+		logger.Raw().Fatal().Err(err).Msg("filters build failed")
+	}
+	builder.Commit(cfg)
 
 	// create queue
-	q := core.NewQueue(cfg.ReadersCount, cfg.WorkersCount, cfg.ReaderQLen, filters, logger)
+	q := core.NewQueue(
+		cfg.ReadersCount,
+		cfg.WorkersCount,
+		cfg.ReaderQLen,
+		cfg.UpdateParallel,
+		uint64(cfg.UpdateMemory)<<20,
+		filters,
+		logger,
+	)
 	if err := q.Load(mainCtx); err != nil {
 		logger.Raw().Fatal().Err(err).Msg("queue load failed")
 	}
-	go q.Update(mainCtx, cfg.UpdateTimeout, cfg.UpdateInterval)
+	if err := loadFilterStates(mainCtx, db, q); err != nil {
+		logger.Raw().Fatal().Err(err).Msg("filter states load failed")
+	}
+	if cfg.HistorySize > 0 {
+		q.SetHistory(newDBHistory(db, cfg.HistorySize))
+	}
+	q.SetSchedule(cfg.UpdateTimeout, cfg.UpdateInterval)
+	go q.Update(mainCtx)
+
+	// reload on SIGHUP or api call
+	reloader := newReloader(flags, cfg, q, logger, builder.Build, builder.Commit)
+	go reloader.Watch(mainCtx)
 
 	// create server
 	api := server.NewServer(
@@ -117,11 +232,28 @@ func main() {
 		cfg.Username,
 		cfg.Password,
 		db,
+		logger,
+		reloader.Reload,
+		reloader.Rebuild,
+		q.Status,
+		q.Trigger,
+		q.SetEnabled,
+		q.Rollback,
+		q.Explain,
 		subnetWhiteList,
 		subnetBlackList,
 		domainWhiteList,
 		domainBlackList,
+		countryWhiteList,
 		countryBlackList,
+		asnList,
+		asnWhiteList,
+		asnBlackList,
+		ja3WhiteList,
+		ja3BlackList,
+		ja3Observed,
+		top,
+		hub,
 	)
 
 	m := graceful.NewManager(graceful.WithContext(mainCtx), graceful.WithLogger(graceful.NewLogger()))
@@ -160,6 +292,11 @@ func main() {
 			logger.Raw().Err(err).Msg("database close failed")
 		}
 
+		// close log sinks
+		if err := writer.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "log sinks close failed: %s\n", err)
+		}
+
 		return nil
 	})
 
@@ -173,25 +310,30 @@ func loadWhiteBlackLists(ctx context.Context, db *database.Database) (
 	*types.DomainList,
 	*types.DomainList,
 	*types.CountryList,
+	*types.CountryList,
+	*types.ASNumberList,
+	*types.ASNumberList,
+	*types.JA3List,
+	*types.JA3List,
 	error,
 ) {
 	// load subnet whitelist
 	subnetWhiteList := types.NewSubnetList()
 	snWhiteList, err := db.Q.GetAllWhiteListSubnets(ctx, db.DB)
 	if err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf("subnet whitelist get: %w", err)
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("subnet whitelist get: %w", err)
 	}
 	if len(snWhiteList) > 0 {
 		subnets, err := get.Subnets(snWhiteList)
 		if err != nil {
-			return nil, nil, nil, nil, nil, fmt.Errorf("subnet whitelist parse: %w", err)
+			return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("subnet whitelist parse: %w", err)
 		}
 		if err := subnetWhiteList.Upsert(subnets); err != nil {
-			return nil, nil, nil, nil, nil, fmt.Errorf("subnet whitelist upsert: %w", err)
+			return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("subnet whitelist upsert: %w", err)
 		}
 	} else {
 		if err := prefillWhiteList(ctx, db, subnetWhiteList); err != nil {
-			return nil, nil, nil, nil, nil, fmt.Errorf("subnet whitelist prefill: %w", err)
+			return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("subnet whitelist prefill: %w", err)
 		}
 	}
 
@@ -199,46 +341,123 @@ func loadWhiteBlackLists(ctx context.Context, db *database.Database) (
 	subnetBlackList := types.NewSubnetList()
 	snBlackList, err := db.Q.GetAllBlackListSubnets(ctx, db.DB)
 	if err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf("subnet blacklist get: %w", err)
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("subnet blacklist get: %w", err)
 	}
 	subnets, err := get.Subnets(snBlackList)
 	if err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf("subnet blacklist parse: %w", err)
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("subnet blacklist parse: %w", err)
 	}
 	if err := subnetBlackList.Upsert(subnets); err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf("subnet blacklist upsert: %w", err)
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("subnet blacklist upsert: %w", err)
 	}
 
 	// load domain whitelist
 	domainWhiteList := types.NewDomainList()
 	dmWhiteList, err := db.Q.GetAllWhiteListDomains(ctx, db.DB)
 	if err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf("domain whitelist get: %w", err)
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("domain whitelist get: %w", err)
 	}
 	if err := domainWhiteList.Upsert(dmWhiteList); err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf("domain whitelist upsert: %w", err)
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("domain whitelist upsert: %w", err)
 	}
 
 	// load domain whitelist
 	domainBlackList := types.NewDomainList()
 	dmBlackList, err := db.Q.GetAllBlackListDomains(ctx, db.DB)
 	if err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf("domain blacklist get: %w", err)
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("domain blacklist get: %w", err)
 	}
 	if err := domainBlackList.Upsert(dmBlackList); err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf("domain blacklist upsert: %w", err)
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("domain blacklist upsert: %w", err)
+	}
+
+	// load country whitelist
+	countryWhiteList := types.NewCountryList()
+	crWhiteList, err := db.Q.GetAllWhiteListCountries(ctx, db.DB)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("country whitelist get: %w", err)
+	}
+	if err := countryWhiteList.Upsert(crWhiteList); err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("country whitelist upsert: %w", err)
 	}
 
+	// load country blacklist
 	countryBlackList := types.NewCountryList()
 	crBlackList, err := db.Q.GetAllBlackListCountries(ctx, db.DB)
 	if err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf("country blacklist get: %w", err)
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("country blacklist get: %w", err)
 	}
 	if err := countryBlackList.Upsert(crBlackList); err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf("country blacklist upsert: %w", err)
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("country blacklist upsert: %w", err)
 	}
 
-	return subnetWhiteList, subnetBlackList, domainWhiteList, domainBlackList, countryBlackList, nil
+	// load asn whitelist
+	asnWhiteList := types.NewASNumberList()
+	asWhiteList, err := db.Q.GetAllWhiteListASNs(ctx, db.DB)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("asn whitelist get: %w", err)
+	}
+	if err := asnWhiteList.Upsert(asNumbers(asWhiteList)); err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("asn whitelist upsert: %w", err)
+	}
+
+	// load asn blacklist
+	asnBlackList := types.NewASNumberList()
+	asBlackList, err := db.Q.GetAllBlackListASNs(ctx, db.DB)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("asn blacklist get: %w", err)
+	}
+	if err := asnBlackList.Upsert(asNumbers(asBlackList)); err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("asn blacklist upsert: %w", err)
+	}
+
+	// load ja3 whitelist
+	ja3WhiteList := types.NewJA3List()
+	jaWhiteList, err := db.Q.GetAllWhiteListJA3s(ctx, db.DB)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("ja3 whitelist get: %w", err)
+	}
+	if err := ja3WhiteList.Upsert(jaWhiteList); err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("ja3 whitelist upsert: %w", err)
+	}
+
+	// load ja3 blacklist
+	ja3BlackList := types.NewJA3List()
+	jaBlackList, err := db.Q.GetAllBlackListJA3s(ctx, db.DB)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("ja3 blacklist get: %w", err)
+	}
+	if err := ja3BlackList.Upsert(jaBlackList); err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("ja3 blacklist upsert: %w", err)
+	}
+
+	return subnetWhiteList, subnetBlackList, domainWhiteList, domainBlackList, countryWhiteList, countryBlackList, asnWhiteList, asnBlackList, ja3WhiteList, ja3BlackList, nil
+}
+
+func asNumbers(rows []int64) []uint32 {
+	asns := make([]uint32, len(rows))
+	for i, asn := range rows {
+		asns[i] = uint32(asn)
+	}
+
+	return asns
+}
+
+// loadFilterStates applies filter states set via api
+func loadFilterStates(ctx context.Context, db *database.Database, q *core.Queue) error {
+	states, err := db.Q.GetAllFilterStates(ctx, db.DB)
+	if err != nil {
+		return fmt.Errorf("get all: %w", err)
+	}
+
+	for _, state := range states {
+		// filter may be removed from config
+		if err := q.SetEnabled(filter.FilterType(state.Type), state.Name, state.Enabled); err != nil && !errors.Is(err, core.ErrFilterNotFound) {
+			return fmt.Errorf("%s (%s): set enabled: %w", state.Name, state.Type, err)
+		}
+	}
+
+	return nil
 }
 
 func prefillWhiteList(ctx context.Context, db *database.Database, subnetWhiteList *types.SubnetList) error {
@@ -255,66 +474,17 @@ func prefillWhiteList(ctx context.Context, db *database.Database, subnetWhiteLis
 	}
 
 	// upsert to database
+	now := time.Now().Unix()
 	for _, subnet := range subnets {
-		if err := db.Q.UpsertWhiteListSubnet(ctx, db.DB, subnet.String()); err != nil {
+		if err := db.Q.UpsertWhiteListSubnet(ctx, db.DB, &database.UpsertWhiteListSubnetParams{
+			Subnet:    subnet.String(),
+			Comment:   "private network",
+			Source:    types.SourceAuto,
+			CreatedAt: now,
+		}); err != nil {
 			return fmt.Errorf("upsert subnet: %w", err)
 		}
 	}
 
 	return nil
 }
-
-func newFilters(
-	cfg config.Config,
-	logger *logger.Logger,
-	subnetWhiteList *types.SubnetList,
-	subnetBlackList *types.SubnetList,
-	domainWhiteList *types.DomainList,
-	domainBlackList *types.DomainList,
-	countryBlacklist *types.CountryList,
-) []filter.Filter {
-	// geofilter.IPLocate is responsible for the ASNList updates
-	asnList := types.NewASNList()
-
-	return []filter.Filter{
-		// ip whitelist
-		ipfilter.NewWhiteList(logger, subnetWhiteList),
-		// rate filter
-		ratefilter.NewLimiter(cfg.LimiterRate, cfg.LimiterBurst, cfg.LimiterCacheSize, cfg.LimiterBucketTTL, logger),
-		// ip blacklist
-		ipfilter.NewBlackList(logger, subnetBlackList),
-		// ip filters
-		ipfilter.NewFireHOL([]string{
-			"https://raw.githubusercontent.com/firehol/blocklist-ipsets/master/firehol_level1.netset",
-		}, logger),
-		ipfilter.NewSpamhaus([]string{
-			"https://www.spamhaus.org/drop/drop.txt",
-		}, logger),
-		ipfilter.NewAbuse([]string{
-			"https://feodotracker.abuse.ch/downloads/ipblocklist.txt",
-		}, logger),
-		// geo filters
-		geofilter.NewIPLocate([]string{
-			"https://github.com/iplocate/ip-address-databases/raw/refs/heads/main/ip-to-asn/ip-to-asn.csv.zip",
-		}, logger, asnList, countryBlacklist),
-		// asn filters
-		asnfilter.NewSpamhaus([]string{
-			"https://www.spamhaus.org/drop/asndrop.json",
-		}, logger, asnList),
-		// domain/sni whitelist
-		domainfilter.NewWhiteList(logger, domainWhiteList),
-		// domain/sni blacklist
-		domainfilter.NewBlackList(logger, domainBlackList),
-		// domain/sni filters
-		domainfilter.NewStevenBlack([]string{
-			"https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts",
-		}, logger),
-		domainfilter.NewSomeoneWhoCares([]string{
-			"https://someonewhocares.org/hosts/hosts",
-		}, logger),
-		// ja3 filters
-		ja3filter.NewAbuse([]string{
-			"https://sslbl.abuse.ch/blacklist/ja3_fingerprints.csv",
-		}, logger),
-	}
-}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/rs/zerolog"
+
+	"github.com/cnaize/meds/src/config"
+	"github.com/cnaize/meds/src/core"
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/logger"
+)
+
+type reloader struct {
+	mu sync.Mutex
+
+	flags config.Config
+	cfg   config.Config
+
+	queue  *core.Queue
+	logger *logger.Logger
+	build  func(ctx context.Context, cfg config.Config) ([]filter.Filter, error)
+	commit func(cfg config.Config)
+}
+
+func newReloader(
+	flags config.Config,
+	cfg config.Config,
+	queue *core.Queue,
+	logger *logger.Logger,
+	build func(ctx context.Context, cfg config.Config) ([]filter.Filter, error),
+	commit func(cfg config.Config),
+) *reloader {
+	return &reloader{
+		flags:  flags,
+		cfg:    cfg,
+		queue:  queue,
+		logger: logger,
+		build:  build,
+		commit: commit,
+	}
+}
+
+func (r *reloader) Watch(ctx context.Context) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+	defer signal.Stop(sigs)
+
+	for {
+		select {
+		case <-sigs:
+			if _, err := r.Reload(ctx); err != nil {
+				r.logger.Raw().Error().Err(err).Msg("reload failed")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *reloader) Reload(ctx context.Context) (config.Diff, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.logger.Raw().Info().Msg("Reloading config...")
+
+	// re-read config file on top of the command-line flags
+	cfg, err := config.Load(r.flags.ConfigFilePath, r.flags)
+	if err != nil {
+		return config.Diff{}, fmt.Errorf("config load: %w", err)
+	}
+	// credentials come from the environment
+	cfg.Username = r.cfg.Username
+	cfg.Password = r.cfg.Password
+
+	diff := r.cfg.Diff(cfg)
+
+	// NOTE: the new filters are swapped in only if loaded,
+	// the rest is applied after that, so a failed reload changes nothing
+	if diff.Rebuild {
+		if err := r.rebuild(ctx, cfg); err != nil {
+			return config.Diff{}, err
+		}
+	}
+	setLogLevel(cfg.LogLevel)
+	r.queue.SetSchedule(cfg.UpdateTimeout, cfg.UpdateInterval)
+
+	r.cfg = cfg
+	r.logger.Raw().
+		Info().
+		Strs("reloaded", diff.Reloaded).
+		Strs("restart", diff.Restart).
+		Bool("rebuild", diff.Rebuild).
+		Msg("Config reloaded")
+
+	return diff, nil
+}
+
+// Rebuild recreates filters with the current config (e.g. after feeds change)
+func (r *reloader) Rebuild(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.rebuild(ctx, r.cfg)
+}
+
+func (r *reloader) rebuild(ctx context.Context, cfg config.Config) error {
+	filters, err := r.build(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("filters build: %w", err)
+	}
+
+	if err := r.queue.Reload(ctx, filters); err != nil {
+		return fmt.Errorf("queue reload: %w", err)
+	}
+	r.commit(cfg)
+
+	return nil
+}
+
+func setLogLevel(level string) {
+	// set "debug" for invalid log level
+	logLevel, err := zerolog.ParseLevel(level)
+	if err != nil {
+		logLevel = zerolog.DebugLevel
+	}
+
+	zerolog.SetGlobalLevel(logLevel)
+}
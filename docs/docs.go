@@ -22,54 +22,105 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/blacklist/countries": {
+        "/v1/blacklist/asns": {
             "get": {
-                "description": "get all blacklisted countries",
+                "description": "get blacklisted asns with their names (if known), paginated if limit is set",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "blacklist"
                 ],
-                "summary": "Get blacklisted countries",
+                "summary": "Get blacklisted asns",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next page cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "value",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "default": "value",
+                        "description": "sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.GetCountriesResp"
+                            "$ref": "#/definitions/api.GetASNsResp"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             },
             "post": {
-                "description": "upsert countries to blacklist",
+                "description": "upsert asns to blacklist",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "blacklist"
                 ],
-                "summary": "Upsert blacklisted countries",
+                "summary": "Upsert blacklisted asns",
                 "parameters": [
                     {
-                        "description": "countries to add",
+                        "description": "asns to add",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.UpsertCountriesReq"
+                            "$ref": "#/definitions/api.UpsertASNsReq"
                         }
                     }
                 ],
                 "responses": {
                     "202": {
-                        "description": "Accepted"
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
                     },
                     "422": {
-                        "description": "Unprocessable Entity"
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error"
@@ -77,34 +128,43 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "remove countries from blacklist",
+                "description": "remove asns from blacklist",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "blacklist"
                 ],
-                "summary": "Remove blacklisted countries",
+                "summary": "Remove blacklisted asns",
                 "parameters": [
                     {
-                        "description": "countries to remove",
+                        "description": "asns to remove",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.RemoveCountriesReq"
+                            "$ref": "#/definitions/api.RemoveASNsReq"
                         }
                     }
                 ],
                 "responses": {
                     "202": {
-                        "description": "Accepted"
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
                     },
                     "422": {
-                        "description": "Unprocessable Entity"
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error"
@@ -112,21 +172,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/blacklist/countries/{country}": {
+        "/v1/blacklist/asns/{asn}": {
             "get": {
-                "description": "check if a country is blacklisted",
+                "description": "check if an asn is blacklisted",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "blacklist"
                 ],
-                "summary": "Check blacklisted country",
+                "summary": "Check blacklisted asn",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "country to check",
-                        "name": "country",
+                        "example": "AS13335",
+                        "description": "asn to check",
+                        "name": "asn",
                         "in": "path",
                         "required": true
                     }
@@ -135,60 +196,120 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.CheckCountryResp"
+                            "$ref": "#/definitions/api.CheckASNResp"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request"
                     }
                 }
             }
         },
-        "/v1/blacklist/domains": {
+        "/v1/blacklist/countries": {
             "get": {
-                "description": "get all blacklisted domains",
+                "description": "get blacklisted countries, paginated if limit is set",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "blacklist"
                 ],
-                "summary": "Get blacklisted domains",
+                "summary": "Get blacklisted countries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "country substring",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next page cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "value",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "default": "value",
+                        "description": "sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.GetDomainsResp"
+                            "$ref": "#/definitions/api.GetCountriesResp"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             },
             "post": {
-                "description": "upsert domains to blacklist",
+                "description": "upsert countries to blacklist",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "blacklist"
                 ],
-                "summary": "Upsert blacklisted domains",
+                "summary": "Upsert blacklisted countries",
                 "parameters": [
                     {
-                        "description": "domains to add",
+                        "description": "countries to add",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.UpsertDomainsReq"
+                            "$ref": "#/definitions/api.UpsertCountriesReq"
                         }
                     }
                 ],
                 "responses": {
                     "202": {
-                        "description": "Accepted"
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
                     },
                     "422": {
-                        "description": "Unprocessable Entity"
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error"
@@ -196,34 +317,43 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "remove domains from blacklist",
+                "description": "remove countries from blacklist",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "blacklist"
                 ],
-                "summary": "Remove blacklisted domains",
+                "summary": "Remove blacklisted countries",
                 "parameters": [
                     {
-                        "description": "domains to remove",
+                        "description": "countries to remove",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.RemoveDomainsReq"
+                            "$ref": "#/definitions/api.RemoveCountriesReq"
                         }
                     }
                 ],
                 "responses": {
                     "202": {
-                        "description": "Accepted"
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
                     },
                     "422": {
-                        "description": "Unprocessable Entity"
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error"
@@ -231,118 +361,122 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/blacklist/domains/{domain}": {
+        "/v1/blacklist/countries/export": {
             "get": {
-                "description": "check if a domain is blacklisted",
+                "description": "export blacklisted countries as text, csv, or json",
                 "produces": [
+                    "text/plain",
                     "application/json"
                 ],
                 "tags": [
                     "blacklist"
                 ],
-                "summary": "Check blacklisted domain",
+                "summary": "Export blacklisted countries",
                 "parameters": [
                     {
+                        "enum": [
+                            "text",
+                            "csv",
+                            "json"
+                        ],
                         "type": "string",
-                        "description": "domain to check",
-                        "name": "domain",
-                        "in": "path",
-                        "required": true
+                        "default": "text",
+                        "description": "output format",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.CheckDomainResp"
-                        }
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/v1/blacklist/subnets": {
-            "get": {
-                "description": "get all blacklisted subnets",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "blacklist"
-                ],
-                "summary": "Get blacklisted subnets",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.GetSubnetsResp"
-                        }
-                    }
-                }
-            },
+        "/v1/blacklist/countries/import": {
             "post": {
-                "description": "upsert subnets to blacklist",
+                "description": "import countries to blacklist from text, csv, or json, replace mode removes the missing ones",
                 "consumes": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "blacklist"
                 ],
-                "summary": "Upsert blacklisted subnets",
+                "summary": "Import blacklisted countries",
                 "parameters": [
                     {
-                        "description": "subnets to add",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.UpsertSubnetsReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "202": {
-                        "description": "Accepted"
+                        "enum": [
+                            "text",
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "text",
+                        "description": "body format",
+                        "name": "format",
+                        "in": "query"
                     },
-                    "400": {
-                        "description": "Bad Request"
+                    {
+                        "enum": [
+                            "merge",
+                            "replace"
+                        ],
+                        "type": "string",
+                        "default": "merge",
+                        "description": "import mode",
+                        "name": "mode",
+                        "in": "query"
                     },
-                    "422": {
-                        "description": "Unprocessable Entity"
+                    {
+                        "type": "integer",
+                        "description": "entries ttl (in seconds)",
+                        "name": "ttl",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error"
-                    }
-                }
-            },
-            "delete": {
-                "description": "remove subnets from blacklist",
-                "consumes": [
-                    "application/json"
-                ],
-                "tags": [
-                    "blacklist"
-                ],
-                "summary": "Remove blacklisted subnets",
-                "parameters": [
                     {
-                        "description": "subnets to remove",
+                        "type": "string",
+                        "description": "entries comment",
+                        "name": "comment",
+                        "in": "query"
+                    },
+                    {
+                        "description": "countries to import",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.RemoveSubnetsReq"
+                            "type": "string"
                         }
                     }
                 ],
                 "responses": {
                     "202": {
-                        "description": "Accepted"
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResp"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
                     },
+                    "413": {
+                        "description": "Request Entity Too Large"
+                    },
                     "422": {
-                        "description": "Unprocessable Entity"
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResp"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error"
@@ -350,21 +484,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/blacklist/subnets/{subnet}": {
+        "/v1/blacklist/countries/{country}": {
             "get": {
-                "description": "check if a subnet is blacklisted",
+                "description": "check if a country is blacklisted",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "blacklist"
                 ],
-                "summary": "Check blacklisted subnet",
+                "summary": "Check blacklisted country",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "subnet to check",
-                        "name": "subnet",
+                        "description": "country to check",
+                        "name": "country",
                         "in": "path",
                         "required": true
                     }
@@ -373,43 +507,97 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.CheckSubnetResp"
+                            "$ref": "#/definitions/api.CheckCountryResp"
                         }
-                    },
-                    "400": {
-                        "description": "Bad Request"
                     }
                 }
             }
         },
-        "/v1/whitelist/domains": {
+        "/v1/blacklist/domains": {
             "get": {
-                "description": "get all whitelisted domains",
+                "description": "get blacklisted domains, paginated if limit is set",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "whitelist"
+                    "blacklist"
+                ],
+                "summary": "Get blacklisted domains",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "domain substring",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "domain prefix",
+                        "name": "prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next page cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "value",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "default": "value",
+                        "description": "sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get whitelisted domains",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/api.GetDomainsResp"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             },
             "post": {
-                "description": "upsert domains to whitelist",
+                "description": "upsert domains to blacklist",
                 "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "whitelist"
+                "produces": [
+                    "application/json"
                 ],
-                "summary": "Upsert whitelisted domains",
+                "tags": [
+                    "blacklist"
+                ],
+                "summary": "Upsert blacklisted domains",
                 "parameters": [
                     {
                         "description": "domains to add",
@@ -423,13 +611,19 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "202": {
-                        "description": "Accepted"
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
                     },
                     "422": {
-                        "description": "Unprocessable Entity"
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error"
@@ -437,14 +631,17 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "remove domains from whitelist",
+                "description": "remove domains from blacklist",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "whitelist"
+                    "blacklist"
                 ],
-                "summary": "Remove whitelisted domains",
+                "summary": "Remove blacklisted domains",
                 "parameters": [
                     {
                         "description": "domains to remove",
@@ -458,13 +655,19 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "202": {
-                        "description": "Accepted"
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
                     },
                     "422": {
-                        "description": "Unprocessable Entity"
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error"
@@ -472,16 +675,141 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/whitelist/domains/{domain}": {
+        "/v1/blacklist/domains/export": {
             "get": {
-                "description": "check if a domain is whitelisted",
+                "description": "export blacklisted domains as text, csv, hosts, or json",
                 "produces": [
+                    "text/plain",
                     "application/json"
                 ],
                 "tags": [
-                    "whitelist"
+                    "blacklist"
                 ],
-                "summary": "Check whitelisted domain",
+                "summary": "Export blacklisted domains",
+                "parameters": [
+                    {
+                        "enum": [
+                            "text",
+                            "csv",
+                            "hosts",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "text",
+                        "description": "output format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/blacklist/domains/import": {
+            "post": {
+                "description": "import domains to blacklist from text, csv, hosts, or json, replace mode removes the missing ones",
+                "consumes": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blacklist"
+                ],
+                "summary": "Import blacklisted domains",
+                "parameters": [
+                    {
+                        "enum": [
+                            "text",
+                            "csv",
+                            "hosts",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "text",
+                        "description": "body format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "merge",
+                            "replace"
+                        ],
+                        "type": "string",
+                        "default": "merge",
+                        "description": "import mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "entries ttl (in seconds)",
+                        "name": "ttl",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "entries comment",
+                        "name": "comment",
+                        "in": "query"
+                    },
+                    {
+                        "description": "domains to import",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/blacklist/domains/{domain}": {
+            "get": {
+                "description": "check if a domain is blacklisted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blacklist"
+                ],
+                "summary": "Check blacklisted domain",
                 "parameters": [
                     {
                         "type": "string",
@@ -501,54 +829,111 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/whitelist/subnets": {
+        "/v1/blacklist/ja3s": {
             "get": {
-                "description": "get all whitelisted subnets",
+                "description": "get blacklisted ja3 hashes, paginated if limit is set",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "whitelist"
+                    "blacklist"
+                ],
+                "summary": "Get blacklisted ja3s",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ja3 hash substring",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next page cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "value",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "default": "value",
+                        "description": "sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get whitelisted subnets",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.GetSubnetsResp"
+                            "$ref": "#/definitions/api.GetJA3sResp"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             },
             "post": {
-                "description": "upsert subnets to whitelist",
+                "description": "upsert ja3 hashes to blacklist",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "whitelist"
+                    "blacklist"
                 ],
-                "summary": "Upsert whitelisted subnets",
+                "summary": "Upsert blacklisted ja3s",
                 "parameters": [
                     {
-                        "description": "subnets to add",
+                        "description": "ja3 hashes to add",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.UpsertSubnetsReq"
+                            "$ref": "#/definitions/api.UpsertJA3sReq"
                         }
                     }
                 ],
                 "responses": {
                     "202": {
-                        "description": "Accepted"
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
                     },
                     "422": {
-                        "description": "Unprocessable Entity"
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error"
@@ -556,34 +941,43 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "remove subnets from whitelist",
+                "description": "remove ja3 hashes from blacklist",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "whitelist"
+                    "blacklist"
                 ],
-                "summary": "Remove whitelisted subnets",
+                "summary": "Remove blacklisted ja3s",
                 "parameters": [
                     {
-                        "description": "subnets to remove",
+                        "description": "ja3 hashes to remove",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.RemoveSubnetsReq"
+                            "$ref": "#/definitions/api.RemoveJA3sReq"
                         }
                     }
                 ],
                 "responses": {
                     "202": {
-                        "description": "Accepted"
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
                     },
                     "422": {
-                        "description": "Unprocessable Entity"
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error"
@@ -591,21 +985,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/whitelist/subnets/{subnet}": {
+        "/v1/blacklist/ja3s/{hash}": {
             "get": {
-                "description": "check if a subnet is whitelisted",
+                "description": "check if a ja3 hash is blacklisted",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "whitelist"
+                    "blacklist"
                 ],
-                "summary": "Check whitelisted subnet",
+                "summary": "Check blacklisted ja3",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "subnet to check",
-                        "name": "subnet",
+                        "description": "ja3 hash to check",
+                        "name": "hash",
                         "in": "path",
                         "required": true
                     }
@@ -614,82 +1008,2966 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.CheckSubnetResp"
+                            "$ref": "#/definitions/api.CheckJA3Resp"
                         }
-                    },
-                    "400": {
-                        "description": "Bad Request"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "api.CheckCountryResp": {
-            "type": "object",
-            "properties": {
-                "found": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "api.CheckDomainResp": {
-            "type": "object",
-            "properties": {
-                "found": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "api.CheckSubnetResp": {
-            "type": "object",
-            "properties": {
-                "found": {
-                    "type": "boolean"
-                }
-            }
         },
-        "api.GetCountriesResp": {
-            "type": "object",
-            "properties": {
-                "countries": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+        "/v1/blacklist/subnets": {
+            "get": {
+                "description": "get blacklisted subnets, paginated if limit is set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blacklist"
+                ],
+                "summary": "Get blacklisted subnets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "entries covering the ip or subnet",
+                        "name": "ip",
+                        "in": "query"
                     },
-                    "example": [
-                        "fr",
-                        "de"
-                    ]
-                }
-            }
-        },
-        "api.GetDomainsResp": {
-            "type": "object",
-            "properties": {
-                "domains": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
                     },
-                    "example": [
-                        "bad.com",
-                        "dead.com"
-                    ]
-                }
-            }
-        },
-        "api.GetSubnetsResp": {
-            "type": "object",
-            "properties": {
-                "subnets": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+                    {
+                        "type": "string",
+                        "description": "next page cursor",
+                        "name": "cursor",
+                        "in": "query"
                     },
-                    "example": [
-                        "100.100.100.100/32",
-                        "200.200.200.0/24"
+                    {
+                        "enum": [
+                            "value",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "default": "value",
+                        "description": "sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetSubnetsResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "description": "upsert subnets to blacklist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blacklist"
+                ],
+                "summary": "Upsert blacklisted subnets",
+                "parameters": [
+                    {
+                        "description": "subnets to add",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.UpsertSubnetsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove subnets from blacklist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blacklist"
+                ],
+                "summary": "Remove blacklisted subnets",
+                "parameters": [
+                    {
+                        "description": "subnets to remove",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.RemoveSubnetsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/blacklist/subnets/export": {
+            "get": {
+                "description": "export blacklisted subnets as text, csv, or json",
+                "produces": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "tags": [
+                    "blacklist"
+                ],
+                "summary": "Export blacklisted subnets",
+                "parameters": [
+                    {
+                        "enum": [
+                            "text",
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "text",
+                        "description": "output format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/blacklist/subnets/import": {
+            "post": {
+                "description": "import subnets to blacklist from text, csv, or json, replace mode removes the missing ones",
+                "consumes": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blacklist"
+                ],
+                "summary": "Import blacklisted subnets",
+                "parameters": [
+                    {
+                        "enum": [
+                            "text",
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "text",
+                        "description": "body format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "merge",
+                            "replace"
+                        ],
+                        "type": "string",
+                        "default": "merge",
+                        "description": "import mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "entries ttl (in seconds)",
+                        "name": "ttl",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "entries comment",
+                        "name": "comment",
+                        "in": "query"
+                    },
+                    {
+                        "description": "subnets to import",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/blacklist/subnets/{subnet}": {
+            "get": {
+                "description": "check if a subnet is blacklisted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blacklist"
+                ],
+                "summary": "Check blacklisted subnet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "subnet to check",
+                        "name": "subnet",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.CheckSubnetResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    }
+                }
+            }
+        },
+        "/v1/config/reload": {
+            "post": {
+                "description": "re-read config file and rebuild filters in place",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "config"
+                ],
+                "summary": "Reload config",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ReloadConfigResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/events": {
+            "get": {
+                "description": "get stored packet verdict events (newest first)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2025-01-02T15:04:05Z",
+                        "description": "events after the time (RFC3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-01-03T15:04:05Z",
+                        "description": "events before the time (RFC3339)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "accept",
+                            "drop",
+                            "trust"
+                        ],
+                        "type": "string",
+                        "description": "packet action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "ip",
+                        "description": "filter type",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "FireHOL",
+                        "description": "filter name or reason",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1.2.3.4",
+                        "description": "source ip",
+                        "name": "ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "us",
+                        "description": "source country",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 443,
+                        "description": "destination port",
+                        "name": "port",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next page cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetEventsResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/events/stream": {
+            "get": {
+                "description": "stream live packet verdict events over sse (or websocket if upgrade requested), events are dropped if the client is slow",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Stream events",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "accept",
+                                "drop",
+                                "trust"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "packet actions",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "ip",
+                        "description": "filter types",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1.2.3.0/24",
+                        "description": "source ip or subnet",
+                        "name": "ip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.StreamEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    }
+                }
+            }
+        },
+        "/v1/feeds": {
+            "get": {
+                "description": "get all feeds added via api",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Get feeds",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetFeedsResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "description": "add or replace a feed and rebuild filters",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Upsert feed",
+                "parameters": [
+                    {
+                        "description": "feed to add",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.UpsertFeedReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove a feed added via api and rebuild filters",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Remove feed",
+                "parameters": [
+                    {
+                        "description": "feed to remove",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.RemoveFeedReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/filters": {
+            "get": {
+                "description": "get all running filters with their update status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "filters"
+                ],
+                "summary": "Get filters",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetFiltersResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/filters/{name}": {
+            "put": {
+                "description": "enable or disable a filter (persisted)",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "filters"
+                ],
+                "summary": "Set filter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "StevenBlack",
+                        "description": "filter name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "filter state",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SetFilterReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/filters/{name}/history": {
+            "get": {
+                "description": "get recorded filter list changes (newest first)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "filters"
+                ],
+                "summary": "Get filter history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "FireHOL",
+                        "description": "filter name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetFilterHistoryResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/filters/{name}/rollback": {
+            "post": {
+                "description": "restore the filter list before the last recorded change",
+                "tags": [
+                    "filters"
+                ],
+                "summary": "Rollback filter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "FireHOL",
+                        "description": "filter name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/filters/{name}/update": {
+            "post": {
+                "description": "schedule an immediate filter update",
+                "tags": [
+                    "filters"
+                ],
+                "summary": "Update filter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "FireHOL",
+                        "description": "filter name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/filters/{type}/{name}": {
+            "put": {
+                "description": "enable or disable a filter (persisted)",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "filters"
+                ],
+                "summary": "Set filter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "domain",
+                        "description": "filter type (may be omitted if the name is unique)",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "StevenBlack",
+                        "description": "filter name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "filter state",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SetFilterReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/filters/{type}/{name}/history": {
+            "get": {
+                "description": "get recorded filter list changes (newest first)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "filters"
+                ],
+                "summary": "Get filter history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ip",
+                        "description": "filter type (may be omitted if the name is unique)",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "FireHOL",
+                        "description": "filter name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetFilterHistoryResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/filters/{type}/{name}/rollback": {
+            "post": {
+                "description": "restore the filter list before the last recorded change",
+                "tags": [
+                    "filters"
+                ],
+                "summary": "Rollback filter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ip",
+                        "description": "filter type (may be omitted if the name is unique)",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "FireHOL",
+                        "description": "filter name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/filters/{type}/{name}/update": {
+            "post": {
+                "description": "schedule an immediate filter update",
+                "tags": [
+                    "filters"
+                ],
+                "summary": "Update filter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ip",
+                        "description": "filter type (may be omitted if the name is unique)",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "FireHOL",
+                        "description": "filter name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/ja3s/top": {
+            "get": {
+                "description": "get most frequent ja3 hashes observed by the ja3 filters (approximate)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ja3"
+                ],
+                "summary": "Get top ja3 hashes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "max hashes",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetTopJA3sResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    }
+                }
+            }
+        },
+        "/v1/lookup": {
+            "get": {
+                "description": "explain the filters verdicts on a synthetic inbound tcp packet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lookup"
+                ],
+                "summary": "Lookup packet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "1.2.3.4",
+                        "description": "source ipv4 (ipv6 is not filtered)",
+                        "name": "ip",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "bad.com",
+                        "description": "tls server name",
+                        "name": "sni",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "e7d705a3286e19ea42f587b344ee6865",
+                        "description": "tls ja3 fingerprint",
+                        "name": "ja3",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 443,
+                        "description": "destination port",
+                        "name": "port",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.LookupResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "422": {
+                        "description": "not an ipv4 address",
+                        "schema": {
+                            "$ref": "#/definitions/api.LookupErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/top": {
+            "get": {
+                "description": "get most frequent dropped packets source ips, subnets, asns, countries, domains, ja3 hashes and destination ports (approximate)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get top stats",
+                "parameters": [
+                    {
+                        "enum": [
+                            "hour",
+                            "day"
+                        ],
+                        "type": "string",
+                        "default": "hour",
+                        "description": "time window",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ip",
+                            "subnet",
+                            "asn",
+                            "country",
+                            "domain",
+                            "ja3",
+                            "port"
+                        ],
+                        "type": "string",
+                        "description": "counted kind (all if empty)",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "max keys (per kind)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetTopStatsResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    }
+                }
+            }
+        },
+        "/v1/whitelist/asns": {
+            "get": {
+                "description": "get whitelisted asns with their names (if known), paginated if limit is set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Get whitelisted asns",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next page cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "value",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "default": "value",
+                        "description": "sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetASNsResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "description": "upsert asns to whitelist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Upsert whitelisted asns",
+                "parameters": [
+                    {
+                        "description": "asns to add",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.UpsertASNsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove asns from whitelist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Remove whitelisted asns",
+                "parameters": [
+                    {
+                        "description": "asns to remove",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.RemoveASNsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/whitelist/asns/{asn}": {
+            "get": {
+                "description": "check if an asn is whitelisted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Check whitelisted asn",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "AS13335",
+                        "description": "asn to check",
+                        "name": "asn",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.CheckASNResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    }
+                }
+            }
+        },
+        "/v1/whitelist/countries": {
+            "get": {
+                "description": "get whitelisted countries (only they are allowed if not empty), paginated if limit is set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Get whitelisted countries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "country substring",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next page cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "value",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "default": "value",
+                        "description": "sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetCountriesResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "description": "upsert countries to whitelist (enables the allowlist mode)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Upsert whitelisted countries",
+                "parameters": [
+                    {
+                        "description": "countries to add",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.UpsertCountriesReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove countries from whitelist (the allowlist mode is disabled if empty)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Remove whitelisted countries",
+                "parameters": [
+                    {
+                        "description": "countries to remove",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.RemoveCountriesReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/whitelist/countries/export": {
+            "get": {
+                "description": "export whitelisted countries as text, csv, or json",
+                "produces": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Export whitelisted countries",
+                "parameters": [
+                    {
+                        "enum": [
+                            "text",
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "text",
+                        "description": "output format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/whitelist/countries/import": {
+            "post": {
+                "description": "import countries to whitelist from text, csv, or json, replace mode removes the missing ones",
+                "consumes": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Import whitelisted countries",
+                "parameters": [
+                    {
+                        "enum": [
+                            "text",
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "text",
+                        "description": "body format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "merge",
+                            "replace"
+                        ],
+                        "type": "string",
+                        "default": "merge",
+                        "description": "import mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "entries ttl (in seconds)",
+                        "name": "ttl",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "entries comment",
+                        "name": "comment",
+                        "in": "query"
+                    },
+                    {
+                        "description": "countries to import",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/whitelist/countries/{country}": {
+            "get": {
+                "description": "check if a country is whitelisted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Check whitelisted country",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "country to check",
+                        "name": "country",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.CheckCountryResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/whitelist/domains": {
+            "get": {
+                "description": "get whitelisted domains, paginated if limit is set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Get whitelisted domains",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "domain substring",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "domain prefix",
+                        "name": "prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next page cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "value",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "default": "value",
+                        "description": "sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetDomainsResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "description": "upsert domains to whitelist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Upsert whitelisted domains",
+                "parameters": [
+                    {
+                        "description": "domains to add",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.UpsertDomainsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove domains from whitelist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Remove whitelisted domains",
+                "parameters": [
+                    {
+                        "description": "domains to remove",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.RemoveDomainsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/whitelist/domains/export": {
+            "get": {
+                "description": "export whitelisted domains as text, csv, hosts, or json",
+                "produces": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Export whitelisted domains",
+                "parameters": [
+                    {
+                        "enum": [
+                            "text",
+                            "csv",
+                            "hosts",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "text",
+                        "description": "output format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/whitelist/domains/import": {
+            "post": {
+                "description": "import domains to whitelist from text, csv, hosts, or json, replace mode removes the missing ones",
+                "consumes": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Import whitelisted domains",
+                "parameters": [
+                    {
+                        "enum": [
+                            "text",
+                            "csv",
+                            "hosts",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "text",
+                        "description": "body format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "merge",
+                            "replace"
+                        ],
+                        "type": "string",
+                        "default": "merge",
+                        "description": "import mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "entries ttl (in seconds)",
+                        "name": "ttl",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "entries comment",
+                        "name": "comment",
+                        "in": "query"
+                    },
+                    {
+                        "description": "domains to import",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/whitelist/domains/{domain}": {
+            "get": {
+                "description": "check if a domain is whitelisted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Check whitelisted domain",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "domain to check",
+                        "name": "domain",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.CheckDomainResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/whitelist/ja3s": {
+            "get": {
+                "description": "get whitelisted ja3 hashes, paginated if limit is set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Get whitelisted ja3s",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ja3 hash substring",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next page cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "value",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "default": "value",
+                        "description": "sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetJA3sResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "description": "upsert ja3 hashes to whitelist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Upsert whitelisted ja3s",
+                "parameters": [
+                    {
+                        "description": "ja3 hashes to add",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.UpsertJA3sReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove ja3 hashes from whitelist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Remove whitelisted ja3s",
+                "parameters": [
+                    {
+                        "description": "ja3 hashes to remove",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.RemoveJA3sReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/whitelist/ja3s/{hash}": {
+            "get": {
+                "description": "check if a ja3 hash is whitelisted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Check whitelisted ja3",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ja3 hash to check",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.CheckJA3Resp"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/whitelist/subnets": {
+            "get": {
+                "description": "get whitelisted subnets, paginated if limit is set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Get whitelisted subnets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "entries covering the ip or subnet",
+                        "name": "ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next page cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "value",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "default": "value",
+                        "description": "sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetSubnetsResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "description": "upsert subnets to whitelist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Upsert whitelisted subnets",
+                "parameters": [
+                    {
+                        "description": "subnets to add",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.UpsertSubnetsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove subnets from whitelist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Remove whitelisted subnets",
+                "parameters": [
+                    {
+                        "description": "subnets to remove",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.RemoveSubnetsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.MutateResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/whitelist/subnets/export": {
+            "get": {
+                "description": "export whitelisted subnets as text, csv, or json",
+                "produces": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Export whitelisted subnets",
+                "parameters": [
+                    {
+                        "enum": [
+                            "text",
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "text",
+                        "description": "output format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/whitelist/subnets/import": {
+            "post": {
+                "description": "import subnets to whitelist from text, csv, or json, replace mode removes the missing ones",
+                "consumes": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Import whitelisted subnets",
+                "parameters": [
+                    {
+                        "enum": [
+                            "text",
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "text",
+                        "description": "body format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "merge",
+                            "replace"
+                        ],
+                        "type": "string",
+                        "default": "merge",
+                        "description": "import mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "entries ttl (in seconds)",
+                        "name": "ttl",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "entries comment",
+                        "name": "comment",
+                        "in": "query"
+                    },
+                    {
+                        "description": "subnets to import",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/whitelist/subnets/{subnet}": {
+            "get": {
+                "description": "check if a subnet is whitelisted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "whitelist"
+                ],
+                "summary": "Check whitelisted subnet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "subnet to check",
+                        "name": "subnet",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.CheckSubnetResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.ASN": {
+            "type": "object",
+            "properties": {
+                "asn": {
+                    "type": "integer",
+                    "example": 13335
+                },
+                "comment": {
+                    "type": "string",
+                    "example": "port scanner"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "last_hit_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Cloudflare, Inc."
+                },
+                "source": {
+                    "type": "string",
+                    "example": "api"
+                },
+                "ttl": {
+                    "description": "TTL is the remaining seconds of the temporary entry",
+                    "type": "integer",
+                    "example": 3600
+                }
+            }
+        },
+        "api.CheckASNResp": {
+            "type": "object",
+            "properties": {
+                "found": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Cloudflare, Inc."
+                }
+            }
+        },
+        "api.CheckCountryResp": {
+            "type": "object",
+            "properties": {
+                "found": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.CheckDomainResp": {
+            "type": "object",
+            "properties": {
+                "found": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.CheckJA3Resp": {
+            "type": "object",
+            "properties": {
+                "found": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.CheckSubnetResp": {
+            "type": "object",
+            "properties": {
+                "found": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.Entry": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "port scanner"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "last_hit_at": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "api"
+                },
+                "ttl": {
+                    "description": "TTL is the remaining seconds of the temporary entry",
+                    "type": "integer",
+                    "example": 3600
+                }
+            }
+        },
+        "api.Event": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "accept",
+                        "drop",
+                        "trust"
+                    ],
+                    "example": "drop"
+                },
+                "asn": {
+                    "type": "integer",
+                    "example": 13335
+                },
+                "country": {
+                    "type": "string",
+                    "example": "us"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "filter": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/filter.FilterType"
+                        }
+                    ],
+                    "example": "ip"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "1.2.3.4"
+                },
+                "port": {
+                    "type": "integer",
+                    "example": 443
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "FireHOL"
+                },
+                "target": {
+                    "description": "Target is the packet part checked by the filter",
+                    "type": "string",
+                    "example": "1.2.3.4"
+                }
+            }
+        },
+        "api.FilterHistory": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "1.2.3.0/24"
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "removed": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "5.6.7.8/32"
+                    ]
+                }
+            }
+        },
+        "api.FilterStatus": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "type": "integer",
+                    "example": 120000
+                },
+                "duration": {
+                    "type": "string",
+                    "example": "1.5s"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "entries": {
+                    "type": "integer",
+                    "example": 4500
+                },
+                "error": {
+                    "type": "string"
+                },
+                "last_attempt": {
+                    "type": "string"
+                },
+                "last_success": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "FireHOL"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/filter.FilterType"
+                        }
+                    ],
+                    "example": "ip"
+                },
+                "updating": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.GetASNsResp": {
+            "type": "object",
+            "properties": {
+                "asns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ASN"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.GetCountriesResp": {
+            "type": "object",
+            "properties": {
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "fr",
+                        "de"
+                    ]
+                },
+                "entries": {
+                    "description": "Entries are the entries metadata by their values",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/api.Entry"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.GetDomainsResp": {
+            "type": "object",
+            "properties": {
+                "domains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "bad.com",
+                        "dead.com"
+                    ]
+                },
+                "entries": {
+                    "description": "Entries are the entries metadata by their values",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/api.Entry"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.GetEventsResp": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.Event"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.GetFeedsResp": {
+            "type": "object",
+            "properties": {
+                "feeds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/feed.Feed"
+                    }
+                }
+            }
+        },
+        "api.GetFilterHistoryResp": {
+            "type": "object",
+            "properties": {
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.FilterHistory"
+                    }
+                }
+            }
+        },
+        "api.GetFiltersResp": {
+            "type": "object",
+            "properties": {
+                "filters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.FilterStatus"
+                    }
+                }
+            }
+        },
+        "api.GetJA3sResp": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "description": "Entries are the entries metadata by their values",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/api.Entry"
+                    }
+                },
+                "ja3s": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "e7d705a3286e19ea42f587b344ee6865",
+                        "6734f37431670b3ab4292b8f60f29984"
+                    ]
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.GetSubnetsResp": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "description": "Entries are the entries metadata by their values",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/api.Entry"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "subnets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "100.100.100.100/32",
+                        "200.200.200.0/24"
+                    ]
+                }
+            }
+        },
+        "api.GetTopJA3sResp": {
+            "type": "object",
+            "properties": {
+                "ja3s": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TopJA3"
+                    }
+                }
+            }
+        },
+        "api.GetTopStatsResp": {
+            "type": "object",
+            "properties": {
+                "top": {
+                    "description": "Top are the counts by kind (most frequent first)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/api.TopCount"
+                        }
+                    }
+                },
+                "window": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/stats.Window"
+                        }
+                    ],
+                    "example": "hour"
+                }
+            }
+        },
+        "api.ImportResp": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "invalid": {
+                    "description": "Invalid are the rejected entries, nothing is imported if any",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.Result"
+                    }
+                },
+                "removed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "api.LookupErrorResp": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "only ipv4 packets are filtered: 2001:db8::1"
+                }
+            }
+        },
+        "api.LookupResp": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "accept",
+                        "drop"
+                    ],
+                    "example": "drop"
+                },
+                "asn": {
+                    "type": "integer",
+                    "example": 13335
+                },
+                "asn_name": {
+                    "type": "string",
+                    "example": "Cloudflare, Inc."
+                },
+                "country": {
+                    "type": "string",
+                    "example": "us"
+                },
+                "name": {
+                    "description": "Name and Type are the decisive filter, \"default\" if accepted by default",
+                    "type": "string",
+                    "example": "FireHOL"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/filter.FilterType"
+                        }
+                    ],
+                    "example": "ip"
+                },
+                "verdicts": {
+                    "description": "Verdicts are in the pipeline order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.Verdict"
+                    }
+                }
+            }
+        },
+        "api.MutateResp": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.Result"
+                    }
+                }
+            }
+        },
+        "api.ReloadConfigResp": {
+            "type": "object",
+            "properties": {
+                "rebuild": {
+                    "type": "boolean"
+                },
+                "reloaded": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "rate-limiter-rate",
+                        "feeds"
+                    ]
+                },
+                "restart": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "readers-count"
+                    ]
+                }
+            }
+        },
+        "api.RemoveASNsReq": {
+            "type": "object",
+            "properties": {
+                "asns": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        13335,
+                        15169
                     ]
                 }
             }
@@ -724,6 +4002,38 @@ const docTemplate = `{
                 }
             }
         },
+        "api.RemoveFeedReq": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Spamhaus"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/filter.FilterType"
+                        }
+                    ],
+                    "example": "ip"
+                }
+            }
+        },
+        "api.RemoveJA3sReq": {
+            "type": "object",
+            "properties": {
+                "ja3s": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "e7d705a3286e19ea42f587b344ee6865",
+                        "6734f37431670b3ab4292b8f60f29984"
+                    ]
+                }
+            }
+        },
         "api.RemoveSubnetsReq": {
             "type": "object",
             "properties": {
@@ -739,9 +4049,169 @@ const docTemplate = `{
                 }
             }
         },
+        "api.Result": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "updated",
+                        "removed",
+                        "not_found",
+                        "invalid"
+                    ],
+                    "example": "created"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "100.100.100.100/32"
+                }
+            }
+        },
+        "api.SetFilterReq": {
+            "type": "object",
+            "required": [
+                "enabled"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "api.StreamEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "accept",
+                        "drop",
+                        "trust"
+                    ],
+                    "example": "drop"
+                },
+                "asn": {
+                    "type": "integer",
+                    "example": 13335
+                },
+                "country": {
+                    "type": "string",
+                    "example": "us"
+                },
+                "dropped": {
+                    "description": "Dropped is the events count dropped since the previous message (slow client)",
+                    "type": "integer",
+                    "example": 0
+                },
+                "filter": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/filter.FilterType"
+                        }
+                    ],
+                    "example": "ip"
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "1.2.3.4"
+                },
+                "port": {
+                    "type": "integer",
+                    "example": 443
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "FireHOL"
+                },
+                "target": {
+                    "description": "Target is the packet part checked by the filter",
+                    "type": "string",
+                    "example": "1.2.3.4"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.TopCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "key": {
+                    "type": "string",
+                    "example": "1.2.3.4"
+                }
+            }
+        },
+        "api.TopJA3": {
+            "type": "object",
+            "properties": {
+                "blacklisted": {
+                    "type": "boolean"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "hash": {
+                    "type": "string",
+                    "example": "e7d705a3286e19ea42f587b344ee6865"
+                },
+                "whitelisted": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.UpsertASNsReq": {
+            "type": "object",
+            "properties": {
+                "asns": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        13335,
+                        15169
+                    ]
+                },
+                "comment": {
+                    "type": "string",
+                    "example": "port scanner"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2030-01-01T00:00:00Z"
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "api",
+                        "auto",
+                        "import"
+                    ],
+                    "example": "api"
+                },
+                "ttl": {
+                    "description": "TTL (in seconds) or ExpiresAt makes the entries temporary",
+                    "type": "integer",
+                    "example": 3600
+                }
+            }
+        },
         "api.UpsertCountriesReq": {
             "type": "object",
             "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "port scanner"
+                },
                 "countries": {
                     "type": "array",
                     "items": {
@@ -751,12 +4221,34 @@ const docTemplate = `{
                         "fr",
                         "de"
                     ]
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2030-01-01T00:00:00Z"
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "api",
+                        "auto",
+                        "import"
+                    ],
+                    "example": "api"
+                },
+                "ttl": {
+                    "description": "TTL (in seconds) or ExpiresAt makes the entries temporary",
+                    "type": "integer",
+                    "example": 3600
                 }
             }
         },
         "api.UpsertDomainsReq": {
             "type": "object",
             "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "port scanner"
+                },
                 "domains": {
                     "type": "array",
                     "items": {
@@ -766,12 +4258,120 @@ const docTemplate = `{
                         "bad.com",
                         "dead.com"
                     ]
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2030-01-01T00:00:00Z"
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "api",
+                        "auto",
+                        "import"
+                    ],
+                    "example": "api"
+                },
+                "ttl": {
+                    "description": "TTL (in seconds) or ExpiresAt makes the entries temporary",
+                    "type": "integer",
+                    "example": 3600
+                }
+            }
+        },
+        "api.UpsertFeedReq": {
+            "type": "object",
+            "properties": {
+                "interval": {
+                    "description": "overrides the default update interval",
+                    "type": "integer",
+                    "example": 3600000000000
+                },
+                "limits": {
+                    "description": "updates violating the limits are refused",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/feed.Limits"
+                        }
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Spamhaus"
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/feed.Source"
+                    }
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/filter.FilterType"
+                        }
+                    ],
+                    "example": "ip"
+                }
+            }
+        },
+        "api.UpsertJA3sReq": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "port scanner"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2030-01-01T00:00:00Z"
+                },
+                "ja3s": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "e7d705a3286e19ea42f587b344ee6865",
+                        "6734f37431670b3ab4292b8f60f29984"
+                    ]
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "api",
+                        "auto",
+                        "import"
+                    ],
+                    "example": "api"
+                },
+                "ttl": {
+                    "description": "TTL (in seconds) or ExpiresAt makes the entries temporary",
+                    "type": "integer",
+                    "example": 3600
                 }
             }
         },
         "api.UpsertSubnetsReq": {
             "type": "object",
             "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "port scanner"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2030-01-01T00:00:00Z"
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "api",
+                        "auto",
+                        "import"
+                    ],
+                    "example": "api"
+                },
                 "subnets": {
                     "type": "array",
                     "items": {
@@ -781,8 +4381,194 @@ const docTemplate = `{
                         "100.100.100.100",
                         "200.200.200.0/24"
                     ]
+                },
+                "ttl": {
+                    "description": "TTL (in seconds) or ExpiresAt makes the entries temporary",
+                    "type": "integer",
+                    "example": 3600
+                }
+            }
+        },
+        "api.Verdict": {
+            "type": "object",
+            "properties": {
+                "decisive": {
+                    "type": "boolean"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "match": {
+                    "description": "Match is the matched list entry",
+                    "type": "string",
+                    "example": "1.2.3.0/24"
+                },
+                "matched": {
+                    "description": "Matched means the packet is in the filter list",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "FireHOL"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/filter.FilterType"
+                        }
+                    ],
+                    "example": "ip"
+                }
+            }
+        },
+        "feed.Feed": {
+            "type": "object",
+            "properties": {
+                "interval": {
+                    "description": "overrides the default update interval",
+                    "type": "integer",
+                    "example": 3600000000000
+                },
+                "limits": {
+                    "description": "updates violating the limits are refused",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/feed.Limits"
+                        }
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Spamhaus"
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/feed.Source"
+                    }
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/filter.FilterType"
+                        }
+                    ],
+                    "example": "ip"
                 }
             }
+        },
+        "feed.Format": {
+            "type": "string",
+            "enum": [
+                "netset",
+                "hosts",
+                "csv",
+                "jsonl",
+                "plain"
+            ],
+            "x-enum-varnames": [
+                "FormatNetset",
+                "FormatHosts",
+                "FormatCSV",
+                "FormatJSONL",
+                "FormatPlain"
+            ]
+        },
+        "feed.Limits": {
+            "type": "object",
+            "properties": {
+                "max_entries": {
+                    "description": "max entries count (0 - unlimited)",
+                    "type": "integer",
+                    "example": 100000
+                },
+                "min_prefix_v4": {
+                    "description": "refuse lists with wider subnets outside the special-purpose ranges (ip feeds only, 0 - /8 and /16)",
+                    "type": "integer",
+                    "example": 8
+                },
+                "min_prefix_v6": {
+                    "type": "integer",
+                    "example": 16
+                },
+                "no_whitelisted": {
+                    "description": "refuse lists intersecting the whitelist (ip feeds only)",
+                    "type": "boolean"
+                }
+            }
+        },
+        "feed.Source": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "description": "url of a published checksum file (sha256)",
+                    "type": "string"
+                },
+                "column": {
+                    "description": "csv column (starting from 0)",
+                    "type": "integer"
+                },
+                "field": {
+                    "description": "jsonl field name",
+                    "type": "string"
+                },
+                "format": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/feed.Format"
+                        }
+                    ],
+                    "example": "netset"
+                },
+                "public_key": {
+                    "description": "minisign public key",
+                    "type": "string"
+                },
+                "signature": {
+                    "description": "url of a detached minisign signature",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.spamhaus.org/drop/drop.txt"
+                },
+                "zip": {
+                    "description": "list is packed into a zip archive",
+                    "type": "boolean"
+                }
+            }
+        },
+        "filter.FilterType": {
+            "type": "string",
+            "enum": [
+                "empty",
+                "ip",
+                "geo",
+                "asn",
+                "ja3",
+                "rate",
+                "domain"
+            ],
+            "x-enum-varnames": [
+                "FilterTypeEmpty",
+                "FilterTypeIP",
+                "FilterTypeGeo",
+                "FilterTypeASN",
+                "FilterTypeJA3",
+                "FilterTypeRate",
+                "FilterTypeDomain"
+            ]
+        },
+        "stats.Window": {
+            "type": "string",
+            "enum": [
+                "hour",
+                "day"
+            ],
+            "x-enum-varnames": [
+                "WindowHour",
+                "WindowDay"
+            ]
         }
     }
 }`
@@ -11,6 +11,7 @@ package api
 //	@license.url	https://opensource.org/licenses/MIT
 
 import (
+	"context"
 	"sync"
 
 	"github.com/gin-gonic/gin"
@@ -21,7 +22,12 @@ import (
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
+	"github.com/cnaize/meds/src/config"
+	"github.com/cnaize/meds/src/core"
+	"github.com/cnaize/meds/src/core/filter"
 	"github.com/cnaize/meds/src/core/metrics"
+	"github.com/cnaize/meds/src/core/stats"
+	"github.com/cnaize/meds/src/core/stream"
 	"github.com/cnaize/meds/src/database"
 	"github.com/cnaize/meds/src/types"
 )
@@ -32,17 +38,41 @@ var (
 	subnetBlackListMu  sync.Mutex
 	domainWhiteListMu  sync.Mutex
 	domainBlackListMu  sync.Mutex
+	countryWhiteListMu sync.Mutex
 	countryBlackListMu sync.Mutex
+	asnWhiteListMu     sync.Mutex
+	asnBlackListMu     sync.Mutex
+	ja3WhiteListMu     sync.Mutex
+	ja3BlackListMu     sync.Mutex
+	feedsMu            sync.Mutex
+	filtersMu          sync.Mutex
 )
 
 func Register(
+	ctx context.Context,
 	r *gin.Engine,
 	db *database.Database,
+	reloadFn func(ctx context.Context) (config.Diff, error),
+	rebuildFn func(ctx context.Context) error,
+	statusFn func() []core.FilterStatus,
+	updateFn func(typ filter.FilterType, name string) error,
+	enableFn func(typ filter.FilterType, name string, enabled bool) error,
+	rollbackFn func(ctx context.Context, typ filter.FilterType, name string) error,
+	explainFn func(packet *types.Packet) core.Explanation,
 	subnetWhiteList *types.SubnetList,
 	subnetBlackList *types.SubnetList,
 	domainWhiteList *types.DomainList,
 	domainBlackList *types.DomainList,
+	countryWhiteList *types.CountryList,
 	countryBlackList *types.CountryList,
+	asnList *types.ASNList,
+	asnWhiteList *types.ASNumberList,
+	asnBlackList *types.ASNumberList,
+	ja3WhiteList *types.JA3List,
+	ja3BlackList *types.JA3List,
+	ja3Observed *types.TopCounter,
+	top *stats.Top,
+	hub *stream.Hub,
 ) {
 	// register prometheus metrics
 	reg := prometheus.NewRegistry()
@@ -54,39 +84,120 @@ func Register(
 
 	root := r.Group("/v1")
 
+	// register config api
+	cfg := root.Group("/config")
+	cfg.POST("/reload", ReloadConfig(reloadFn))
+
+	// register filters api
+	filters := root.Group("/filters")
+	filters.GET("", GetFilters(statusFn))
+	filters.PUT("/:type/:name", SetFilter(&filtersMu, db, statusFn, enableFn))
+	filters.POST("/:type/:name/update", UpdateFilter(updateFn))
+	filters.GET("/:type/:name/history", GetFilterHistory(db))
+	filters.POST("/:type/:name/rollback", RollbackFilter(rollbackFn))
+	// NOTE: the type may be omitted if the name is unique
+	filters.PUT("/:type", FilterByName(statusFn), SetFilter(&filtersMu, db, statusFn, enableFn))
+	filters.POST("/:type/update", FilterByName(statusFn), UpdateFilter(updateFn))
+	filters.GET("/:type/history", FilterByName(statusFn), GetFilterHistory(db))
+	filters.POST("/:type/rollback", FilterByName(statusFn), RollbackFilter(rollbackFn))
+
+	// register feeds api
+	feeds := root.Group("/feeds")
+	feeds.GET("", GetFeeds(&feedsMu, db))
+	feeds.POST("", UpsertFeed(&feedsMu, db, rebuildFn))
+	feeds.DELETE("", RemoveFeed(&feedsMu, db, rebuildFn))
+
+	// register events api
+	events := root.Group("/events")
+	events.GET("", GetEvents(db))
+	events.GET("/stream", StreamEvents(ctx, hub))
+
+	// register stats api
+	st := root.Group("/stats")
+	st.GET("/top", GetTopStats(top))
+
+	// register lookup api
+	root.GET("/lookup", Lookup(explainFn, asnList))
+
+	// register ja3 api
+	ja3s := root.Group("/ja3s")
+	ja3s.GET("/top", GetTopJA3s(ja3Observed, ja3WhiteList, ja3BlackList))
+
 	// register whitelist api
 	whitelist := root.Group("/whitelist")
 	// register subnet whitelist
 	snWhiteList := whitelist.Group("/subnets")
-	snWhiteList.GET("", GetWhiteListSubnets(subnetWhiteList, &subnetWhiteListMu))
+	snWhiteList.GET("", GetWhiteListSubnets(db))
 	snWhiteList.GET("/:subnet", CheckWhiteListSubnet(subnetWhiteList, &subnetWhiteListMu))
 	snWhiteList.POST("", UpsertWhiteListSubnets(subnetWhiteList, &subnetWhiteListMu, db))
 	snWhiteList.DELETE("", RemoveWhiteListSubnets(subnetWhiteList, &subnetWhiteListMu, db))
+	snWhiteList.POST("/import", ImportWhiteListSubnets(subnetWhiteList, &subnetWhiteListMu, db))
+	snWhiteList.GET("/export", ExportWhiteListSubnets(db))
 	// register domain whitelist
 	dmWhiteList := whitelist.Group("/domains")
-	dmWhiteList.GET("", GetWhiteListDomains(domainWhiteList, &domainWhiteListMu))
+	dmWhiteList.GET("", GetWhiteListDomains(db))
 	dmWhiteList.GET("/:domain", CheckWhiteListDomain(domainWhiteList, &domainWhiteListMu))
 	dmWhiteList.POST("", UpsertWhiteListDomains(domainWhiteList, &domainWhiteListMu, db))
 	dmWhiteList.DELETE("", RemoveWhiteListDomains(domainWhiteList, &domainWhiteListMu, db))
+	dmWhiteList.POST("/import", ImportWhiteListDomains(domainWhiteList, &domainWhiteListMu, db))
+	dmWhiteList.GET("/export", ExportWhiteListDomains(db))
+	// register country whitelist
+	crWhiteList := whitelist.Group("/countries")
+	crWhiteList.GET("", GetWhiteListCountries(db))
+	crWhiteList.GET("/:country", CheckWhiteListCountry(countryWhiteList, &countryWhiteListMu))
+	crWhiteList.POST("", UpsertWhiteListCountries(countryWhiteList, &countryWhiteListMu, db))
+	crWhiteList.DELETE("", RemoveWhiteListCountries(countryWhiteList, &countryWhiteListMu, db))
+	crWhiteList.POST("/import", ImportWhiteListCountries(countryWhiteList, &countryWhiteListMu, db))
+	crWhiteList.GET("/export", ExportWhiteListCountries(db))
+	// register asn whitelist
+	asWhiteList := whitelist.Group("/asns")
+	asWhiteList.GET("", GetWhiteListASNs(db, asnList))
+	asWhiteList.GET("/:asn", CheckWhiteListASN(asnWhiteList, &asnWhiteListMu, asnList))
+	asWhiteList.POST("", UpsertWhiteListASNs(asnWhiteList, &asnWhiteListMu, db))
+	asWhiteList.DELETE("", RemoveWhiteListASNs(asnWhiteList, &asnWhiteListMu, db))
+	// register ja3 whitelist
+	jaWhiteList := whitelist.Group("/ja3s")
+	jaWhiteList.GET("", GetWhiteListJA3s(db))
+	jaWhiteList.GET("/:hash", CheckWhiteListJA3(ja3WhiteList, &ja3WhiteListMu))
+	jaWhiteList.POST("", UpsertWhiteListJA3s(ja3WhiteList, &ja3WhiteListMu, db))
+	jaWhiteList.DELETE("", RemoveWhiteListJA3s(ja3WhiteList, &ja3WhiteListMu, db))
 
 	// register blacklist api
 	blacklist := root.Group("/blacklist")
 	// register subnet blacklist
 	snBlackList := blacklist.Group("/subnets")
-	snBlackList.GET("", GetBlackListSubnets(subnetBlackList, &subnetBlackListMu))
+	snBlackList.GET("", GetBlackListSubnets(db))
 	snBlackList.GET("/:subnet", CheckBlackListSubnet(subnetBlackList, &subnetBlackListMu))
 	snBlackList.POST("", UpsertBlackListSubnets(subnetBlackList, &subnetBlackListMu, db))
 	snBlackList.DELETE("", RemoveBlackListSubnets(subnetBlackList, &subnetBlackListMu, db))
+	snBlackList.POST("/import", ImportBlackListSubnets(subnetBlackList, &subnetBlackListMu, db))
+	snBlackList.GET("/export", ExportBlackListSubnets(db))
 	// register domain blacklist
 	dmBlackList := blacklist.Group("/domains")
-	dmBlackList.GET("", GetBlackListDomains(domainBlackList, &domainBlackListMu))
+	dmBlackList.GET("", GetBlackListDomains(db))
 	dmBlackList.GET("/:domain", CheckBlackListDomain(domainBlackList, &domainBlackListMu))
 	dmBlackList.POST("", UpsertBlackListDomains(domainBlackList, &domainBlackListMu, db))
 	dmBlackList.DELETE("", RemoveBlackListDomains(domainBlackList, &domainBlackListMu, db))
+	dmBlackList.POST("/import", ImportBlackListDomains(domainBlackList, &domainBlackListMu, db))
+	dmBlackList.GET("/export", ExportBlackListDomains(db))
 	// register country blacklist
 	crBlackList := blacklist.Group("/countries")
-	crBlackList.GET("", GetBlackListCountries(countryBlackList, &countryBlackListMu))
+	crBlackList.GET("", GetBlackListCountries(db))
 	crBlackList.GET("/:country", CheckBlackListCountry(countryBlackList, &countryBlackListMu))
 	crBlackList.POST("", UpsertBlackListCountries(countryBlackList, &countryBlackListMu, db))
 	crBlackList.DELETE("", RemoveBlackListCountries(countryBlackList, &countryBlackListMu, db))
+	crBlackList.POST("/import", ImportBlackListCountries(countryBlackList, &countryBlackListMu, db))
+	crBlackList.GET("/export", ExportBlackListCountries(db))
+	// register asn blacklist
+	asBlackList := blacklist.Group("/asns")
+	asBlackList.GET("", GetBlackListASNs(db, asnList))
+	asBlackList.GET("/:asn", CheckBlackListASN(asnBlackList, &asnBlackListMu, asnList))
+	asBlackList.POST("", UpsertBlackListASNs(asnBlackList, &asnBlackListMu, db))
+	asBlackList.DELETE("", RemoveBlackListASNs(asnBlackList, &asnBlackListMu, db))
+	// register ja3 blacklist
+	jaBlackList := blacklist.Group("/ja3s")
+	jaBlackList.GET("", GetBlackListJA3s(db))
+	jaBlackList.GET("/:hash", CheckBlackListJA3(ja3BlackList, &ja3BlackListMu))
+	jaBlackList.POST("", UpsertBlackListJA3s(ja3BlackList, &ja3BlackListMu, db))
+	jaBlackList.DELETE("", RemoveBlackListJA3s(ja3BlackList, &ja3BlackListMu, db))
 }
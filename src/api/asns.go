@@ -0,0 +1,346 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cnaize/meds/src/database"
+	"github.com/cnaize/meds/src/types"
+)
+
+// GetWhiteListASNs godoc
+//
+//	@Summary		Get whitelisted asns
+//	@Description	get whitelisted asns with their names (if known), paginated if limit is set
+//	@Tags			whitelist
+//	@Param			limit	query	int		false	"page size"
+//	@Param			cursor	query	string	false	"next page cursor"
+//	@Param			sort	query	string	false	"sort field"	Enums(value, created_at)	default(value)
+//	@Param			order	query	string	false	"sort order"	Enums(asc, desc)			default(asc)
+//	@Produce		json
+//	@Success		200	{object}	GetASNsResp
+//	@Failure		400
+//	@Failure		500
+//	@Router			/v1/whitelist/asns [get]
+func GetWhiteListASNs(db *database.Database, asnlist *types.ASNList) func(*gin.Context) {
+	return asnListGetAll(db, asnlist, db.Q.GetWhiteListASNPage, func(row *database.AsnWhitelist) (string, Entry) {
+		return strconv.FormatInt(row.Asn, 10), newEntry(row.ExpiresAt, row.Comment, row.Source, row.CreatedBy, row.CreatedAt, row.LastHitAt)
+	})
+}
+
+// GetBlackListASNs godoc
+//
+//	@Summary		Get blacklisted asns
+//	@Description	get blacklisted asns with their names (if known), paginated if limit is set
+//	@Tags			blacklist
+//	@Param			limit	query	int		false	"page size"
+//	@Param			cursor	query	string	false	"next page cursor"
+//	@Param			sort	query	string	false	"sort field"	Enums(value, created_at)	default(value)
+//	@Param			order	query	string	false	"sort order"	Enums(asc, desc)			default(asc)
+//	@Produce		json
+//	@Success		200	{object}	GetASNsResp
+//	@Failure		400
+//	@Failure		500
+//	@Router			/v1/blacklist/asns [get]
+func GetBlackListASNs(db *database.Database, asnlist *types.ASNList) func(*gin.Context) {
+	return asnListGetAll(db, asnlist, db.Q.GetBlackListASNPage, func(row *database.AsnBlacklist) (string, Entry) {
+		return strconv.FormatInt(row.Asn, 10), newEntry(row.ExpiresAt, row.Comment, row.Source, row.CreatedBy, row.CreatedAt, row.LastHitAt)
+	})
+}
+
+type GetASNsResp struct {
+	ASNs       []ASN  `json:"asns"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type ASN struct {
+	ASN  uint32 `json:"asn" example:"13335"`
+	Name string `json:"name,omitempty" example:"Cloudflare, Inc."`
+	Entry
+}
+
+func asnListGetAll[R any](
+	db *database.Database,
+	asnlist *types.ASNList,
+	pageFn func(ctx context.Context, db database.DBTX, arg *database.ListPageParams) ([]*R, error),
+	entryFn func(row *R) (string, Entry),
+) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var query PageQuery
+		if err := c.ShouldBindQuery(&query); err != nil || !query.valid() {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		// NOTE: the database is updated before the list, no need to lock it
+		rows, next, err := listPage(c, db, query, database.ListPageParams{}, pageFn, entryFn)
+		if err != nil {
+			c.AbortWithStatus(pageStatus(err))
+			return
+		}
+
+		asns := make([]ASN, 0, len(rows))
+		for _, row := range rows {
+			value, entry := entryFn(row)
+			asn, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				continue
+			}
+
+			asns = append(asns, ASN{ASN: uint32(asn), Name: asnlist.Name(uint32(asn)), Entry: entry})
+		}
+
+		c.JSON(http.StatusOK, GetASNsResp{ASNs: asns, NextCursor: next})
+	}
+}
+
+// CheckWhiteListASN godoc
+//
+//	@Summary		Check whitelisted asn
+//	@Description	check if an asn is whitelisted
+//	@Tags			whitelist
+//	@Produce		json
+//	@Param			asn	path		string	true	"asn to check"	example(AS13335)
+//	@Success		200	{object}	CheckASNResp
+//	@Failure		400
+//	@Router			/v1/whitelist/asns/{asn} [get]
+func CheckWhiteListASN(whitelist *types.ASNumberList, mu *sync.Mutex, asnlist *types.ASNList) func(*gin.Context) {
+	return asnListLookup(whitelist, mu, asnlist)
+}
+
+// CheckBlackListASN godoc
+//
+//	@Summary		Check blacklisted asn
+//	@Description	check if an asn is blacklisted
+//	@Tags			blacklist
+//	@Produce		json
+//	@Param			asn	path		string	true	"asn to check"	example(AS13335)
+//	@Success		200	{object}	CheckASNResp
+//	@Failure		400
+//	@Router			/v1/blacklist/asns/{asn} [get]
+func CheckBlackListASN(blacklist *types.ASNumberList, mu *sync.Mutex, asnlist *types.ASNList) func(*gin.Context) {
+	return asnListLookup(blacklist, mu, asnlist)
+}
+
+type CheckASNResp struct {
+	Found bool   `json:"found"`
+	Name  string `json:"name,omitempty" example:"Cloudflare, Inc."`
+}
+
+func asnListLookup(list *types.ASNumberList, mu *sync.Mutex, asnlist *types.ASNList) func(*gin.Context) {
+	return func(c *gin.Context) {
+		// accept both "AS123" and "123"
+		asn, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(c.Param("asn")), "AS"), 10, 32)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		c.JSON(http.StatusOK, CheckASNResp{
+			Found: list.Lookup(uint32(asn)),
+			Name:  asnlist.Name(uint32(asn)),
+		})
+	}
+}
+
+// UpsertWhiteListASNs godoc
+//
+//	@Summary		Upsert whitelisted asns
+//	@Description	upsert asns to whitelist
+//	@Tags			whitelist
+//	@Accept			json
+//	@Param			body	body	UpsertASNsReq	true	"asns to add"
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
+//	@Failure		400
+//	@Failure		422	{object}	MutateResp
+//	@Failure		500
+//	@Router			/v1/whitelist/asns [post]
+func UpsertWhiteListASNs(whitelist *types.ASNumberList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return asnListUpsert(whitelist, mu, db, db.Q.CountWhiteListASN, func(ctx context.Context, dbtx database.DBTX, asn int64, meta entryMeta) error {
+		return db.Q.UpsertWhiteListASN(ctx, dbtx, &database.UpsertWhiteListASNParams{
+			Asn:           asn,
+			ExpiresAt:     meta.ExpiresAt,
+			Comment:       meta.Comment,
+			Source:        meta.Source,
+			DefaultSource: meta.DefaultSource,
+			CreatedBy:     meta.CreatedBy,
+			CreatedAt:     meta.CreatedAt,
+			LastHitAt:     meta.LastHitAt,
+		})
+	})
+}
+
+// UpsertBlackListASNs godoc
+//
+//	@Summary		Upsert blacklisted asns
+//	@Description	upsert asns to blacklist
+//	@Tags			blacklist
+//	@Accept			json
+//	@Param			body	body	UpsertASNsReq	true	"asns to add"
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
+//	@Failure		400
+//	@Failure		422	{object}	MutateResp
+//	@Failure		500
+//	@Router			/v1/blacklist/asns [post]
+func UpsertBlackListASNs(blacklist *types.ASNumberList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return asnListUpsert(blacklist, mu, db, db.Q.CountBlackListASN, func(ctx context.Context, dbtx database.DBTX, asn int64, meta entryMeta) error {
+		return db.Q.UpsertBlackListASN(ctx, dbtx, &database.UpsertBlackListASNParams{
+			Asn:           asn,
+			ExpiresAt:     meta.ExpiresAt,
+			Comment:       meta.Comment,
+			Source:        meta.Source,
+			DefaultSource: meta.DefaultSource,
+			CreatedBy:     meta.CreatedBy,
+			CreatedAt:     meta.CreatedAt,
+			LastHitAt:     meta.LastHitAt,
+		})
+	})
+}
+
+type UpsertASNsReq struct {
+	ASNs []uint32 `json:"asns" example:"13335,15169"`
+	UpsertMeta
+}
+
+func asnListUpsert(
+	list *types.ASNumberList,
+	mu *sync.Mutex,
+	db *database.Database,
+	countFn func(ctx context.Context, db database.DBTX, asn int64) (int64, error),
+	upsertFn func(ctx context.Context, db database.DBTX, asn int64, meta entryMeta) error,
+) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var req UpsertASNsReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		meta, err := req.meta(c)
+		if err != nil {
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+			return
+		}
+
+		asns, invalid, ok := parseEntries(req.ASNs, parseASN, formatASN)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, MutateResp{Results: invalid})
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		// NOTE: the list is swapped only after the database commit
+		results, err := upsertEntries(c, db, asns, meta, asnKey, formatASN, countFn, upsertFn)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		if err := list.Upsert(asns); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, MutateResp{Results: results})
+	}
+}
+
+// RemoveWhiteListASNs godoc
+//
+//	@Summary		Remove whitelisted asns
+//	@Description	remove asns from whitelist
+//	@Tags			whitelist
+//	@Accept			json
+//	@Param			body	body	RemoveASNsReq	true	"asns to remove"
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
+//	@Failure		400
+//	@Failure		422	{object}	MutateResp
+//	@Failure		500
+//	@Router			/v1/whitelist/asns [delete]
+func RemoveWhiteListASNs(whitelist *types.ASNumberList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return asnListRemove(whitelist, mu, db, db.Q.RemoveWhiteListASN)
+}
+
+// RemoveBlackListASNs godoc
+//
+//	@Summary		Remove blacklisted asns
+//	@Description	remove asns from blacklist
+//	@Tags			blacklist
+//	@Accept			json
+//	@Param			body	body	RemoveASNsReq	true	"asns to remove"
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
+//	@Failure		400
+//	@Failure		422	{object}	MutateResp
+//	@Failure		500
+//	@Router			/v1/blacklist/asns [delete]
+func RemoveBlackListASNs(blacklist *types.ASNumberList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return asnListRemove(blacklist, mu, db, db.Q.RemoveBlackListASN)
+}
+
+type RemoveASNsReq struct {
+	ASNs []uint32 `json:"asns" example:"13335,15169"`
+}
+
+func asnListRemove(
+	list *types.ASNumberList,
+	mu *sync.Mutex,
+	db *database.Database,
+	removeFn func(ctx context.Context, db database.DBTX, asn int64) (int64, error),
+) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var req RemoveASNsReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		asns, invalid, ok := parseEntries(req.ASNs, parseASN, formatASN)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, MutateResp{Results: invalid})
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		// NOTE: the list is swapped only after the database commit
+		results, err := removeEntries(c, db, asns, asnKey, formatASN, removeFn)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		if err := list.Remove(asns); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, MutateResp{Results: results})
+	}
+}
+
+func parseASN(asn uint32) (uint32, bool) {
+	return asn, asn > 0
+}
+
+func formatASN(asn uint32) string {
+	return strconv.FormatUint(uint64(asn), 10)
+}
+
+func asnKey(asn uint32) int64 {
+	return int64(asn)
+}
@@ -0,0 +1,474 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cnaize/meds/src/database"
+	"github.com/cnaize/meds/src/types"
+)
+
+// bulk import/export formats
+const (
+	FormatText  = "text"
+	FormatCSV   = "csv"
+	FormatHosts = "hosts"
+	FormatJSON  = "json"
+)
+
+// bulk import modes
+const (
+	ModeMerge   = "merge"
+	ModeReplace = "replace"
+)
+
+// importMaxBytes limits the import request body
+const importMaxBytes = 64 << 20
+
+var (
+	listFormats   = []string{FormatText, FormatCSV, FormatJSON}
+	domainFormats = []string{FormatText, FormatCSV, FormatHosts, FormatJSON}
+)
+
+var csvHeader = []string{"value", "ttl", "comment", "source", "created_by", "created_at", "last_hit_at"}
+
+// bulkList is the white/black list updated by the import
+type bulkList[T any] interface {
+	Upsert(entries []T) error
+	Remove(entries []T) error
+}
+
+type ImportQuery struct {
+	Format string `form:"format"`
+	Mode   string `form:"mode"`
+	// TTL (in seconds) makes the imported entries temporary
+	TTL     int64  `form:"ttl"`
+	Comment string `form:"comment"`
+}
+
+type ImportResp struct {
+	Created int `json:"created" example:"100"`
+	Updated int `json:"updated" example:"10"`
+	Removed int `json:"removed" example:"1"`
+	// Invalid are the rejected entries, nothing is imported if any
+	Invalid []Result `json:"invalid,omitempty"`
+}
+
+// ExportEntry is the exported list entry
+type ExportEntry struct {
+	Value string `json:"value" example:"100.100.100.100/32"`
+	Entry
+}
+
+func listImport[T comparable, R any](
+	list bulkList[T],
+	mu *sync.Mutex,
+	db *database.Database,
+	formats []string,
+	parseFn func(value string) (T, bool),
+	valueFn func(entry T) string,
+	eachFn func(ctx context.Context, db database.DBTX, fn func(row *R) error) error,
+	entryFn func(row *R) (string, Entry),
+	countFn func(ctx context.Context, db database.DBTX, value string) (int64, error),
+	upsertFn func(ctx context.Context, db database.DBTX, value string, meta entryMeta) error,
+	removeFn func(ctx context.Context, db database.DBTX, value string) (int64, error),
+) func(*gin.Context) {
+	return func(c *gin.Context) {
+		query := ImportQuery{Format: FormatText, Mode: ModeMerge}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		if !slices.Contains(formats, query.Format) || (query.Mode != ModeMerge && query.Mode != ModeReplace) {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		defaults, err := UpsertMeta{TTL: query.TTL, Comment: query.Comment}.meta(c)
+		if err != nil {
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+			return
+		}
+		defaults.DefaultSource = types.SourceImport
+
+		// NOTE: the body is parsed before the lock and the transaction,
+		// so a slow client doesn't block the other writers
+		var resp ImportResp
+		var entries []T
+		var metas []entryMeta
+		seen := make(map[T]bool)
+		body := http.MaxBytesReader(c.Writer, c.Request.Body, importMaxBytes)
+		if err := readValues(body, query.Format, func(item ExportEntry) error {
+			entry, ok := parseFn(item.Value)
+			meta, err := defaults.with(item.Entry)
+			if !ok || err != nil {
+				resp.Invalid = append(resp.Invalid, Result{Value: item.Value, Status: StatusInvalid})
+				return nil
+			}
+			if seen[entry] {
+				return nil
+			}
+			seen[entry] = true
+			entries = append(entries, entry)
+			metas = append(metas, meta)
+
+			return nil
+		}); err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				c.AbortWithStatus(http.StatusRequestEntityTooLarge)
+				return
+			}
+
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		if len(resp.Invalid) > 0 {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, ImportResp{Invalid: resp.Invalid})
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		var stale []T
+		// NOTE: the list is swapped only after the commit
+		if err := withTx(c, db, func(tx database.DBTX) error {
+			for i, entry := range entries {
+				value := valueFn(entry)
+				count, err := countFn(c, tx, value)
+				if err != nil {
+					return fmt.Errorf("count: %w", err)
+				}
+				if err := upsertFn(c, tx, value, metas[i]); err != nil {
+					return fmt.Errorf("upsert: %w", err)
+				}
+
+				if count > 0 {
+					resp.Updated++
+				} else {
+					resp.Created++
+				}
+			}
+
+			if query.Mode == ModeReplace {
+				var values []string
+				if err := eachFn(c, tx, func(row *R) error {
+					value, _ := entryFn(row)
+					if entry, ok := parseFn(value); ok {
+						if seen[entry] {
+							return nil
+						}
+						stale = append(stale, entry)
+					}
+					values = append(values, value)
+
+					return nil
+				}); err != nil {
+					return fmt.Errorf("get all: %w", err)
+				}
+
+				for _, value := range values {
+					if _, err := removeFn(c, tx, value); err != nil {
+						return fmt.Errorf("remove: %w", err)
+					}
+					resp.Removed++
+				}
+			}
+
+			return nil
+		}); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		if len(stale) > 0 {
+			if err := list.Remove(stale); err != nil {
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		if err := list.Upsert(entries); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, resp)
+	}
+}
+
+// readValues scans the body in the format and calls fn for every value,
+// the metadata is read from csv and json only
+func readValues(r io.Reader, format string, fn func(item ExportEntry) error) error {
+	switch format {
+	case FormatText, FormatHosts:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line, _, _ := strings.Cut(scanner.Text(), "#")
+			fields := strings.Fields(line)
+			if len(fields) < 1 {
+				continue
+			}
+
+			if format == FormatText {
+				if err := fn(ExportEntry{Value: fields[0]}); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// NOTE: "0.0.0.0 bad.com dead.com", the address is skipped
+			for _, field := range fields[1:] {
+				if err := fn(ExportEntry{Value: field}); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+
+		return nil
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.Comment = '#'
+		reader.FieldsPerRecord = -1
+		reader.ReuseRecord = true
+		// NOTE: the columns are positional unless the header is set
+		columns := csvHeader
+		for i := 0; ; i++ {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if i == 0 && strings.EqualFold(strings.TrimSpace(record[0]), csvHeader[0]) {
+				columns = make([]string, len(record))
+				for j, column := range record {
+					columns[j] = strings.ToLower(strings.TrimSpace(column))
+				}
+				continue
+			}
+
+			item, err := csvEntry(columns, record)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", i+1, err)
+			}
+			if len(item.Value) < 1 {
+				continue
+			}
+
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+	case FormatJSON:
+		// NOTE: ["bad.com", {"value": "dead.com", "comment": "phishing"}]
+		decoder := json.NewDecoder(r)
+		if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+			return errors.New("json array expected")
+		}
+
+		for decoder.More() {
+			var raw json.RawMessage
+			if err := decoder.Decode(&raw); err != nil {
+				return err
+			}
+
+			var item ExportEntry
+			if err := json.Unmarshal(raw, &item.Value); err != nil {
+				if err := json.Unmarshal(raw, &item); err != nil {
+					return err
+				}
+			}
+
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("unsupported format: %s", format)
+}
+
+// csvEntry reads the entry from the csv record by the columns names
+func csvEntry(columns, record []string) (ExportEntry, error) {
+	var item ExportEntry
+	for i, column := range columns[:min(len(columns), len(record))] {
+		value := strings.TrimSpace(record[i])
+		if len(value) < 1 {
+			continue
+		}
+
+		var err error
+		switch column {
+		case "value":
+			item.Value = value
+		case "ttl":
+			item.TTL, err = strconv.ParseInt(value, 10, 64)
+		case "comment":
+			item.Comment = value
+		case "source":
+			item.Source = value
+		case "created_by":
+			item.CreatedBy = value
+		case "created_at":
+			item.CreatedAt, err = parseTime(value)
+		case "last_hit_at":
+			item.LastHitAt, err = parseTime(value)
+		}
+		if err != nil {
+			return ExportEntry{}, fmt.Errorf("%s: %w", column, err)
+		}
+	}
+
+	return item, nil
+}
+
+func listExport[R any](
+	db *database.Database,
+	formats []string,
+	eachFn func(ctx context.Context, db database.DBTX, fn func(row *R) error) error,
+	entryFn func(row *R) (string, Entry),
+) func(*gin.Context) {
+	return func(c *gin.Context) {
+		format := c.DefaultQuery("format", FormatText)
+		if !slices.Contains(formats, format) {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		switch format {
+		case FormatCSV:
+			c.Header("Content-Type", "text/csv; charset=utf-8")
+		case FormatJSON:
+			c.Header("Content-Type", "application/json; charset=utf-8")
+		default:
+			c.Header("Content-Type", "text/plain; charset=utf-8")
+		}
+		c.Status(http.StatusOK)
+
+		// NOTE: the database is updated before the list, no need to lock it
+		w := bufio.NewWriter(c.Writer)
+		err := writeEntries(w, format, func(fn func(row *R) error) error {
+			return eachFn(c, db.DB, fn)
+		}, entryFn)
+		if err == nil {
+			err = w.Flush()
+		}
+		if err != nil {
+			// NOTE: the status is sent with the first flushed rows
+			if !c.Writer.Written() {
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+
+			_ = c.Error(err)
+		}
+	}
+}
+
+// writeEntries writes the rows in the format as they are read
+func writeEntries[R any](w *bufio.Writer, format string, each func(fn func(row *R) error) error, entryFn func(row *R) (string, Entry)) error {
+	switch format {
+	case FormatText, FormatHosts:
+		return each(func(row *R) error {
+			value, _ := entryFn(row)
+			if format == FormatHosts {
+				w.WriteString("0.0.0.0 ")
+			}
+			w.WriteString(value)
+
+			return w.WriteByte('\n')
+		})
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		writer.Write(csvHeader)
+		if err := each(func(row *R) error {
+			value, entry := entryFn(row)
+			return writer.Write([]string{
+				value,
+				strconv.FormatInt(entry.TTL, 10),
+				entry.Comment,
+				entry.Source,
+				entry.CreatedBy,
+				formatTime(entry.CreatedAt),
+				formatTime(entry.LastHitAt),
+			})
+		}); err != nil {
+			return err
+		}
+		writer.Flush()
+
+		return writer.Error()
+	case FormatJSON:
+		w.WriteByte('[')
+		var count int
+		if err := each(func(row *R) error {
+			value, entry := entryFn(row)
+			data, err := json.Marshal(ExportEntry{Value: value, Entry: entry})
+			if err != nil {
+				return err
+			}
+
+			if count > 0 {
+				w.WriteByte(',')
+			}
+			count++
+			w.WriteString("\n  ")
+			_, err = w.Write(data)
+
+			return err
+		}); err != nil {
+			return err
+		}
+		_, err := w.WriteString("\n]\n")
+
+		return err
+	}
+
+	return fmt.Errorf("unsupported format: %s", format)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func parseTime(value string) (*time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return timePtr(t.UTC()), nil
+}
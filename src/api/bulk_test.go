@@ -0,0 +1,154 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/database"
+	"github.com/cnaize/meds/src/types"
+)
+
+func newTestDatabase(t *testing.T) *database.Database {
+	t.Helper()
+
+	nop := zerolog.Nop()
+	db := database.NewDatabase(filepath.Join(t.TempDir(), "meds.db"), logger.NewLogger(&nop, 1))
+	if err := db.Init(t.Context()); err != nil {
+		t.Fatalf("init: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func serve(r *gin.Engine, method, url, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, url, strings.NewReader(body)))
+
+	return w
+}
+
+func TestReadValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		body   string
+		want   []ExportEntry
+		err    bool
+	}{
+		{
+			name:   "text",
+			format: FormatText,
+			body:   "# comment\nbad.com\n\n dead.com extra # tail\n",
+			want:   []ExportEntry{{Value: "bad.com"}, {Value: "dead.com"}},
+		},
+		{
+			name:   "hosts",
+			format: FormatHosts,
+			body:   "0.0.0.0 bad.com dead.com\n127.0.0.1 localhost\n",
+			want:   []ExportEntry{{Value: "bad.com"}, {Value: "dead.com"}, {Value: "localhost"}},
+		},
+		{
+			name:   "csv positional",
+			format: FormatCSV,
+			body:   "bad.com,60,phishing\n",
+			want:   []ExportEntry{{Value: "bad.com", Entry: Entry{TTL: 60, Comment: "phishing"}}},
+		},
+		{
+			name:   "csv header",
+			format: FormatCSV,
+			body:   "value,comment,source\nbad.com,phishing,auto\n",
+			want:   []ExportEntry{{Value: "bad.com", Entry: Entry{Comment: "phishing", Source: "auto"}}},
+		},
+		{
+			name:   "csv invalid ttl",
+			format: FormatCSV,
+			body:   "bad.com,soon\n",
+			err:    true,
+		},
+		{
+			name:   "json",
+			format: FormatJSON,
+			body:   `["bad.com", {"value": "dead.com", "comment": "phishing"}]`,
+			want:   []ExportEntry{{Value: "bad.com"}, {Value: "dead.com", Entry: Entry{Comment: "phishing"}}},
+		},
+		{
+			name:   "json not array",
+			format: FormatJSON,
+			body:   `{"value": "bad.com"}`,
+			err:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []ExportEntry
+			err := readValues(strings.NewReader(tt.body), tt.format, func(item ExportEntry) error {
+				got = append(got, item)
+				return nil
+			})
+			if (err != nil) != tt.err {
+				t.Fatalf("err %v, want error %t", err, tt.err)
+			}
+			if !tt.err && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportExport(t *testing.T) {
+	db := newTestDatabase(t)
+	list := types.NewSubnetList()
+
+	var mu sync.Mutex
+	r := gin.New()
+	r.POST("/import", ImportWhiteListSubnets(list, &mu, db))
+	r.GET("/export", ExportWhiteListSubnets(db))
+
+	body := "value,ttl,comment,source,created_by,created_at,last_hit_at\n" +
+		"1.2.3.4,,scanner,auto,admin,2020-01-02T03:04:05Z,2021-01-02T03:04:05Z\n" +
+		"10.0.0.0/8,,,,,2020-01-02T03:04:05Z,\n"
+	if w := serve(r, http.MethodPost, "/import?format=csv", body); w.Code != http.StatusAccepted {
+		t.Fatalf("import status %d: %s", w.Code, w.Body)
+	}
+	if !list.Lookup(netip.MustParsePrefix("1.2.3.4/32")) {
+		t.Errorf("imported subnet not in the list")
+	}
+
+	w := serve(r, http.MethodGet, "/export?format=csv", "")
+	want := "value,ttl,comment,source,created_by,created_at,last_hit_at\n" +
+		"1.2.3.4/32,0,scanner,auto,admin,2020-01-02T03:04:05Z,2021-01-02T03:04:05Z\n" +
+		"10.0.0.0/8,0,,import,,2020-01-02T03:04:05Z,\n"
+	if w.Code != http.StatusOK || w.Body.String() != want {
+		t.Errorf("export status %d:\n%s\nwant:\n%s", w.Code, w.Body, want)
+	}
+
+	// invalid entries reject the whole import
+	w = serve(r, http.MethodPost, "/import?format=text&mode=replace", "2.2.2.2\nnot-an-ip\n")
+	if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), "not-an-ip") {
+		t.Errorf("invalid import status %d: %s", w.Code, w.Body)
+	}
+
+	// replace removes the missing entries
+	w = serve(r, http.MethodPost, "/import?format=text&mode=replace", "10.0.0.0/8\n")
+	if w.Code != http.StatusAccepted || !strings.Contains(w.Body.String(), `"removed":1`) {
+		t.Errorf("replace status %d: %s", w.Code, w.Body)
+	}
+	if w := serve(r, http.MethodGet, "/export", ""); w.Body.String() != "10.0.0.0/8\n" {
+		t.Errorf("export after replace %q", w.Body)
+	}
+	if list.Lookup(netip.MustParsePrefix("1.2.3.4/32")) {
+		t.Errorf("removed subnet still in the list")
+	}
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cnaize/meds/src/config"
+)
+
+// ReloadConfig godoc
+//
+//	@Summary		Reload config
+//	@Description	re-read config file and rebuild filters in place
+//	@Tags			config
+//	@Produce		json
+//	@Success		200	{object}	ReloadConfigResp
+//	@Failure		500
+//	@Router			/v1/config/reload [post]
+func ReloadConfig(reloadFn func(ctx context.Context) (config.Diff, error)) func(*gin.Context) {
+	return func(c *gin.Context) {
+		// don't interrupt reload on client disconnect
+		diff, err := reloadFn(context.WithoutCancel(c))
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, ReloadConfigResp{
+			Reloaded: diff.Reloaded,
+			Restart:  diff.Restart,
+			Rebuild:  diff.Rebuild,
+		})
+	}
+}
+
+type ReloadConfigResp struct {
+	Reloaded []string `json:"reloaded" example:"rate-limiter-rate,feeds"`
+	Restart  []string `json:"restart" example:"readers-count"`
+	Rebuild  bool     `json:"rebuild"`
+}
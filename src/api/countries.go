@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"net/http"
+	"strings"
 	"sync"
 
 	"github.com/gin-gonic/gin"
@@ -11,31 +12,102 @@ import (
 	"github.com/cnaize/meds/src/types"
 )
 
+// GetWhiteListCountries godoc
+//
+//	@Summary		Get whitelisted countries
+//	@Description	get whitelisted countries (only they are allowed if not empty), paginated if limit is set
+//	@Tags			whitelist
+//	@Param			search	query	string	false	"country substring"
+//	@Param			limit	query	int		false	"page size"
+//	@Param			cursor	query	string	false	"next page cursor"
+//	@Param			sort	query	string	false	"sort field"	Enums(value, created_at)	default(value)
+//	@Param			order	query	string	false	"sort order"	Enums(asc, desc)			default(asc)
+//	@Produce		json
+//	@Success		200	{object}	GetCountriesResp
+//	@Failure		400
+//	@Failure		500
+//	@Router			/v1/whitelist/countries [get]
+func GetWhiteListCountries(db *database.Database) func(*gin.Context) {
+	return countryListGetAll(db, db.Q.GetWhiteListCountryPage, whiteListCountryEntry)
+}
+
 // GetBlackListCountries godoc
 //
 //	@Summary		Get blacklisted countries
-//	@Description	get all blacklisted countries
+//	@Description	get blacklisted countries, paginated if limit is set
 //	@Tags			blacklist
+//	@Param			search	query	string	false	"country substring"
+//	@Param			limit	query	int		false	"page size"
+//	@Param			cursor	query	string	false	"next page cursor"
+//	@Param			sort	query	string	false	"sort field"	Enums(value, created_at)	default(value)
+//	@Param			order	query	string	false	"sort order"	Enums(asc, desc)			default(asc)
 //	@Produce		json
 //	@Success		200	{object}	GetCountriesResp
+//	@Failure		400
+//	@Failure		500
 //	@Router			/v1/blacklist/countries [get]
-func GetBlackListCountries(blacklist *types.CountryList, mu *sync.Mutex) func(*gin.Context) {
-	return countryListGetAll(blacklist, mu)
+func GetBlackListCountries(db *database.Database) func(*gin.Context) {
+	return countryListGetAll(db, db.Q.GetBlackListCountryPage, blackListCountryEntry)
+}
+
+type GetCountriesQuery struct {
+	Search string `form:"search"`
+	PageQuery
 }
 
 type GetCountriesResp struct {
 	Countries []string `json:"countries" example:"fr,de"`
+	// Entries are the entries metadata by their values
+	Entries    map[string]Entry `json:"entries,omitempty"`
+	NextCursor string           `json:"next_cursor,omitempty"`
 }
 
-func countryListGetAll(list *types.CountryList, mu *sync.Mutex) func(*gin.Context) {
+func countryListGetAll[R any](
+	db *database.Database,
+	pageFn func(ctx context.Context, db database.DBTX, arg *database.ListPageParams) ([]*R, error),
+	entryFn func(row *R) (string, Entry),
+) func(*gin.Context) {
 	return func(c *gin.Context) {
-		mu.Lock()
-		defer mu.Unlock()
+		var query GetCountriesQuery
+		if err := c.ShouldBindQuery(&query); err != nil || !query.valid() {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		params := database.ListPageParams{Search: strings.ToLower(strings.TrimSpace(query.Search))}
 
-		c.JSON(http.StatusOK, GetCountriesResp{Countries: list.GetAll()})
+		// NOTE: the database is updated before the list, no need to lock it
+		rows, next, err := listPage(c, db, query.PageQuery, params, pageFn, entryFn)
+		if err != nil {
+			c.AbortWithStatus(pageStatus(err))
+			return
+		}
+
+		countries, entries := pageEntries(rows, entryFn)
+		c.JSON(http.StatusOK, GetCountriesResp{Countries: countries, Entries: entries, NextCursor: next})
 	}
 }
 
+func whiteListCountryEntry(row *database.CountryWhitelist) (string, Entry) {
+	return row.Country, newEntry(row.ExpiresAt, row.Comment, row.Source, row.CreatedBy, row.CreatedAt, row.LastHitAt)
+}
+
+func blackListCountryEntry(row *database.CountryBlacklist) (string, Entry) {
+	return row.Country, newEntry(row.ExpiresAt, row.Comment, row.Source, row.CreatedBy, row.CreatedAt, row.LastHitAt)
+}
+
+// CheckWhiteListCountry godoc
+//
+//	@Summary		Check whitelisted country
+//	@Description	check if a country is whitelisted
+//	@Tags			whitelist
+//	@Produce		json
+//	@Param			country	path		string	true	"country to check"
+//	@Success		200		{object}	CheckCountryResp
+//	@Router			/v1/whitelist/countries/{country} [get]
+func CheckWhiteListCountry(whitelist *types.CountryList, mu *sync.Mutex) func(*gin.Context) {
+	return countryListLookup(whitelist, mu)
+}
+
 // CheckBlackListCountry godoc
 //
 //	@Summary		Check blacklisted country
@@ -66,31 +138,51 @@ func countryListLookup(list *types.CountryList, mu *sync.Mutex) func(*gin.Contex
 	}
 }
 
-// UpsertCountriesReq godoc
+// UpsertWhiteListCountries godoc
+//
+//	@Summary		Upsert whitelisted countries
+//	@Description	upsert countries to whitelist (enables the allowlist mode)
+//	@Tags			whitelist
+//	@Accept			json
+//	@Param			body	body	UpsertCountriesReq	true	"countries to add"
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
+//	@Failure		400
+//	@Failure		422	{object}	MutateResp
+//	@Failure		500
+//	@Router			/v1/whitelist/countries [post]
+func UpsertWhiteListCountries(whitelist *types.CountryList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return countryListUpsert(whitelist, mu, db, db.Q.CountWhiteListCountry, upsertWhiteListCountry(db))
+}
+
+// UpsertBlackListCountries godoc
 //
 //	@Summary		Upsert blacklisted countries
 //	@Description	upsert countries to blacklist
 //	@Tags			blacklist
 //	@Accept			json
 //	@Param			body	body	UpsertCountriesReq	true	"countries to add"
-//	@Success		202
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
 //	@Failure		400
-//	@Failure		422
+//	@Failure		422	{object}	MutateResp
 //	@Failure		500
 //	@Router			/v1/blacklist/countries [post]
 func UpsertBlackListCountries(blacklist *types.CountryList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
-	return countryListUpsert(blacklist, mu, db, db.Q.UpsertBlackListCountry)
+	return countryListUpsert(blacklist, mu, db, db.Q.CountBlackListCountry, upsertBlackListCountry(db))
 }
 
 type UpsertCountriesReq struct {
 	Countries []string `json:"countries" example:"fr,de"`
+	UpsertMeta
 }
 
 func countryListUpsert(
 	list *types.CountryList,
 	mu *sync.Mutex,
 	db *database.Database,
-	upsertFn func(ctx context.Context, db database.DBTX, country string) error,
+	countFn func(ctx context.Context, db database.DBTX, country string) (int64, error),
+	upsertFn func(ctx context.Context, db database.DBTX, country string, meta entryMeta) error,
 ) func(*gin.Context) {
 	return func(c *gin.Context) {
 		var req UpsertCountriesReq
@@ -99,25 +191,84 @@ func countryListUpsert(
 			return
 		}
 
+		meta, err := req.meta(c)
+		if err != nil {
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+			return
+		}
+
+		countries, invalid, ok := parseEntries(req.Countries, parseName, identity[string])
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, MutateResp{Results: invalid})
+			return
+		}
+
 		mu.Lock()
 		defer mu.Unlock()
 
-		if err := list.Upsert(req.Countries); err != nil {
-			c.AbortWithStatus(http.StatusUnprocessableEntity)
+		// NOTE: the list is swapped only after the database commit
+		results, err := upsertEntries(c, db, countries, meta, identity[string], identity[string], countFn, upsertFn)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
 			return
 		}
 
-		for _, country := range req.Countries {
-			if err := upsertFn(c, db.DB, country); err != nil {
-				c.AbortWithStatus(http.StatusInternalServerError)
-				return
-			}
+		if err := list.Upsert(countries); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
 		}
 
-		c.Status(http.StatusAccepted)
+		c.JSON(http.StatusAccepted, MutateResp{Results: results})
+	}
+}
+
+func upsertWhiteListCountry(db *database.Database) func(ctx context.Context, dbtx database.DBTX, country string, meta entryMeta) error {
+	return func(ctx context.Context, dbtx database.DBTX, country string, meta entryMeta) error {
+		return db.Q.UpsertWhiteListCountry(ctx, dbtx, &database.UpsertWhiteListCountryParams{
+			Country:       country,
+			ExpiresAt:     meta.ExpiresAt,
+			Comment:       meta.Comment,
+			Source:        meta.Source,
+			DefaultSource: meta.DefaultSource,
+			CreatedBy:     meta.CreatedBy,
+			CreatedAt:     meta.CreatedAt,
+			LastHitAt:     meta.LastHitAt,
+		})
+	}
+}
+
+func upsertBlackListCountry(db *database.Database) func(ctx context.Context, dbtx database.DBTX, country string, meta entryMeta) error {
+	return func(ctx context.Context, dbtx database.DBTX, country string, meta entryMeta) error {
+		return db.Q.UpsertBlackListCountry(ctx, dbtx, &database.UpsertBlackListCountryParams{
+			Country:       country,
+			ExpiresAt:     meta.ExpiresAt,
+			Comment:       meta.Comment,
+			Source:        meta.Source,
+			DefaultSource: meta.DefaultSource,
+			CreatedBy:     meta.CreatedBy,
+			CreatedAt:     meta.CreatedAt,
+			LastHitAt:     meta.LastHitAt,
+		})
 	}
 }
 
+// RemoveWhiteListCountries godoc
+//
+//	@Summary		Remove whitelisted countries
+//	@Description	remove countries from whitelist (the allowlist mode is disabled if empty)
+//	@Tags			whitelist
+//	@Accept			json
+//	@Param			body	body	RemoveCountriesReq	true	"countries to remove"
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
+//	@Failure		400
+//	@Failure		422	{object}	MutateResp
+//	@Failure		500
+//	@Router			/v1/whitelist/countries [delete]
+func RemoveWhiteListCountries(whitelist *types.CountryList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return countryListRemove(whitelist, mu, db, db.Q.RemoveWhiteListCountry)
+}
+
 // RemoveBlackListCountries godoc
 //
 //	@Summary		Remove blacklisted countries
@@ -125,9 +276,10 @@ func countryListUpsert(
 //	@Tags			blacklist
 //	@Accept			json
 //	@Param			body	body	RemoveCountriesReq	true	"countries to remove"
-//	@Success		202
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
 //	@Failure		400
-//	@Failure		422
+//	@Failure		422	{object}	MutateResp
 //	@Failure		500
 //	@Router			/v1/blacklist/countries [delete]
 func RemoveBlackListCountries(blacklist *types.CountryList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
@@ -142,7 +294,7 @@ func countryListRemove(
 	list *types.CountryList,
 	mu *sync.Mutex,
 	db *database.Database,
-	removeFn func(ctx context.Context, db database.DBTX, country string) error,
+	removeFn func(ctx context.Context, db database.DBTX, country string) (int64, error),
 ) func(*gin.Context) {
 	return func(c *gin.Context) {
 		var req RemoveCountriesReq
@@ -151,21 +303,101 @@ func countryListRemove(
 			return
 		}
 
+		countries, invalid, ok := parseEntries(req.Countries, parseName, identity[string])
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, MutateResp{Results: invalid})
+			return
+		}
+
 		mu.Lock()
 		defer mu.Unlock()
 
-		if err := list.Remove(req.Countries); err != nil {
-			c.AbortWithStatus(http.StatusUnprocessableEntity)
+		// NOTE: the list is swapped only after the database commit
+		results, err := removeEntries(c, db, countries, identity[string], identity[string], removeFn)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
 			return
 		}
 
-		for _, country := range req.Countries {
-			if err := removeFn(c, db.DB, country); err != nil {
-				c.AbortWithStatus(http.StatusInternalServerError)
-				return
-			}
+		if err := list.Remove(countries); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
 		}
 
-		c.Status(http.StatusAccepted)
+		c.JSON(http.StatusAccepted, MutateResp{Results: results})
 	}
 }
+
+// ImportWhiteListCountries godoc
+//
+//	@Summary		Import whitelisted countries
+//	@Description	import countries to whitelist from text, csv, or json, replace mode removes the missing ones
+//	@Tags			whitelist
+//	@Accept			plain,json
+//	@Param			format	query		string	false	"body format"	Enums(text, csv, json)	default(text)
+//	@Param			mode	query		string	false	"import mode"	Enums(merge, replace)	default(merge)
+//	@Param			ttl		query		int		false	"entries ttl (in seconds)"
+//	@Param			comment	query		string	false	"entries comment"
+//	@Param			body	body		string	true	"countries to import"
+//	@Produce		json
+//	@Success		202		{object}	ImportResp
+//	@Failure		400
+//	@Failure		413
+//	@Failure		422		{object}	ImportResp
+//	@Failure		500
+//	@Router			/v1/whitelist/countries/import [post]
+func ImportWhiteListCountries(whitelist *types.CountryList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return listImport(whitelist, mu, db, listFormats, parseName, identity[string], db.Q.EachWhiteListCountryEntry, whiteListCountryEntry, db.Q.CountWhiteListCountry, upsertWhiteListCountry(db), db.Q.RemoveWhiteListCountry)
+}
+
+// ExportWhiteListCountries godoc
+//
+//	@Summary		Export whitelisted countries
+//	@Description	export whitelisted countries as text, csv, or json
+//	@Tags			whitelist
+//	@Param			format	query	string	false	"output format"	Enums(text, csv, json)	default(text)
+//	@Produce		plain,json
+//	@Success		200
+//	@Failure		400
+//	@Failure		500
+//	@Router			/v1/whitelist/countries/export [get]
+func ExportWhiteListCountries(db *database.Database) func(*gin.Context) {
+	return listExport(db, listFormats, db.Q.EachWhiteListCountryEntry, whiteListCountryEntry)
+}
+
+// ImportBlackListCountries godoc
+//
+//	@Summary		Import blacklisted countries
+//	@Description	import countries to blacklist from text, csv, or json, replace mode removes the missing ones
+//	@Tags			blacklist
+//	@Accept			plain,json
+//	@Param			format	query		string	false	"body format"	Enums(text, csv, json)	default(text)
+//	@Param			mode	query		string	false	"import mode"	Enums(merge, replace)	default(merge)
+//	@Param			ttl		query		int		false	"entries ttl (in seconds)"
+//	@Param			comment	query		string	false	"entries comment"
+//	@Param			body	body		string	true	"countries to import"
+//	@Produce		json
+//	@Success		202		{object}	ImportResp
+//	@Failure		400
+//	@Failure		413
+//	@Failure		422		{object}	ImportResp
+//	@Failure		500
+//	@Router			/v1/blacklist/countries/import [post]
+func ImportBlackListCountries(blacklist *types.CountryList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return listImport(blacklist, mu, db, listFormats, parseName, identity[string], db.Q.EachBlackListCountryEntry, blackListCountryEntry, db.Q.CountBlackListCountry, upsertBlackListCountry(db), db.Q.RemoveBlackListCountry)
+}
+
+// ExportBlackListCountries godoc
+//
+//	@Summary		Export blacklisted countries
+//	@Description	export blacklisted countries as text, csv, or json
+//	@Tags			blacklist
+//	@Param			format	query	string	false	"output format"	Enums(text, csv, json)	default(text)
+//	@Produce		plain,json
+//	@Success		200
+//	@Failure		400
+//	@Failure		500
+//	@Router			/v1/blacklist/countries/export [get]
+func ExportBlackListCountries(db *database.Database) func(*gin.Context) {
+	return listExport(db, listFormats, db.Q.EachBlackListCountryEntry, blackListCountryEntry)
+}
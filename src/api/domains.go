@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"net/http"
+	"strings"
 	"sync"
 
 	"github.com/gin-gonic/gin"
@@ -14,40 +15,92 @@ import (
 // GetWhiteListDomains godoc
 //
 //	@Summary		Get whitelisted domains
-//	@Description	get all whitelisted domains
+//	@Description	get whitelisted domains, paginated if limit is set
 //	@Tags			whitelist
+//	@Param			search	query	string	false	"domain substring"
+//	@Param			prefix	query	string	false	"domain prefix"
+//	@Param			limit	query	int		false	"page size"
+//	@Param			cursor	query	string	false	"next page cursor"
+//	@Param			sort	query	string	false	"sort field"	Enums(value, created_at)	default(value)
+//	@Param			order	query	string	false	"sort order"	Enums(asc, desc)			default(asc)
 //	@Produce		json
 //	@Success		200	{object}	GetDomainsResp
+//	@Failure		400
+//	@Failure		500
 //	@Router			/v1/whitelist/domains [get]
-func GetWhiteListDomains(whitelist *types.DomainList, mu *sync.Mutex) func(*gin.Context) {
-	return domainListGetAll(whitelist, mu)
+func GetWhiteListDomains(db *database.Database) func(*gin.Context) {
+	return domainListGetAll(db, db.Q.GetWhiteListDomainPage, whiteListDomainEntry)
 }
 
 // GetBlackListDomains godoc
 //
 //	@Summary		Get blacklisted domains
-//	@Description	get all blacklisted domains
+//	@Description	get blacklisted domains, paginated if limit is set
 //	@Tags			blacklist
+//	@Param			search	query	string	false	"domain substring"
+//	@Param			prefix	query	string	false	"domain prefix"
+//	@Param			limit	query	int		false	"page size"
+//	@Param			cursor	query	string	false	"next page cursor"
+//	@Param			sort	query	string	false	"sort field"	Enums(value, created_at)	default(value)
+//	@Param			order	query	string	false	"sort order"	Enums(asc, desc)			default(asc)
 //	@Produce		json
 //	@Success		200	{object}	GetDomainsResp
+//	@Failure		400
+//	@Failure		500
 //	@Router			/v1/blacklist/domains [get]
-func GetBlackListDomains(blacklist *types.DomainList, mu *sync.Mutex) func(*gin.Context) {
-	return domainListGetAll(blacklist, mu)
+func GetBlackListDomains(db *database.Database) func(*gin.Context) {
+	return domainListGetAll(db, db.Q.GetBlackListDomainPage, blackListDomainEntry)
+}
+
+type GetDomainsQuery struct {
+	Search string `form:"search"`
+	Prefix string `form:"prefix"`
+	PageQuery
 }
 
 type GetDomainsResp struct {
 	Domains []string `json:"domains" example:"bad.com,dead.com"`
+	// Entries are the entries metadata by their values
+	Entries    map[string]Entry `json:"entries,omitempty"`
+	NextCursor string           `json:"next_cursor,omitempty"`
 }
 
-func domainListGetAll(list *types.DomainList, mu *sync.Mutex) func(*gin.Context) {
+func domainListGetAll[R any](
+	db *database.Database,
+	pageFn func(ctx context.Context, db database.DBTX, arg *database.ListPageParams) ([]*R, error),
+	entryFn func(row *R) (string, Entry),
+) func(*gin.Context) {
 	return func(c *gin.Context) {
-		mu.Lock()
-		defer mu.Unlock()
+		var query GetDomainsQuery
+		if err := c.ShouldBindQuery(&query); err != nil || !query.valid() {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		params := database.ListPageParams{
+			Search: strings.ToLower(strings.TrimSpace(query.Search)),
+			Prefix: strings.ToLower(strings.TrimSpace(query.Prefix)),
+		}
 
-		c.JSON(http.StatusOK, GetDomainsResp{Domains: list.GetAll()})
+		// NOTE: the database is updated before the list, no need to lock it
+		rows, next, err := listPage(c, db, query.PageQuery, params, pageFn, entryFn)
+		if err != nil {
+			c.AbortWithStatus(pageStatus(err))
+			return
+		}
+
+		domains, entries := pageEntries(rows, entryFn)
+		c.JSON(http.StatusOK, GetDomainsResp{Domains: domains, Entries: entries, NextCursor: next})
 	}
 }
 
+func whiteListDomainEntry(row *database.DomainWhitelist) (string, Entry) {
+	return row.Domain, newEntry(row.ExpiresAt, row.Comment, row.Source, row.CreatedBy, row.CreatedAt, row.LastHitAt)
+}
+
+func blackListDomainEntry(row *database.DomainBlacklist) (string, Entry) {
+	return row.Domain, newEntry(row.ExpiresAt, row.Comment, row.Source, row.CreatedBy, row.CreatedAt, row.LastHitAt)
+}
+
 // CheckWhiteListDomain godoc
 //
 //	@Summary		Check whitelisted domain
@@ -98,13 +151,14 @@ func domainListLookup(list *types.DomainList, mu *sync.Mutex) func(*gin.Context)
 //	@Tags			whitelist
 //	@Accept			json
 //	@Param			body	body	UpsertDomainsReq	true	"domains to add"
-//	@Success		202
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
 //	@Failure		400
-//	@Failure		422
+//	@Failure		422	{object}	MutateResp
 //	@Failure		500
 //	@Router			/v1/whitelist/domains [post]
 func UpsertWhiteListDomains(whitelist *types.DomainList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
-	return domainListUpsert(whitelist, mu, db, db.Q.UpsertWhiteListDomain)
+	return domainListUpsert(whitelist, mu, db, db.Q.CountWhiteListDomain, upsertWhiteListDomain(db))
 }
 
 // UpsertBlackListDomains godoc
@@ -114,24 +168,27 @@ func UpsertWhiteListDomains(whitelist *types.DomainList, mu *sync.Mutex, db *dat
 //	@Tags			blacklist
 //	@Accept			json
 //	@Param			body	body	UpsertDomainsReq	true	"domains to add"
-//	@Success		202
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
 //	@Failure		400
-//	@Failure		422
+//	@Failure		422	{object}	MutateResp
 //	@Failure		500
 //	@Router			/v1/blacklist/domains [post]
 func UpsertBlackListDomains(blacklist *types.DomainList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
-	return domainListUpsert(blacklist, mu, db, db.Q.UpsertBlackListDomain)
+	return domainListUpsert(blacklist, mu, db, db.Q.CountBlackListDomain, upsertBlackListDomain(db))
 }
 
 type UpsertDomainsReq struct {
 	Domains []string `json:"domains" example:"bad.com,dead.com"`
+	UpsertMeta
 }
 
 func domainListUpsert(
 	list *types.DomainList,
 	mu *sync.Mutex,
 	db *database.Database,
-	upsertFn func(ctx context.Context, db database.DBTX, domain string) error,
+	countFn func(ctx context.Context, db database.DBTX, domain string) (int64, error),
+	upsertFn func(ctx context.Context, db database.DBTX, domain string, meta entryMeta) error,
 ) func(*gin.Context) {
 	return func(c *gin.Context) {
 		var req UpsertDomainsReq
@@ -140,22 +197,64 @@ func domainListUpsert(
 			return
 		}
 
+		meta, err := req.meta(c)
+		if err != nil {
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+			return
+		}
+
+		domains, invalid, ok := parseEntries(req.Domains, parseName, identity[string])
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, MutateResp{Results: invalid})
+			return
+		}
+
 		mu.Lock()
 		defer mu.Unlock()
 
-		if err := list.Upsert(req.Domains); err != nil {
-			c.AbortWithStatus(http.StatusUnprocessableEntity)
+		// NOTE: the list is swapped only after the database commit
+		results, err := upsertEntries(c, db, domains, meta, identity[string], identity[string], countFn, upsertFn)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
 			return
 		}
 
-		for _, domain := range req.Domains {
-			if err := upsertFn(c, db.DB, domain); err != nil {
-				c.AbortWithStatus(http.StatusInternalServerError)
-				return
-			}
+		if err := list.Upsert(domains); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
 		}
 
-		c.Status(http.StatusAccepted)
+		c.JSON(http.StatusAccepted, MutateResp{Results: results})
+	}
+}
+
+func upsertWhiteListDomain(db *database.Database) func(ctx context.Context, dbtx database.DBTX, domain string, meta entryMeta) error {
+	return func(ctx context.Context, dbtx database.DBTX, domain string, meta entryMeta) error {
+		return db.Q.UpsertWhiteListDomain(ctx, dbtx, &database.UpsertWhiteListDomainParams{
+			Domain:        domain,
+			ExpiresAt:     meta.ExpiresAt,
+			Comment:       meta.Comment,
+			Source:        meta.Source,
+			DefaultSource: meta.DefaultSource,
+			CreatedBy:     meta.CreatedBy,
+			CreatedAt:     meta.CreatedAt,
+			LastHitAt:     meta.LastHitAt,
+		})
+	}
+}
+
+func upsertBlackListDomain(db *database.Database) func(ctx context.Context, dbtx database.DBTX, domain string, meta entryMeta) error {
+	return func(ctx context.Context, dbtx database.DBTX, domain string, meta entryMeta) error {
+		return db.Q.UpsertBlackListDomain(ctx, dbtx, &database.UpsertBlackListDomainParams{
+			Domain:        domain,
+			ExpiresAt:     meta.ExpiresAt,
+			Comment:       meta.Comment,
+			Source:        meta.Source,
+			DefaultSource: meta.DefaultSource,
+			CreatedBy:     meta.CreatedBy,
+			CreatedAt:     meta.CreatedAt,
+			LastHitAt:     meta.LastHitAt,
+		})
 	}
 }
 
@@ -166,9 +265,10 @@ func domainListUpsert(
 //	@Tags			whitelist
 //	@Accept			json
 //	@Param			body	body	RemoveDomainsReq	true	"domains to remove"
-//	@Success		202
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
 //	@Failure		400
-//	@Failure		422
+//	@Failure		422	{object}	MutateResp
 //	@Failure		500
 //	@Router			/v1/whitelist/domains [delete]
 func RemoveWhiteListDomains(whitelist *types.DomainList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
@@ -182,9 +282,10 @@ func RemoveWhiteListDomains(whitelist *types.DomainList, mu *sync.Mutex, db *dat
 //	@Tags			blacklist
 //	@Accept			json
 //	@Param			body	body	RemoveDomainsReq	true	"domains to remove"
-//	@Success		202
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
 //	@Failure		400
-//	@Failure		422
+//	@Failure		422	{object}	MutateResp
 //	@Failure		500
 //	@Router			/v1/blacklist/domains [delete]
 func RemoveBlackListDomains(blacklist *types.DomainList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
@@ -199,7 +300,7 @@ func domainListRemove(
 	list *types.DomainList,
 	mu *sync.Mutex,
 	db *database.Database,
-	removeFn func(ctx context.Context, db database.DBTX, domain string) error,
+	removeFn func(ctx context.Context, db database.DBTX, domain string) (int64, error),
 ) func(*gin.Context) {
 	return func(c *gin.Context) {
 		var req RemoveDomainsReq
@@ -208,21 +309,101 @@ func domainListRemove(
 			return
 		}
 
+		domains, invalid, ok := parseEntries(req.Domains, parseName, identity[string])
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, MutateResp{Results: invalid})
+			return
+		}
+
 		mu.Lock()
 		defer mu.Unlock()
 
-		if err := list.Remove(req.Domains); err != nil {
-			c.AbortWithStatus(http.StatusUnprocessableEntity)
+		// NOTE: the list is swapped only after the database commit
+		results, err := removeEntries(c, db, domains, identity[string], identity[string], removeFn)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
 			return
 		}
 
-		for _, domain := range req.Domains {
-			if err := removeFn(c, db.DB, domain); err != nil {
-				c.AbortWithStatus(http.StatusInternalServerError)
-				return
-			}
+		if err := list.Remove(domains); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
 		}
 
-		c.Status(http.StatusAccepted)
+		c.JSON(http.StatusAccepted, MutateResp{Results: results})
 	}
 }
+
+// ImportWhiteListDomains godoc
+//
+//	@Summary		Import whitelisted domains
+//	@Description	import domains to whitelist from text, csv, hosts, or json, replace mode removes the missing ones
+//	@Tags			whitelist
+//	@Accept			plain,json
+//	@Param			format	query		string	false	"body format"	Enums(text, csv, hosts, json)	default(text)
+//	@Param			mode	query		string	false	"import mode"	Enums(merge, replace)	default(merge)
+//	@Param			ttl		query		int		false	"entries ttl (in seconds)"
+//	@Param			comment	query		string	false	"entries comment"
+//	@Param			body	body		string	true	"domains to import"
+//	@Produce		json
+//	@Success		202		{object}	ImportResp
+//	@Failure		400
+//	@Failure		413
+//	@Failure		422		{object}	ImportResp
+//	@Failure		500
+//	@Router			/v1/whitelist/domains/import [post]
+func ImportWhiteListDomains(whitelist *types.DomainList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return listImport(whitelist, mu, db, domainFormats, parseName, identity[string], db.Q.EachWhiteListDomainEntry, whiteListDomainEntry, db.Q.CountWhiteListDomain, upsertWhiteListDomain(db), db.Q.RemoveWhiteListDomain)
+}
+
+// ExportWhiteListDomains godoc
+//
+//	@Summary		Export whitelisted domains
+//	@Description	export whitelisted domains as text, csv, hosts, or json
+//	@Tags			whitelist
+//	@Param			format	query	string	false	"output format"	Enums(text, csv, hosts, json)	default(text)
+//	@Produce		plain,json
+//	@Success		200
+//	@Failure		400
+//	@Failure		500
+//	@Router			/v1/whitelist/domains/export [get]
+func ExportWhiteListDomains(db *database.Database) func(*gin.Context) {
+	return listExport(db, domainFormats, db.Q.EachWhiteListDomainEntry, whiteListDomainEntry)
+}
+
+// ImportBlackListDomains godoc
+//
+//	@Summary		Import blacklisted domains
+//	@Description	import domains to blacklist from text, csv, hosts, or json, replace mode removes the missing ones
+//	@Tags			blacklist
+//	@Accept			plain,json
+//	@Param			format	query		string	false	"body format"	Enums(text, csv, hosts, json)	default(text)
+//	@Param			mode	query		string	false	"import mode"	Enums(merge, replace)	default(merge)
+//	@Param			ttl		query		int		false	"entries ttl (in seconds)"
+//	@Param			comment	query		string	false	"entries comment"
+//	@Param			body	body		string	true	"domains to import"
+//	@Produce		json
+//	@Success		202		{object}	ImportResp
+//	@Failure		400
+//	@Failure		413
+//	@Failure		422		{object}	ImportResp
+//	@Failure		500
+//	@Router			/v1/blacklist/domains/import [post]
+func ImportBlackListDomains(blacklist *types.DomainList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return listImport(blacklist, mu, db, domainFormats, parseName, identity[string], db.Q.EachBlackListDomainEntry, blackListDomainEntry, db.Q.CountBlackListDomain, upsertBlackListDomain(db), db.Q.RemoveBlackListDomain)
+}
+
+// ExportBlackListDomains godoc
+//
+//	@Summary		Export blacklisted domains
+//	@Description	export blacklisted domains as text, csv, hosts, or json
+//	@Tags			blacklist
+//	@Param			format	query	string	false	"output format"	Enums(text, csv, hosts, json)	default(text)
+//	@Produce		plain,json
+//	@Success		200
+//	@Failure		400
+//	@Failure		500
+//	@Router			/v1/blacklist/domains/export [get]
+func ExportBlackListDomains(db *database.Database) func(*gin.Context) {
+	return listExport(db, domainFormats, db.Q.EachBlackListDomainEntry, blackListDomainEntry)
+}
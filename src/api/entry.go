@@ -0,0 +1,298 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cnaize/meds/src/database"
+	"github.com/cnaize/meds/src/types"
+)
+
+// Entry is the white/black list entry metadata
+type Entry struct {
+	// TTL is the remaining seconds of the temporary entry
+	TTL       int64      `json:"ttl,omitempty" example:"3600"`
+	Comment   string     `json:"comment,omitempty" example:"port scanner"`
+	Source    string     `json:"source,omitempty" example:"api"`
+	CreatedBy string     `json:"created_by,omitempty" example:"admin"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	LastHitAt *time.Time `json:"last_hit_at,omitempty"`
+}
+
+func newEntry(expiresAt int64, comment, source, createdBy string, createdAt, lastHitAt int64) Entry {
+	entry := Entry{
+		Comment:   comment,
+		Source:    source,
+		CreatedBy: createdBy,
+	}
+	if expiresAt > 0 {
+		entry.TTL = max(0, expiresAt-time.Now().Unix())
+	}
+	if createdAt > 0 {
+		entry.CreatedAt = timePtr(time.Unix(createdAt, 0).UTC())
+	}
+	if lastHitAt > 0 {
+		entry.LastHitAt = timePtr(time.Unix(lastHitAt, 0).UTC())
+	}
+
+	return entry
+}
+
+// UpsertMeta is the optional metadata of the upserted entries, the unset fields of existing entries are kept
+type UpsertMeta struct {
+	// TTL (in seconds) or ExpiresAt makes the entries temporary
+	TTL       int64      `json:"ttl,omitempty" example:"3600"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2030-01-01T00:00:00Z"`
+	Comment   string     `json:"comment,omitempty" example:"port scanner"`
+	Source    string     `json:"source,omitempty" example:"api" enums:"api,auto,import"`
+}
+
+// entryMeta is the metadata stored with the entry,
+// the unset fields (zero expiration, empty comment and source) of an existing entry are kept
+type entryMeta struct {
+	ExpiresAt int64
+	Comment   string
+	Source    string
+	// DefaultSource is the source of the new entries if unset
+	DefaultSource string
+	CreatedBy     string
+	CreatedAt     int64
+	LastHitAt     int64
+}
+
+func (m UpsertMeta) meta(c *gin.Context) (entryMeta, error) {
+	expiresAt, err := expiresAt(m.TTL, m.ExpiresAt)
+	if err != nil {
+		return entryMeta{}, err
+	}
+
+	switch m.Source {
+	case "", types.SourceAPI, types.SourceAuto, types.SourceImport:
+	default:
+		return entryMeta{}, fmt.Errorf("invalid source: %s", m.Source)
+	}
+
+	return entryMeta{
+		ExpiresAt:     expiresAt,
+		Comment:       m.Comment,
+		Source:        m.Source,
+		DefaultSource: types.SourceAPI,
+		CreatedBy:     c.GetString(gin.AuthUserKey),
+		CreatedAt:     time.Now().Unix(),
+	}, nil
+}
+
+// with overrides the metadata by the imported entry one (if set)
+func (m entryMeta) with(entry Entry) (entryMeta, error) {
+	switch {
+	case entry.TTL < 0:
+		return entryMeta{}, fmt.Errorf("negative ttl: %d", entry.TTL)
+	case entry.TTL > 0:
+		m.ExpiresAt = time.Now().Unix() + entry.TTL
+	}
+
+	switch entry.Source {
+	case "":
+	case types.SourceAPI, types.SourceAuto, types.SourceImport:
+		m.Source = entry.Source
+	default:
+		return entryMeta{}, fmt.Errorf("invalid source: %s", entry.Source)
+	}
+
+	if len(entry.Comment) > 0 {
+		m.Comment = entry.Comment
+	}
+	if len(entry.CreatedBy) > 0 {
+		m.CreatedBy = entry.CreatedBy
+	}
+	if entry.CreatedAt != nil {
+		m.CreatedAt = entry.CreatedAt.Unix()
+	}
+	if entry.LastHitAt != nil {
+		m.LastHitAt = entry.LastHitAt.Unix()
+	}
+
+	return m, nil
+}
+
+// expiresAt returns the entry expiration unix time (0 means never expires)
+func expiresAt(ttl int64, at *time.Time) (int64, error) {
+	switch {
+	case ttl < 0:
+		return 0, fmt.Errorf("negative ttl: %d", ttl)
+	case ttl > 0 && at != nil:
+		return 0, fmt.Errorf("both ttl and expires_at are set")
+	case ttl > 0:
+		return time.Now().Unix() + ttl, nil
+	case at != nil:
+		if !at.After(time.Now()) {
+			return 0, fmt.Errorf("expires_at in the past: %s", at)
+		}
+		return at.Unix(), nil
+	}
+
+	return 0, nil
+}
+
+// entry mutation statuses
+const (
+	StatusCreated  = "created"
+	StatusUpdated  = "updated"
+	StatusRemoved  = "removed"
+	StatusNotFound = "not_found"
+	StatusInvalid  = "invalid"
+)
+
+// Result is the per-entry mutation result
+type Result struct {
+	Value  string `json:"value" example:"100.100.100.100/32"`
+	Status string `json:"status" example:"created" enums:"created,updated,removed,not_found,invalid"`
+}
+
+type MutateResp struct {
+	Results []Result `json:"results"`
+}
+
+// parseEntries validates and deduplicates the requested entries
+func parseEntries[R any, T comparable](items []R, parseFn func(item R) (T, bool), valueFn func(item R) string) ([]T, []Result, bool) {
+	entries := make([]T, 0, len(items))
+	seen := make(map[T]bool, len(items))
+	var invalid []Result
+	for _, item := range items {
+		entry, ok := parseFn(item)
+		if !ok {
+			invalid = append(invalid, Result{Value: valueFn(item), Status: StatusInvalid})
+			continue
+		}
+
+		if !seen[entry] {
+			seen[entry] = true
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, invalid, len(invalid) < 1
+}
+
+// upsertEntries upserts the entries to the database in a single transaction
+func upsertEntries[T, K any](
+	ctx context.Context,
+	db *database.Database,
+	entries []T,
+	meta entryMeta,
+	keyFn func(entry T) K,
+	valueFn func(entry T) string,
+	countFn func(ctx context.Context, db database.DBTX, key K) (int64, error),
+	upsertFn func(ctx context.Context, db database.DBTX, key K, meta entryMeta) error,
+) ([]Result, error) {
+	var results []Result
+	err := withTx(ctx, db, func(tx database.DBTX) error {
+		var err error
+		results, err = upsertEntriesTx(ctx, tx, entries, meta, keyFn, valueFn, countFn, upsertFn)
+		return err
+	})
+
+	return results, err
+}
+
+func upsertEntriesTx[T, K any](
+	ctx context.Context,
+	tx database.DBTX,
+	entries []T,
+	meta entryMeta,
+	keyFn func(entry T) K,
+	valueFn func(entry T) string,
+	countFn func(ctx context.Context, db database.DBTX, key K) (int64, error),
+	upsertFn func(ctx context.Context, db database.DBTX, key K, meta entryMeta) error,
+) ([]Result, error) {
+	results := make([]Result, len(entries))
+	for i, entry := range entries {
+		key := keyFn(entry)
+		count, err := countFn(ctx, tx, key)
+		if err != nil {
+			return nil, fmt.Errorf("count: %w", err)
+		}
+
+		if err := upsertFn(ctx, tx, key, meta); err != nil {
+			return nil, fmt.Errorf("upsert: %w", err)
+		}
+
+		results[i] = Result{Value: valueFn(entry), Status: StatusCreated}
+		if count > 0 {
+			results[i].Status = StatusUpdated
+		}
+	}
+
+	return results, nil
+}
+
+// removeEntries removes the entries from the database in a single transaction
+func removeEntries[T, K any](
+	ctx context.Context,
+	db *database.Database,
+	entries []T,
+	keyFn func(entry T) K,
+	valueFn func(entry T) string,
+	removeFn func(ctx context.Context, db database.DBTX, key K) (int64, error),
+) ([]Result, error) {
+	var results []Result
+	err := withTx(ctx, db, func(tx database.DBTX) error {
+		var err error
+		results, err = removeEntriesTx(ctx, tx, entries, keyFn, valueFn, removeFn)
+		return err
+	})
+
+	return results, err
+}
+
+func removeEntriesTx[T, K any](
+	ctx context.Context,
+	tx database.DBTX,
+	entries []T,
+	keyFn func(entry T) K,
+	valueFn func(entry T) string,
+	removeFn func(ctx context.Context, db database.DBTX, key K) (int64, error),
+) ([]Result, error) {
+	results := make([]Result, len(entries))
+	for i, entry := range entries {
+		count, err := removeFn(ctx, tx, keyFn(entry))
+		if err != nil {
+			return nil, fmt.Errorf("remove: %w", err)
+		}
+
+		results[i] = Result{Value: valueFn(entry), Status: StatusRemoved}
+		if count < 1 {
+			results[i].Status = StatusNotFound
+		}
+	}
+
+	return results, nil
+}
+
+func withTx(ctx context.Context, db *database.Database, fn func(tx database.DBTX) error) error {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// parseName normalizes a domain or a country
+func parseName(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return name, name != ""
+}
+
+func identity[T any](value T) T {
+	return value
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cnaize/meds/src/types"
+)
+
+func TestUpsertKeepsMeta(t *testing.T) {
+	db := newTestDatabase(t)
+	list := types.NewSubnetList()
+
+	var mu sync.Mutex
+	r := gin.New()
+	r.POST("/subnets", UpsertBlackListSubnets(list, &mu, db))
+	r.POST("/import", ImportBlackListSubnets(list, &mu, db))
+	r.GET("/export", ExportBlackListSubnets(db))
+
+	export := func() (string, int64) {
+		t.Helper()
+
+		w := serve(r, http.MethodGet, "/export?format=csv", "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("export status %d", w.Code)
+		}
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("exported %q, want a single entry", w.Body)
+		}
+
+		// NOTE: the timestamps are dropped
+		fields := strings.Split(lines[1], ",")
+		ttl, _ := strconv.ParseInt(fields[1], 10, 64)
+
+		return fields[0] + "," + strings.Join(fields[2:4], ","), ttl
+	}
+
+	steps := []struct {
+		name string
+		url  string
+		body string
+		want string
+		ttl  int64
+	}{
+		{
+			name: "create",
+			url:  "/subnets",
+			body: `{"subnets": ["1.2.3.4"], "ttl": 3600, "comment": "scanner", "source": "auto"}`,
+			want: "1.2.3.4/32,scanner,auto",
+			ttl:  3600,
+		},
+		{
+			name: "re-add without meta",
+			url:  "/subnets",
+			body: `{"subnets": ["1.2.3.4"]}`,
+			want: "1.2.3.4/32,scanner,auto",
+			ttl:  3600,
+		},
+		{
+			name: "import without meta",
+			url:  "/import?format=text",
+			body: "1.2.3.4\n",
+			want: "1.2.3.4/32,scanner,auto",
+			ttl:  3600,
+		},
+		{
+			name: "override",
+			url:  "/subnets",
+			body: `{"subnets": ["1.2.3.4"], "ttl": 7200, "comment": "brute force", "source": "api"}`,
+			want: "1.2.3.4/32,brute force,api",
+			ttl:  7200,
+		},
+	}
+
+	for _, step := range steps {
+		if w := serve(r, http.MethodPost, step.url, step.body); w.Code >= http.StatusMultipleChoices {
+			t.Fatalf("%s: status %d: %s", step.name, w.Code, w.Body)
+		}
+		// NOTE: the remaining ttl is exported
+		if got, ttl := export(); got != step.want || ttl > step.ttl || ttl < step.ttl-60 {
+			t.Errorf("%s: got %q with ttl %d, want %q with ttl %d", step.name, got, ttl, step.want, step.ttl)
+		}
+	}
+}
+
+func TestUpsertDefaultSource(t *testing.T) {
+	db := newTestDatabase(t)
+	list := types.NewSubnetList()
+
+	var mu sync.Mutex
+	r := gin.New()
+	r.POST("/subnets", UpsertWhiteListSubnets(list, &mu, db))
+	r.POST("/import", ImportWhiteListSubnets(list, &mu, db))
+	r.GET("/export", ExportWhiteListSubnets(db))
+
+	serve(r, http.MethodPost, "/subnets", `{"subnets": ["1.1.1.1"]}`)
+	serve(r, http.MethodPost, "/import?format=text", "2.2.2.2\n")
+
+	w := serve(r, http.MethodGet, "/export?format=csv", "")
+	if !strings.Contains(w.Body.String(), "1.1.1.1/32,0,,api,") || !strings.Contains(w.Body.String(), "2.2.2.2/32,0,,import,") {
+		t.Errorf("exported %q, want api and import sources", w.Body)
+	}
+}
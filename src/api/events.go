@@ -0,0 +1,146 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/database"
+)
+
+const defaultEventsLimit = 100
+
+// GetEvents godoc
+//
+//	@Summary		Get events
+//	@Description	get stored packet verdict events (newest first)
+//	@Tags			events
+//	@Param			since	query	string	false	"events after the time (RFC3339)"	example(2025-01-02T15:04:05Z)
+//	@Param			until	query	string	false	"events before the time (RFC3339)"	example(2025-01-03T15:04:05Z)
+//	@Param			action	query	string	false	"packet action"						Enums(accept, drop, trust)
+//	@Param			filter	query	string	false	"filter type"						example(ip)
+//	@Param			reason	query	string	false	"filter name or reason"				example(FireHOL)
+//	@Param			ip		query	string	false	"source ip"							example(1.2.3.4)
+//	@Param			country	query	string	false	"source country"					example(us)
+//	@Param			port	query	int		false	"destination port"					example(443)
+//	@Param			limit	query	int		false	"page size"							default(100)
+//	@Param			cursor	query	string	false	"next page cursor"
+//	@Produce		json
+//	@Success		200	{object}	GetEventsResp
+//	@Failure		400
+//	@Failure		500
+//	@Router			/v1/events [get]
+func GetEvents(db *database.Database) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var query GetEventsQuery
+		if err := c.ShouldBindQuery(&query); err != nil || query.Limit < 0 || query.Limit > maxPageLimit {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		if query.Limit == 0 {
+			query.Limit = defaultEventsLimit
+		}
+
+		params := database.GetEventsParams{
+			Before:  math.MaxInt64,
+			Until:   math.MaxInt64,
+			Action:  query.Action,
+			Filter:  query.Filter,
+			Reason:  query.Reason,
+			Country: strings.ToLower(query.Country),
+			DstPort: int64(query.Port),
+			// NOTE: one more to find out whether there is the next page
+			Limit: int64(query.Limit) + 1,
+		}
+		if query.Cursor != "" {
+			before, err := strconv.ParseInt(query.Cursor, 10, 64)
+			if err != nil {
+				c.AbortWithStatus(http.StatusBadRequest)
+				return
+			}
+			params.Before = before
+		}
+		if !query.Since.IsZero() {
+			params.Since = query.Since.Unix()
+		}
+		if !query.Until.IsZero() {
+			params.Until = query.Until.Unix()
+		}
+		if query.IP != "" {
+			srcIP, err := netip.ParseAddr(query.IP)
+			if err != nil {
+				c.AbortWithStatus(http.StatusBadRequest)
+				return
+			}
+			params.SrcIp = srcIP.Unmap().String()
+		}
+
+		rows, err := db.Q.GetEvents(c, db.DB, &params)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		var resp GetEventsResp
+		if len(rows) > query.Limit {
+			rows = rows[:query.Limit]
+			resp.NextCursor = strconv.FormatInt(rows[len(rows)-1].ID, 10)
+		}
+
+		resp.Events = make([]Event, len(rows))
+		for i, row := range rows {
+			resp.Events[i] = Event{
+				ID:        row.ID,
+				CreatedAt: time.Unix(row.CreatedAt, 0).UTC(),
+				Action:    row.Action,
+				Reason:    row.Reason,
+				Filter:    filter.FilterType(row.Filter),
+				Target:    row.Target,
+				IP:        row.SrcIp,
+				Port:      uint16(row.DstPort),
+				Country:   row.Country,
+				ASN:       uint32(row.Asn),
+			}
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+type GetEventsQuery struct {
+	Since   time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Until   time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+	Action  string    `form:"action"`
+	Filter  string    `form:"filter"`
+	Reason  string    `form:"reason"`
+	IP      string    `form:"ip"`
+	Country string    `form:"country"`
+	Port    uint16    `form:"port"`
+	Limit   int       `form:"limit"`
+	Cursor  string    `form:"cursor"`
+}
+
+type GetEventsResp struct {
+	Events     []Event `json:"events"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+type Event struct {
+	ID        int64             `json:"id" example:"42"`
+	CreatedAt time.Time         `json:"created_at"`
+	Action    string            `json:"action" example:"drop" enums:"accept,drop,trust"`
+	Reason    string            `json:"reason" example:"FireHOL"`
+	Filter    filter.FilterType `json:"filter" example:"ip"`
+	// Target is the packet part checked by the filter
+	Target  string `json:"target" example:"1.2.3.4"`
+	IP      string `json:"ip,omitempty" example:"1.2.3.4"`
+	Port    uint16 `json:"port,omitempty" example:"443"`
+	Country string `json:"country,omitempty" example:"us"`
+	ASN     uint32 `json:"asn,omitempty" example:"13335"`
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/filter/feed"
+	"github.com/cnaize/meds/src/database"
+)
+
+// GetFeeds godoc
+//
+//	@Summary		Get feeds
+//	@Description	get all feeds added via api
+//	@Tags			feeds
+//	@Produce		json
+//	@Success		200	{object}	GetFeedsResp
+//	@Failure		500
+//	@Router			/v1/feeds [get]
+func GetFeeds(mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return func(c *gin.Context) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		rows, err := db.Q.GetAllFeeds(c, db.DB)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		feeds := make([]feed.Feed, len(rows))
+		for i, row := range rows {
+			feeds[i] = feed.Feed{
+				Name:     row.Name,
+				Type:     filter.FilterType(row.Type),
+				Interval: time.Duration(row.UpdateInterval),
+			}
+
+			if err := json.Unmarshal([]byte(row.Sources), &feeds[i].Sources); err != nil {
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+			if err := json.Unmarshal([]byte(row.Limits), &feeds[i].Limits); err != nil {
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, GetFeedsResp{Feeds: feeds})
+	}
+}
+
+type GetFeedsResp struct {
+	Feeds []feed.Feed `json:"feeds"`
+}
+
+// UpsertFeed godoc
+//
+//	@Summary		Upsert feed
+//	@Description	add or replace a feed and rebuild filters
+//	@Tags			feeds
+//	@Accept			json
+//	@Param			body	body	UpsertFeedReq	true	"feed to add"
+//	@Success		202
+//	@Failure		400
+//	@Failure		422
+//	@Failure		500
+//	@Router			/v1/feeds [post]
+func UpsertFeed(mu *sync.Mutex, db *database.Database, rebuildFn func(ctx context.Context) error) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var req UpsertFeedReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		if err := req.Validate(); err != nil {
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+			return
+		}
+
+		sources, err := json.Marshal(req.Sources)
+		if err != nil {
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+			return
+		}
+		limits, err := json.Marshal(req.Limits)
+		if err != nil {
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err := db.Q.UpsertFeed(c, db.DB, &database.UpsertFeedParams{
+			Name:           req.Name,
+			Type:           string(req.Type),
+			Sources:        string(sources),
+			UpdateInterval: int64(req.Interval),
+			Limits:         string(limits),
+		}); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// don't interrupt rebuild on client disconnect
+		if err := rebuildFn(context.WithoutCancel(c)); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.Status(http.StatusAccepted)
+	}
+}
+
+type UpsertFeedReq struct {
+	feed.Feed
+}
+
+// RemoveFeed godoc
+//
+//	@Summary		Remove feed
+//	@Description	remove a feed added via api and rebuild filters
+//	@Tags			feeds
+//	@Accept			json
+//	@Param			body	body	RemoveFeedReq	true	"feed to remove"
+//	@Success		202
+//	@Failure		400
+//	@Failure		500
+//	@Router			/v1/feeds [delete]
+func RemoveFeed(mu *sync.Mutex, db *database.Database, rebuildFn func(ctx context.Context) error) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var req RemoveFeedReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err := db.Q.RemoveFeed(c, db.DB, &database.RemoveFeedParams{
+			Type: string(req.Type),
+			Name: req.Name,
+		}); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// don't interrupt rebuild on client disconnect
+		if err := rebuildFn(context.WithoutCancel(c)); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.Status(http.StatusAccepted)
+	}
+}
+
+type RemoveFeedReq struct {
+	Name string            `json:"name" example:"Spamhaus"`
+	Type filter.FilterType `json:"type" example:"ip"`
+}
@@ -0,0 +1,309 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cnaize/meds/src/core"
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/database"
+)
+
+// GetFilters godoc
+//
+//	@Summary		Get filters
+//	@Description	get all running filters with their update status
+//	@Tags			filters
+//	@Produce		json
+//	@Success		200	{object}	GetFiltersResp
+//	@Router			/v1/filters [get]
+func GetFilters(statusFn func() []core.FilterStatus) func(*gin.Context) {
+	return func(c *gin.Context) {
+		statuses := statusFn()
+
+		filters := make([]FilterStatus, len(statuses))
+		for i, status := range statuses {
+			filters[i] = FilterStatus{
+				Name:        status.Name,
+				Type:        status.Type,
+				LastAttempt: timePtr(status.LastAttempt),
+				LastSuccess: timePtr(status.LastSuccess),
+				Duration:    status.Duration.String(),
+				Entries:     status.Entries,
+				Bytes:       status.Bytes,
+				Error:       status.Error,
+				Updating:    status.Updating,
+				Enabled:     status.Enabled,
+			}
+		}
+
+		c.JSON(http.StatusOK, GetFiltersResp{Filters: filters})
+	}
+}
+
+type GetFiltersResp struct {
+	Filters []FilterStatus `json:"filters"`
+}
+
+type FilterStatus struct {
+	Name        string            `json:"name" example:"FireHOL"`
+	Type        filter.FilterType `json:"type" example:"ip"`
+	LastAttempt *time.Time        `json:"last_attempt,omitempty"`
+	LastSuccess *time.Time        `json:"last_success,omitempty"`
+	Duration    string            `json:"duration" example:"1.5s"`
+	Entries     int               `json:"entries" example:"4500"`
+	Bytes       int               `json:"bytes" example:"120000"`
+	Error       string            `json:"error,omitempty"`
+	Updating    bool              `json:"updating"`
+	Enabled     bool              `json:"enabled"`
+}
+
+// FilterByName serves "/v1/filters/{name}/..." as "/v1/filters/{type}/{name}/...":
+// the type is looked up by the running filter name, 409 if several filters share it (e.g. "WhiteList")
+func FilterByName(statusFn func() []core.FilterStatus) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// NOTE: the only path segment is the name
+		name := c.Param("type")
+
+		var types []filter.FilterType
+		for _, status := range statusFn() {
+			if status.Name == name {
+				types = append(types, status.Type)
+			}
+		}
+
+		switch len(types) {
+		case 0:
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		case 1:
+		default:
+			c.AbortWithStatus(http.StatusConflict)
+			return
+		}
+
+		c.Params = gin.Params{{Key: "type", Value: string(types[0])}, {Key: "name", Value: name}}
+		c.Next()
+	}
+}
+
+// UpdateFilter godoc
+//
+//	@Summary		Update filter
+//	@Description	schedule an immediate filter update
+//	@Tags			filters
+//	@Param			type	path	string	true	"filter type (may be omitted if the name is unique)"	example(ip)
+//	@Param			name	path	string	true	"filter name"	example(FireHOL)
+//	@Success		202
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/v1/filters/{type}/{name}/update [post]
+//	@Router			/v1/filters/{name}/update [post]
+func UpdateFilter(updateFn func(typ filter.FilterType, name string) error) func(*gin.Context) {
+	return func(c *gin.Context) {
+		if err := updateFn(filter.FilterType(c.Param("type")), c.Param("name")); err != nil {
+			if errors.Is(err, core.ErrFilterNotFound) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.Status(http.StatusAccepted)
+	}
+}
+
+// SetFilter godoc
+//
+//	@Summary		Set filter
+//	@Description	enable or disable a filter (persisted)
+//	@Tags			filters
+//	@Accept			json
+//	@Param			type	path	string			true	"filter type (may be omitted if the name is unique)"	example(domain)
+//	@Param			name	path	string			true	"filter name"	example(StevenBlack)
+//	@Param			body	body	SetFilterReq	true	"filter state"
+//	@Success		202
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Failure		409
+//	@Router			/v1/filters/{type}/{name} [put]
+//	@Router			/v1/filters/{name} [put]
+func SetFilter(
+	mu *sync.Mutex,
+	db *database.Database,
+	statusFn func() []core.FilterStatus,
+	enableFn func(typ filter.FilterType, name string, enabled bool) error,
+) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var req SetFilterReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		typ := filter.FilterType(c.Param("type"))
+		name := c.Param("name")
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		statuses := statusFn()
+		i := slices.IndexFunc(statuses, func(status core.FilterStatus) bool {
+			return status.Type == typ && status.Name == name
+		})
+		if i < 0 {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		enabled := statuses[i].Enabled
+
+		// NOTE: the filter is switched once persisted, the row is rolled back if switching fails
+		var switched bool
+		err := withTx(c, db, func(tx database.DBTX) error {
+			if err := db.Q.UpsertFilterState(c, tx, &database.UpsertFilterStateParams{
+				Name:    name,
+				Type:    string(typ),
+				Enabled: *req.Enabled,
+			}); err != nil {
+				return fmt.Errorf("upsert: %w", err)
+			}
+
+			if err := enableFn(typ, name, *req.Enabled); err != nil {
+				return fmt.Errorf("enable: %w", err)
+			}
+			switched = true
+
+			return nil
+		})
+		if err != nil {
+			// restore the running state if the commit failed
+			if switched {
+				enableFn(typ, name, enabled)
+			}
+
+			if errors.Is(err, core.ErrFilterNotFound) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.Status(http.StatusAccepted)
+	}
+}
+
+type SetFilterReq struct {
+	Enabled *bool `json:"enabled" binding:"required" example:"false"`
+}
+
+// GetFilterHistory godoc
+//
+//	@Summary		Get filter history
+//	@Description	get recorded filter list changes (newest first)
+//	@Tags			filters
+//	@Produce		json
+//	@Param			type	path		string	true	"filter type (may be omitted if the name is unique)"	example(ip)
+//	@Param			name	path		string	true	"filter name"	example(FireHOL)
+//	@Success		200		{object}	GetFilterHistoryResp
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/v1/filters/{type}/{name}/history [get]
+//	@Router			/v1/filters/{name}/history [get]
+func GetFilterHistory(db *database.Database) func(*gin.Context) {
+	return func(c *gin.Context) {
+		rows, err := db.Q.GetFilterHistory(c, db.DB, &database.GetFilterHistoryParams{
+			Type: c.Param("type"),
+			Name: c.Param("name"),
+		})
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		history := make([]FilterHistory, len(rows))
+		for i, row := range rows {
+			history[i] = FilterHistory{
+				ID:        row.ID,
+				CreatedAt: time.Unix(row.CreatedAt, 0).UTC(),
+			}
+			if err := json.Unmarshal([]byte(row.Added), &history[i].Added); err != nil {
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+			if err := json.Unmarshal([]byte(row.Removed), &history[i].Removed); err != nil {
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, GetFilterHistoryResp{History: history})
+	}
+}
+
+type GetFilterHistoryResp struct {
+	History []FilterHistory `json:"history"`
+}
+
+type FilterHistory struct {
+	ID        int64     `json:"id" example:"42"`
+	CreatedAt time.Time `json:"created_at"`
+	Added     []string  `json:"added" example:"1.2.3.0/24"`
+	Removed   []string  `json:"removed" example:"5.6.7.8/32"`
+}
+
+// RollbackFilter godoc
+//
+//	@Summary		Rollback filter
+//	@Description	restore the filter list before the last recorded change
+//	@Tags			filters
+//	@Param			type	path	string	true	"filter type (may be omitted if the name is unique)"	example(ip)
+//	@Param			name	path	string	true	"filter name"	example(FireHOL)
+//	@Success		202
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/v1/filters/{type}/{name}/rollback [post]
+//	@Router			/v1/filters/{name}/rollback [post]
+func RollbackFilter(rollbackFn func(ctx context.Context, typ filter.FilterType, name string) error) func(*gin.Context) {
+	return func(c *gin.Context) {
+		if err := rollbackFn(c, filter.FilterType(c.Param("type")), c.Param("name")); err != nil {
+			if errors.Is(err, core.ErrFilterNotFound) || errors.Is(err, core.ErrNoHistory) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, core.ErrFilterBusy) {
+				c.AbortWithStatus(http.StatusConflict)
+				return
+			}
+
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.Status(http.StatusAccepted)
+	}
+}
+
+// timePtr returns nil for zero time
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cnaize/meds/src/core"
+	"github.com/cnaize/meds/src/core/filter"
+)
+
+func TestFilterByName(t *testing.T) {
+	statusFn := func() []core.FilterStatus {
+		return []core.FilterStatus{
+			{Name: "FireHOL", Type: filter.FilterTypeIP},
+			{Name: filter.FilterNameWhiteList, Type: filter.FilterTypeIP},
+			{Name: filter.FilterNameWhiteList, Type: filter.FilterTypeDomain},
+		}
+	}
+
+	var updated []string
+	updateFn := func(typ filter.FilterType, name string) error {
+		updated = append(updated, string(typ)+"/"+name)
+		return nil
+	}
+
+	r := gin.New()
+	r.POST("/filters/:type/:name/update", UpdateFilter(updateFn))
+	r.POST("/filters/:type/update", FilterByName(statusFn), UpdateFilter(updateFn))
+
+	tests := []struct {
+		url    string
+		status int
+		want   string
+	}{
+		{url: "/filters/ip/FireHOL/update", status: http.StatusAccepted, want: "ip/FireHOL"},
+		{url: "/filters/FireHOL/update", status: http.StatusAccepted, want: "ip/FireHOL"},
+		{url: "/filters/domain/WhiteList/update", status: http.StatusAccepted, want: "domain/WhiteList"},
+		{url: "/filters/WhiteList/update", status: http.StatusConflict},
+		{url: "/filters/Missing/update", status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			updated = nil
+			w := serve(r, http.MethodPost, tt.url, "")
+			if w.Code != tt.status {
+				t.Fatalf("status %d, want %d", w.Code, tt.status)
+			}
+			if tt.want != "" && (len(updated) != 1 || updated[0] != tt.want) {
+				t.Errorf("updated %v, want %s", updated, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cnaize/meds/src/database"
+	"github.com/cnaize/meds/src/types"
+)
+
+// GetWhiteListJA3s godoc
+//
+//	@Summary		Get whitelisted ja3s
+//	@Description	get whitelisted ja3 hashes, paginated if limit is set
+//	@Tags			whitelist
+//	@Param			search	query	string	false	"ja3 hash substring"
+//	@Param			limit	query	int		false	"page size"
+//	@Param			cursor	query	string	false	"next page cursor"
+//	@Param			sort	query	string	false	"sort field"	Enums(value, created_at)	default(value)
+//	@Param			order	query	string	false	"sort order"	Enums(asc, desc)			default(asc)
+//	@Produce		json
+//	@Success		200	{object}	GetJA3sResp
+//	@Failure		400
+//	@Failure		500
+//	@Router			/v1/whitelist/ja3s [get]
+func GetWhiteListJA3s(db *database.Database) func(*gin.Context) {
+	return ja3ListGetAll(db, db.Q.GetWhiteListJA3Page, whiteListJA3Entry)
+}
+
+// GetBlackListJA3s godoc
+//
+//	@Summary		Get blacklisted ja3s
+//	@Description	get blacklisted ja3 hashes, paginated if limit is set
+//	@Tags			blacklist
+//	@Param			search	query	string	false	"ja3 hash substring"
+//	@Param			limit	query	int		false	"page size"
+//	@Param			cursor	query	string	false	"next page cursor"
+//	@Param			sort	query	string	false	"sort field"	Enums(value, created_at)	default(value)
+//	@Param			order	query	string	false	"sort order"	Enums(asc, desc)			default(asc)
+//	@Produce		json
+//	@Success		200	{object}	GetJA3sResp
+//	@Failure		400
+//	@Failure		500
+//	@Router			/v1/blacklist/ja3s [get]
+func GetBlackListJA3s(db *database.Database) func(*gin.Context) {
+	return ja3ListGetAll(db, db.Q.GetBlackListJA3Page, blackListJA3Entry)
+}
+
+type GetJA3sQuery struct {
+	Search string `form:"search"`
+	PageQuery
+}
+
+type GetJA3sResp struct {
+	JA3s []string `json:"ja3s" example:"e7d705a3286e19ea42f587b344ee6865,6734f37431670b3ab4292b8f60f29984"`
+	// Entries are the entries metadata by their values
+	Entries    map[string]Entry `json:"entries,omitempty"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+func ja3ListGetAll[R any](
+	db *database.Database,
+	pageFn func(ctx context.Context, db database.DBTX, arg *database.ListPageParams) ([]*R, error),
+	entryFn func(row *R) (string, Entry),
+) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var query GetJA3sQuery
+		if err := c.ShouldBindQuery(&query); err != nil || !query.valid() {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		params := database.ListPageParams{Search: strings.ToLower(strings.TrimSpace(query.Search))}
+
+		// NOTE: the database is updated before the list, no need to lock it
+		rows, next, err := listPage(c, db, query.PageQuery, params, pageFn, entryFn)
+		if err != nil {
+			c.AbortWithStatus(pageStatus(err))
+			return
+		}
+
+		ja3s, entries := pageEntries(rows, entryFn)
+		c.JSON(http.StatusOK, GetJA3sResp{JA3s: ja3s, Entries: entries, NextCursor: next})
+	}
+}
+
+func whiteListJA3Entry(row *database.Ja3Whitelist) (string, Entry) {
+	return row.Hash, newEntry(row.ExpiresAt, row.Comment, row.Source, row.CreatedBy, row.CreatedAt, row.LastHitAt)
+}
+
+func blackListJA3Entry(row *database.Ja3Blacklist) (string, Entry) {
+	return row.Hash, newEntry(row.ExpiresAt, row.Comment, row.Source, row.CreatedBy, row.CreatedAt, row.LastHitAt)
+}
+
+// CheckWhiteListJA3 godoc
+//
+//	@Summary		Check whitelisted ja3
+//	@Description	check if a ja3 hash is whitelisted
+//	@Tags			whitelist
+//	@Produce		json
+//	@Param			hash	path		string	true	"ja3 hash to check"
+//	@Success		200		{object}	CheckJA3Resp
+//	@Router			/v1/whitelist/ja3s/{hash} [get]
+func CheckWhiteListJA3(whitelist *types.JA3List, mu *sync.Mutex) func(*gin.Context) {
+	return ja3ListLookup(whitelist, mu)
+}
+
+// CheckBlackListJA3 godoc
+//
+//	@Summary		Check blacklisted ja3
+//	@Description	check if a ja3 hash is blacklisted
+//	@Tags			blacklist
+//	@Produce		json
+//	@Param			hash	path		string	true	"ja3 hash to check"
+//	@Success		200		{object}	CheckJA3Resp
+//	@Router			/v1/blacklist/ja3s/{hash} [get]
+func CheckBlackListJA3(blacklist *types.JA3List, mu *sync.Mutex) func(*gin.Context) {
+	return ja3ListLookup(blacklist, mu)
+}
+
+type CheckJA3Resp struct {
+	Found bool `json:"found"`
+}
+
+func ja3ListLookup(list *types.JA3List, mu *sync.Mutex) func(*gin.Context) {
+	return func(c *gin.Context) {
+		hash := c.Param("hash")
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		c.JSON(http.StatusOK, CheckJA3Resp{
+			Found: list.Lookup(hash),
+		})
+	}
+}
+
+// UpsertWhiteListJA3s godoc
+//
+//	@Summary		Upsert whitelisted ja3s
+//	@Description	upsert ja3 hashes to whitelist
+//	@Tags			whitelist
+//	@Accept			json
+//	@Param			body	body	UpsertJA3sReq	true	"ja3 hashes to add"
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
+//	@Failure		400
+//	@Failure		422	{object}	MutateResp
+//	@Failure		500
+//	@Router			/v1/whitelist/ja3s [post]
+func UpsertWhiteListJA3s(whitelist *types.JA3List, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return ja3ListUpsert(whitelist, mu, db, db.Q.CountWhiteListJA3, func(ctx context.Context, dbtx database.DBTX, hash string, meta entryMeta) error {
+		return db.Q.UpsertWhiteListJA3(ctx, dbtx, &database.UpsertWhiteListJA3Params{
+			Hash:          hash,
+			ExpiresAt:     meta.ExpiresAt,
+			Comment:       meta.Comment,
+			Source:        meta.Source,
+			DefaultSource: meta.DefaultSource,
+			CreatedBy:     meta.CreatedBy,
+			CreatedAt:     meta.CreatedAt,
+			LastHitAt:     meta.LastHitAt,
+		})
+	})
+}
+
+// UpsertBlackListJA3s godoc
+//
+//	@Summary		Upsert blacklisted ja3s
+//	@Description	upsert ja3 hashes to blacklist
+//	@Tags			blacklist
+//	@Accept			json
+//	@Param			body	body	UpsertJA3sReq	true	"ja3 hashes to add"
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
+//	@Failure		400
+//	@Failure		422	{object}	MutateResp
+//	@Failure		500
+//	@Router			/v1/blacklist/ja3s [post]
+func UpsertBlackListJA3s(blacklist *types.JA3List, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return ja3ListUpsert(blacklist, mu, db, db.Q.CountBlackListJA3, func(ctx context.Context, dbtx database.DBTX, hash string, meta entryMeta) error {
+		return db.Q.UpsertBlackListJA3(ctx, dbtx, &database.UpsertBlackListJA3Params{
+			Hash:          hash,
+			ExpiresAt:     meta.ExpiresAt,
+			Comment:       meta.Comment,
+			Source:        meta.Source,
+			DefaultSource: meta.DefaultSource,
+			CreatedBy:     meta.CreatedBy,
+			CreatedAt:     meta.CreatedAt,
+			LastHitAt:     meta.LastHitAt,
+		})
+	})
+}
+
+type UpsertJA3sReq struct {
+	JA3s []string `json:"ja3s" example:"e7d705a3286e19ea42f587b344ee6865,6734f37431670b3ab4292b8f60f29984"`
+	UpsertMeta
+}
+
+func ja3ListUpsert(
+	list *types.JA3List,
+	mu *sync.Mutex,
+	db *database.Database,
+	countFn func(ctx context.Context, db database.DBTX, hash string) (int64, error),
+	upsertFn func(ctx context.Context, db database.DBTX, hash string, meta entryMeta) error,
+) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var req UpsertJA3sReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		meta, err := req.meta(c)
+		if err != nil {
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+			return
+		}
+
+		hashes, invalid, ok := parseEntries(req.JA3s, parseJA3, identity[string])
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, MutateResp{Results: invalid})
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		// NOTE: the list is swapped only after the database commit
+		results, err := upsertEntries(c, db, hashes, meta, identity[string], identity[string], countFn, upsertFn)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		if err := list.Upsert(hashes); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, MutateResp{Results: results})
+	}
+}
+
+// RemoveWhiteListJA3s godoc
+//
+//	@Summary		Remove whitelisted ja3s
+//	@Description	remove ja3 hashes from whitelist
+//	@Tags			whitelist
+//	@Accept			json
+//	@Param			body	body	RemoveJA3sReq	true	"ja3 hashes to remove"
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
+//	@Failure		400
+//	@Failure		422	{object}	MutateResp
+//	@Failure		500
+//	@Router			/v1/whitelist/ja3s [delete]
+func RemoveWhiteListJA3s(whitelist *types.JA3List, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return ja3ListRemove(whitelist, mu, db, db.Q.RemoveWhiteListJA3)
+}
+
+// RemoveBlackListJA3s godoc
+//
+//	@Summary		Remove blacklisted ja3s
+//	@Description	remove ja3 hashes from blacklist
+//	@Tags			blacklist
+//	@Accept			json
+//	@Param			body	body	RemoveJA3sReq	true	"ja3 hashes to remove"
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
+//	@Failure		400
+//	@Failure		422	{object}	MutateResp
+//	@Failure		500
+//	@Router			/v1/blacklist/ja3s [delete]
+func RemoveBlackListJA3s(blacklist *types.JA3List, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return ja3ListRemove(blacklist, mu, db, db.Q.RemoveBlackListJA3)
+}
+
+type RemoveJA3sReq struct {
+	JA3s []string `json:"ja3s" example:"e7d705a3286e19ea42f587b344ee6865,6734f37431670b3ab4292b8f60f29984"`
+}
+
+func ja3ListRemove(
+	list *types.JA3List,
+	mu *sync.Mutex,
+	db *database.Database,
+	removeFn func(ctx context.Context, db database.DBTX, hash string) (int64, error),
+) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var req RemoveJA3sReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		hashes, invalid, ok := parseEntries(req.JA3s, parseJA3, identity[string])
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, MutateResp{Results: invalid})
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		// NOTE: the list is swapped only after the database commit
+		results, err := removeEntries(c, db, hashes, identity[string], identity[string], removeFn)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		if err := list.Remove(hashes); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, MutateResp{Results: results})
+	}
+}
+
+// GetTopJA3s godoc
+//
+//	@Summary		Get top ja3 hashes
+//	@Description	get most frequent ja3 hashes observed by the ja3 filters (approximate)
+//	@Tags			ja3
+//	@Produce		json
+//	@Param			limit	query		int	false	"max hashes"	default(20)
+//	@Success		200		{object}	GetTopJA3sResp
+//	@Failure		400
+//	@Router			/v1/ja3s/top [get]
+func GetTopJA3s(observed *types.TopCounter, whitelist *types.JA3List, blacklist *types.JA3List) func(*gin.Context) {
+	return func(c *gin.Context) {
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+		if err != nil || limit < 1 {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		counts := observed.Top(limit)
+		hashes := make([]TopJA3, len(counts))
+		for i, count := range counts {
+			hashes[i] = TopJA3{
+				Hash:        count.Key,
+				Count:       count.Count,
+				Whitelisted: whitelist.Lookup(count.Key),
+				Blacklisted: blacklist.Lookup(count.Key),
+			}
+		}
+
+		c.JSON(http.StatusOK, GetTopJA3sResp{JA3s: hashes})
+	}
+}
+
+type GetTopJA3sResp struct {
+	JA3s []TopJA3 `json:"ja3s"`
+}
+
+type TopJA3 struct {
+	Hash        string `json:"hash" example:"e7d705a3286e19ea42f587b344ee6865"`
+	Count       uint64 `json:"count" example:"1200"`
+	Whitelisted bool   `json:"whitelisted"`
+	Blacklisted bool   `json:"blacklisted"`
+}
+
+func parseJA3(hash string) (string, bool) {
+	hash = strings.ToLower(strings.TrimSpace(hash))
+	return hash, types.IsJA3(hash)
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cnaize/meds/src/core"
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/types"
+)
+
+// Lookup godoc
+//
+//	@Summary		Lookup packet
+//	@Description	explain the filters verdicts on a synthetic inbound tcp packet
+//	@Tags			lookup
+//	@Param			ip		query	string	true	"source ipv4 (ipv6 is not filtered)"	example(1.2.3.4)
+//	@Param			sni		query	string	false	"tls server name"		example(bad.com)
+//	@Param			ja3		query	string	false	"tls ja3 fingerprint"	example(e7d705a3286e19ea42f587b344ee6865)
+//	@Param			port	query	int		false	"destination port"		example(443)
+//	@Produce		json
+//	@Success		200	{object}	LookupResp
+//	@Failure		400
+//	@Failure		422	{object}	LookupErrorResp	"not an ipv4 address"
+//	@Router			/v1/lookup [get]
+func Lookup(explainFn func(packet *types.Packet) core.Explanation, asnlist *types.ASNList) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var query LookupQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		srcIP, err := netip.ParseAddr(query.IP)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		hash := strings.ToLower(query.JA3)
+		if hash != "" && !types.IsJA3(hash) {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		packet, err := types.NewLookupPacket(srcIP.Unmap(), query.Port, strings.ToLower(query.SNI), hash)
+		if errors.Is(err, types.ErrNotIPv4) {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, LookupErrorResp{Error: err.Error()})
+			return
+		}
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		explanation := explainFn(packet)
+
+		resp := LookupResp{
+			Action:   explanation.Action,
+			Name:     explanation.Name,
+			Type:     explanation.Type,
+			Verdicts: make([]Verdict, len(explanation.Verdicts)),
+		}
+		if asn, ok := packet.GetASN(asnlist); ok {
+			resp.ASN = asn.ASN
+			resp.ASNName = asnlist.Name(asn.ASN)
+			resp.Country = strings.ToLower(asn.Country)
+		}
+		for i, verdict := range explanation.Verdicts {
+			resp.Verdicts[i] = Verdict{
+				Name:     verdict.Name,
+				Type:     verdict.Type,
+				Enabled:  verdict.Enabled,
+				Matched:  verdict.Matched,
+				Match:    verdict.Match,
+				Decisive: verdict.Decisive,
+			}
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+type LookupQuery struct {
+	IP   string `form:"ip" binding:"required"`
+	SNI  string `form:"sni"`
+	JA3  string `form:"ja3"`
+	Port uint16 `form:"port"`
+}
+
+type LookupErrorResp struct {
+	Error string `json:"error" example:"only ipv4 packets are filtered: 2001:db8::1"`
+}
+
+type LookupResp struct {
+	Action string `json:"action" example:"drop" enums:"accept,drop"`
+	// Name and Type are the decisive filter, "default" if accepted by default
+	Name    string            `json:"name" example:"FireHOL"`
+	Type    filter.FilterType `json:"type" example:"ip"`
+	ASN     uint32            `json:"asn,omitempty" example:"13335"`
+	ASNName string            `json:"asn_name,omitempty" example:"Cloudflare, Inc."`
+	Country string            `json:"country,omitempty" example:"us"`
+	// Verdicts are in the pipeline order
+	Verdicts []Verdict `json:"verdicts"`
+}
+
+type Verdict struct {
+	Name    string            `json:"name" example:"FireHOL"`
+	Type    filter.FilterType `json:"type" example:"ip"`
+	Enabled bool              `json:"enabled"`
+	// Matched means the packet is in the filter list
+	Matched bool `json:"matched"`
+	// Match is the matched list entry
+	Match    string `json:"match,omitempty" example:"1.2.3.0/24"`
+	Decisive bool   `json:"decisive"`
+}
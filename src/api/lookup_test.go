@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cnaize/meds/src/core"
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/types"
+)
+
+func TestLookup(t *testing.T) {
+	explain := func(packet *types.Packet) core.Explanation {
+		srcIP, _ := packet.GetSrcIP()
+		if srcIP.String() != "1.2.3.4" {
+			return core.Explanation{Action: core.ActionAccept, Name: "default", Type: filter.FilterTypeEmpty}
+		}
+
+		return core.Explanation{
+			Action:   core.ActionDrop,
+			Name:     filter.FilterNameBlackList,
+			Type:     filter.FilterTypeIP,
+			Verdicts: []core.Verdict{{Name: filter.FilterNameBlackList, Type: filter.FilterTypeIP, Enabled: true, Matched: true, Match: "1.2.3.0/24", Decisive: true}},
+		}
+	}
+
+	r := gin.New()
+	r.GET("/lookup", Lookup(explain, types.NewASNList()))
+
+	tests := []struct {
+		name   string
+		url    string
+		status int
+		action string
+	}{
+		{name: "drop", url: "/lookup?ip=1.2.3.4&sni=bad.com", status: http.StatusOK, action: core.ActionDrop},
+		{name: "mapped", url: "/lookup?ip=::ffff:1.2.3.4", status: http.StatusOK, action: core.ActionDrop},
+		{name: "accept", url: "/lookup?ip=5.5.5.5&port=443", status: http.StatusOK, action: core.ActionAccept},
+		{name: "ipv6", url: "/lookup?ip=2001:db8::1", status: http.StatusUnprocessableEntity},
+		{name: "invalid ip", url: "/lookup?ip=bad", status: http.StatusBadRequest},
+		{name: "invalid ja3", url: "/lookup?ip=1.2.3.4&ja3=bad", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodGet, tt.url, "")
+			if w.Code != tt.status {
+				t.Fatalf("status %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+
+			switch tt.status {
+			case http.StatusOK:
+				var resp LookupResp
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Action != tt.action {
+					t.Errorf("got %s (%v), want %s", w.Body, err, tt.action)
+				}
+			case http.StatusUnprocessableEntity:
+				var resp LookupErrorResp
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Error == "" {
+					t.Errorf("got %s (%v), want the error message", w.Body, err)
+				}
+			}
+		})
+	}
+}
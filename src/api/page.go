@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cnaize/meds/src/database"
+)
+
+// list sort fields
+const (
+	SortValue     = "value"
+	SortCreatedAt = "created_at"
+)
+
+// list sort orders
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+const maxPageLimit = 10000
+
+// PageQuery is the list pagination query
+type PageQuery struct {
+	// Limit is the page size, all entries are returned if not set
+	Limit  int    `form:"limit"`
+	Cursor string `form:"cursor"`
+	Sort   string `form:"sort"`
+	Order  string `form:"order"`
+}
+
+func (q *PageQuery) valid() bool {
+	if q.Sort == "" {
+		q.Sort = SortValue
+	}
+	if q.Order == "" {
+		q.Order = OrderAsc
+	}
+
+	return q.Limit >= 0 && q.Limit <= maxPageLimit &&
+		(q.Sort == SortValue || q.Sort == SortCreatedAt) &&
+		(q.Order == OrderAsc || q.Order == OrderDesc)
+}
+
+// errInvalidCursor is the malformed page cursor error
+var errInvalidCursor = errors.New("invalid cursor")
+
+// listPage returns the page rows after the cursor with the next page cursor,
+// the rows are filtered by params and sorted by the database
+func listPage[R any](
+	ctx context.Context,
+	db *database.Database,
+	query PageQuery,
+	params database.ListPageParams,
+	pageFn func(ctx context.Context, db database.DBTX, arg *database.ListPageParams) ([]*R, error),
+	entryFn func(row *R) (string, Entry),
+) ([]*R, string, error) {
+	params.ByCreatedAt = query.Sort == SortCreatedAt
+	params.Desc = query.Order == OrderDesc
+	if query.Cursor != "" {
+		after, ok := decodeCursor(query.Cursor)
+		if !ok {
+			return nil, "", errInvalidCursor
+		}
+		params.After = &after
+	}
+	// NOTE: one more row tells if there is the next page
+	if query.Limit > 0 {
+		params.Limit = int64(query.Limit) + 1
+	}
+
+	rows, err := pageFn(ctx, db.DB, &params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if query.Limit < 1 || len(rows) <= query.Limit {
+		return rows, "", nil
+	}
+
+	rows = rows[:query.Limit]
+	value, entry := entryFn(rows[len(rows)-1])
+
+	return rows, encodeCursor(createdAt(entry), value), nil
+}
+
+// pageEntries returns the page values and their metadata
+func pageEntries[R any](rows []*R, entryFn func(row *R) (string, Entry)) ([]string, map[string]Entry) {
+	if len(rows) < 1 {
+		return []string{}, nil
+	}
+
+	values := make([]string, len(rows))
+	entries := make(map[string]Entry, len(rows))
+	for i, row := range rows {
+		value, entry := entryFn(row)
+		values[i] = value
+		entries[value] = entry
+	}
+
+	return values, entries
+}
+
+// pageStatus returns the list page error status
+func pageStatus(err error) int {
+	if errors.Is(err, errInvalidCursor) {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
+
+// NOTE: the cursor is the last returned entry "created_at|value"
+func encodeCursor(createdAt int64, value string) string {
+	cursor := strconv.FormatInt(createdAt, 10) + "|" + value
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor))
+}
+
+func decodeCursor(cursor string) (database.ListCursor, bool) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return database.ListCursor{}, false
+	}
+
+	created, value, ok := strings.Cut(string(data), "|")
+	if !ok {
+		return database.ListCursor{}, false
+	}
+
+	unix, err := strconv.ParseInt(created, 10, 64)
+	if err != nil {
+		return database.ListCursor{}, false
+	}
+
+	return database.ListCursor{CreatedAt: unix, Value: value}, true
+}
+
+func createdAt(entry Entry) int64 {
+	if entry.CreatedAt == nil {
+		return 0
+	}
+
+	return entry.CreatedAt.Unix()
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cnaize/meds/src/types"
+)
+
+func TestListPage(t *testing.T) {
+	db := newTestDatabase(t)
+	list := types.NewDomainList()
+
+	var mu sync.Mutex
+	r := gin.New()
+	r.GET("/domains", GetBlackListDomains(db))
+	r.POST("/domains", UpsertBlackListDomains(list, &mu, db))
+
+	if w := serve(r, http.MethodPost, "/domains", `{"domains": ["e.com", "a.com", "d.org", "b.com", "c.net"]}`); w.Code != http.StatusAccepted {
+		t.Fatalf("upsert status %d", w.Code)
+	}
+
+	// walk walks the pages by the next cursors
+	walk := func(query url.Values) []string {
+		t.Helper()
+
+		var got []string
+		for range 10 {
+			w := serve(r, http.MethodGet, "/domains?"+query.Encode(), "")
+			if w.Code != http.StatusOK {
+				t.Fatalf("get %s status %d", query.Encode(), w.Code)
+			}
+
+			var resp GetDomainsResp
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("unmarshal: %s", err)
+			}
+			if len(resp.Entries) != len(resp.Domains) {
+				t.Errorf("entries %v of %v", resp.Entries, resp.Domains)
+			}
+			got = append(got, resp.Domains...)
+
+			if resp.NextCursor == "" {
+				return got
+			}
+			query.Set("cursor", resp.NextCursor)
+		}
+
+		t.Fatalf("too many pages: %v", got)
+		return nil
+	}
+
+	tests := []struct {
+		name  string
+		query url.Values
+		want  []string
+	}{
+		{name: "all", query: url.Values{}, want: []string{"a.com", "b.com", "c.net", "d.org", "e.com"}},
+		{name: "pages", query: url.Values{"limit": {"2"}}, want: []string{"a.com", "b.com", "c.net", "d.org", "e.com"}},
+		{name: "exact pages", query: url.Values{"limit": {"5"}}, want: []string{"a.com", "b.com", "c.net", "d.org", "e.com"}},
+		{name: "desc pages", query: url.Values{"limit": {"2"}, "order": {"desc"}}, want: []string{"e.com", "d.org", "c.net", "b.com", "a.com"}},
+		// NOTE: the created_at ties are broken by the value
+		{name: "created pages", query: url.Values{"limit": {"2"}, "sort": {"created_at"}}, want: []string{"a.com", "b.com", "c.net", "d.org", "e.com"}},
+		{name: "search pages", query: url.Values{"limit": {"1"}, "search": {" .COM "}}, want: []string{"a.com", "b.com", "e.com"}},
+		{name: "prefix", query: url.Values{"prefix": {"d"}}, want: []string{"d.org"}},
+		{name: "nothing", query: url.Values{"search": {"missing"}}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := walk(tt.query); !slices.Equal(got, tt.want) {
+				t.Errorf("walked %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, query := range []string{"limit=-1", "limit=10001", "sort=comment", "order=up", "cursor=invalid", "cursor=" + encodeCursor(0, "a.com")[1:]} {
+		if w := serve(r, http.MethodGet, "/domains?"+query, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("get %s status %d, want 400", query, w.Code)
+		}
+	}
+}
+
+func TestCursor(t *testing.T) {
+	for _, value := range []string{"a.com", "1.2.3.0/24", "with|pipe", ""} {
+		cursor, ok := decodeCursor(encodeCursor(1_760_000_000, value))
+		if !ok || cursor.CreatedAt != 1_760_000_000 || cursor.Value != value {
+			t.Errorf("decoded %+v (%t), want %q", cursor, ok, value)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/cnaize/meds/lib/util/get"
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/core/metrics"
+	"github.com/cnaize/meds/src/database"
+	"github.com/cnaize/meds/src/types"
+)
+
+const reapInterval = 5 * time.Second
+
+// Reaper removes expired white/black list entries and saves the entries hits
+type Reaper struct {
+	lists  []reapedList
+	logger *logger.Logger
+}
+
+type reapedList struct {
+	name string
+	reap func(ctx context.Context, now int64) (int, error)
+	save func(ctx context.Context) error
+}
+
+func NewReaper(
+	db *database.Database,
+	logger *logger.Logger,
+	subnetWhiteList *types.SubnetList,
+	subnetBlackList *types.SubnetList,
+	domainWhiteList *types.DomainList,
+	domainBlackList *types.DomainList,
+	countryWhiteList *types.CountryList,
+	countryBlackList *types.CountryList,
+	asnWhiteList *types.ASNumberList,
+	asnBlackList *types.ASNumberList,
+	ja3WhiteList *types.JA3List,
+	ja3BlackList *types.JA3List,
+) *Reaper {
+	return &Reaper{
+		lists: []reapedList{
+			newReapedList("subnet whitelist", &subnetWhiteListMu, db, subnetWhiteList.Remove, db.Q.GetExpiredWhiteListSubnets, db.Q.RemoveExpiredWhiteListSubnets, get.Subnets,
+				func(ctx context.Context, dbtx database.DBTX) error {
+					return saveHits(subnetWhiteList.FlushHits(), func(subnet netip.Prefix, at int64) error {
+						return db.Q.TouchWhiteListSubnet(ctx, dbtx, &database.TouchWhiteListSubnetParams{LastHitAt: at, Subnet: subnet.String()})
+					})
+				},
+			),
+			newReapedList("subnet blacklist", &subnetBlackListMu, db, subnetBlackList.Remove, db.Q.GetExpiredBlackListSubnets, db.Q.RemoveExpiredBlackListSubnets, get.Subnets,
+				func(ctx context.Context, dbtx database.DBTX) error {
+					return saveHits(subnetBlackList.FlushHits(), func(subnet netip.Prefix, at int64) error {
+						return db.Q.TouchBlackListSubnet(ctx, dbtx, &database.TouchBlackListSubnetParams{LastHitAt: at, Subnet: subnet.String()})
+					})
+				},
+			),
+			newReapedList("domain whitelist", &domainWhiteListMu, db, domainWhiteList.Remove, db.Q.GetExpiredWhiteListDomains, db.Q.RemoveExpiredWhiteListDomains, asIs[string],
+				func(ctx context.Context, dbtx database.DBTX) error {
+					return saveHits(domainWhiteList.FlushHits(), func(domain string, at int64) error {
+						return db.Q.TouchWhiteListDomain(ctx, dbtx, &database.TouchWhiteListDomainParams{LastHitAt: at, Domain: domain})
+					})
+				},
+			),
+			newReapedList("domain blacklist", &domainBlackListMu, db, domainBlackList.Remove, db.Q.GetExpiredBlackListDomains, db.Q.RemoveExpiredBlackListDomains, asIs[string],
+				func(ctx context.Context, dbtx database.DBTX) error {
+					return saveHits(domainBlackList.FlushHits(), func(domain string, at int64) error {
+						return db.Q.TouchBlackListDomain(ctx, dbtx, &database.TouchBlackListDomainParams{LastHitAt: at, Domain: domain})
+					})
+				},
+			),
+			newReapedList("country whitelist", &countryWhiteListMu, db, countryWhiteList.Remove, db.Q.GetExpiredWhiteListCountries, db.Q.RemoveExpiredWhiteListCountries, asIs[string],
+				func(ctx context.Context, dbtx database.DBTX) error {
+					return saveHits(countryWhiteList.FlushHits(), func(country string, at int64) error {
+						return db.Q.TouchWhiteListCountry(ctx, dbtx, &database.TouchWhiteListCountryParams{LastHitAt: at, Country: country})
+					})
+				},
+			),
+			newReapedList("country blacklist", &countryBlackListMu, db, countryBlackList.Remove, db.Q.GetExpiredBlackListCountries, db.Q.RemoveExpiredBlackListCountries, asIs[string],
+				func(ctx context.Context, dbtx database.DBTX) error {
+					return saveHits(countryBlackList.FlushHits(), func(country string, at int64) error {
+						return db.Q.TouchBlackListCountry(ctx, dbtx, &database.TouchBlackListCountryParams{LastHitAt: at, Country: country})
+					})
+				},
+			),
+			newReapedList("asn whitelist", &asnWhiteListMu, db, asnWhiteList.Remove, db.Q.GetExpiredWhiteListASNs, db.Q.RemoveExpiredWhiteListASNs, asNumbers,
+				func(ctx context.Context, dbtx database.DBTX) error {
+					return saveHits(asnWhiteList.FlushHits(), func(asn uint32, at int64) error {
+						return db.Q.TouchWhiteListASN(ctx, dbtx, &database.TouchWhiteListASNParams{LastHitAt: at, Asn: int64(asn)})
+					})
+				},
+			),
+			newReapedList("asn blacklist", &asnBlackListMu, db, asnBlackList.Remove, db.Q.GetExpiredBlackListASNs, db.Q.RemoveExpiredBlackListASNs, asNumbers,
+				func(ctx context.Context, dbtx database.DBTX) error {
+					return saveHits(asnBlackList.FlushHits(), func(asn uint32, at int64) error {
+						return db.Q.TouchBlackListASN(ctx, dbtx, &database.TouchBlackListASNParams{LastHitAt: at, Asn: int64(asn)})
+					})
+				},
+			),
+			newReapedList("ja3 whitelist", &ja3WhiteListMu, db, ja3WhiteList.Remove, db.Q.GetExpiredWhiteListJA3s, db.Q.RemoveExpiredWhiteListJA3s, asIs[string],
+				func(ctx context.Context, dbtx database.DBTX) error {
+					return saveHits(ja3WhiteList.FlushHits(), func(hash string, at int64) error {
+						return db.Q.TouchWhiteListJA3(ctx, dbtx, &database.TouchWhiteListJA3Params{LastHitAt: at, Hash: hash})
+					})
+				},
+			),
+			newReapedList("ja3 blacklist", &ja3BlackListMu, db, ja3BlackList.Remove, db.Q.GetExpiredBlackListJA3s, db.Q.RemoveExpiredBlackListJA3s, asIs[string],
+				func(ctx context.Context, dbtx database.DBTX) error {
+					return saveHits(ja3BlackList.FlushHits(), func(hash string, at int64) error {
+						return db.Q.TouchBlackListJA3(ctx, dbtx, &database.TouchBlackListJA3Params{LastHitAt: at, Hash: hash})
+					})
+				},
+			),
+		},
+		logger: logger,
+	}
+}
+
+// Run removes expired entries till the context is done
+func (r *Reaper) Run(ctx context.Context) {
+	ticker := time.NewTicker(reapInterval)
+	defer ticker.Stop()
+
+	for {
+		// NOTE: entries may expire while not running, so reap at once
+		r.reap(ctx, time.Now().Unix())
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Reaper) reap(ctx context.Context, now int64) {
+	for _, list := range r.lists {
+		count, err := list.reap(ctx, now)
+		if err != nil {
+			msg := "list reap failed"
+
+			metrics.Get().ErrorsTotal.WithLabelValues(msg).Inc()
+			r.logger.Raw().
+				Error().
+				Err(err).
+				Str("list", list.name).
+				Msg(msg)
+			continue
+		}
+
+		// NOTE: hits are not critical, so save them after the expired entries removal
+		if err := list.save(ctx); err != nil {
+			msg := "list hits save failed"
+
+			metrics.Get().ErrorsTotal.WithLabelValues(msg).Inc()
+			r.logger.Raw().
+				Error().
+				Err(err).
+				Str("list", list.name).
+				Msg(msg)
+		}
+
+		if count > 0 {
+			r.logger.Raw().
+				Info().
+				Str("list", list.name).
+				Int("count", count).
+				Msg("expired entries removed")
+		}
+	}
+}
+
+func newReapedList[R, T any](
+	name string,
+	mu *sync.Mutex,
+	db *database.Database,
+	removeList func(items []T) error,
+	getFn func(ctx context.Context, db database.DBTX, now int64) ([]R, error),
+	removeFn func(ctx context.Context, db database.DBTX, now int64) error,
+	parseFn func(rows []R) ([]T, error),
+	saveFn func(ctx context.Context, db database.DBTX) error,
+) reapedList {
+	return reapedList{
+		name: name,
+		save: func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return saveFn(ctx, db.DB)
+		},
+		reap: func(ctx context.Context, now int64) (int, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			rows, err := getFn(ctx, db.DB, now)
+			if err != nil {
+				return 0, fmt.Errorf("get expired: %w", err)
+			}
+			if len(rows) < 1 {
+				return 0, nil
+			}
+
+			items, err := parseFn(rows)
+			if err != nil {
+				return 0, fmt.Errorf("parse: %w", err)
+			}
+
+			// NOTE: the same "now" removes exactly the fetched rows
+			if err := removeFn(ctx, db.DB, now); err != nil {
+				return 0, fmt.Errorf("remove expired: %w", err)
+			}
+
+			if err := removeList(items); err != nil {
+				return 0, fmt.Errorf("list remove: %w", err)
+			}
+
+			return len(rows), nil
+		},
+	}
+}
+
+func asIs[T any](rows []T) ([]T, error) {
+	return rows, nil
+}
+
+func asNumbers(rows []int64) ([]uint32, error) {
+	asns := make([]uint32, len(rows))
+	for i, asn := range rows {
+		asns[i] = uint32(asn)
+	}
+
+	return asns, nil
+}
+
+// saveHits saves the entries last hit time
+func saveHits[K comparable](hits map[K]int64, touchFn func(key K, at int64) error) error {
+	for key, at := range hits {
+		if err := touchFn(key, at); err != nil {
+			return fmt.Errorf("touch %v: %w", key, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"net/netip"
+	"slices"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/database"
+	"github.com/cnaize/meds/src/types"
+)
+
+func TestReaper(t *testing.T) {
+	ctx := t.Context()
+	db := newTestDatabase(t)
+	subnetBlackList := types.NewSubnetList()
+	domainWhiteList := types.NewDomainList()
+
+	nop := zerolog.Nop()
+	reaper := NewReaper(db, logger.NewLogger(&nop, 1),
+		types.NewSubnetList(), subnetBlackList,
+		domainWhiteList, types.NewDomainList(),
+		types.NewCountryList(), types.NewCountryList(),
+		types.NewASNumberList(), types.NewASNumberList(),
+		types.NewJA3List(), types.NewJA3List(),
+	)
+
+	const now = int64(1_800_000_000)
+	subnets := map[string]int64{"1.1.1.1/32": now - 1, "2.2.2.0/24": now, "3.3.3.3/32": now + 1, "4.4.4.4/32": 0}
+	for subnet, expiresAt := range subnets {
+		if err := db.Q.UpsertBlackListSubnet(ctx, db.DB, &database.UpsertBlackListSubnetParams{Subnet: subnet, ExpiresAt: expiresAt, DefaultSource: types.SourceAPI}); err != nil {
+			t.Fatalf("upsert %s: %s", subnet, err)
+		}
+		if err := subnetBlackList.Upsert([]netip.Prefix{netip.MustParsePrefix(subnet)}); err != nil {
+			t.Fatalf("list upsert %s: %s", subnet, err)
+		}
+	}
+	if err := db.Q.UpsertWhiteListDomain(ctx, db.DB, &database.UpsertWhiteListDomainParams{Domain: "example.com", ExpiresAt: now - 60, DefaultSource: types.SourceAPI}); err != nil {
+		t.Fatalf("upsert domain: %s", err)
+	}
+	if err := domainWhiteList.Upsert([]string{"example.com"}); err != nil {
+		t.Fatalf("list upsert domain: %s", err)
+	}
+
+	// NOTE: the hits are saved along with the reaping
+	if !subnetBlackList.Hit(netip.MustParseAddr("3.3.3.3")) {
+		t.Fatalf("no hit")
+	}
+
+	reaper.reap(ctx, now)
+
+	want := []string{"3.3.3.3/32", "4.4.4.4/32"}
+	entries, err := db.Q.GetBlackListSubnetEntries(ctx, db.DB)
+	if err != nil {
+		t.Fatalf("get entries: %s", err)
+	}
+	var stored []string
+	for _, entry := range entries {
+		stored = append(stored, entry.Subnet)
+		if entry.Subnet == "3.3.3.3/32" && entry.LastHitAt < 1 {
+			t.Errorf("hit of %s not saved", entry.Subnet)
+		}
+	}
+	slices.Sort(stored)
+	if !slices.Equal(stored, want) {
+		t.Errorf("stored %v, want %v", stored, want)
+	}
+
+	var listed []string
+	for _, subnet := range subnetBlackList.GetAll() {
+		listed = append(listed, subnet.String())
+	}
+	if !slices.Equal(listed, want) {
+		t.Errorf("listed %v, want %v", listed, want)
+	}
+
+	if domains, err := db.Q.GetAllWhiteListDomains(ctx, db.DB); err != nil || len(domains) != 0 {
+		t.Errorf("stored domains %v: %v, want none", domains, err)
+	}
+	if domains := domainWhiteList.GetAll(); len(domains) != 0 {
+		t.Errorf("listed domains %v, want none", domains)
+	}
+
+	// nothing left to reap
+	reaper.reap(ctx, now)
+	if got := subnetBlackList.GetAll(); len(got) != 2 {
+		t.Errorf("listed %v after the second reap, want 2", got)
+	}
+}
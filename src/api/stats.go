@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/cnaize/meds/src/core/stats"
+)
+
+const maxTopLimit = 1000
+
+// GetTopStats godoc
+//
+//	@Summary		Get top stats
+//	@Description	get most frequent dropped packets source ips, subnets, asns, countries, domains, ja3 hashes and destination ports (approximate)
+//	@Tags			stats
+//	@Param			window	query	string	false	"time window"				Enums(hour, day)								default(hour)
+//	@Param			kind	query	string	false	"counted kind (all if empty)"	Enums(ip, subnet, asn, country, domain, ja3, port)
+//	@Param			limit	query	int		false	"max keys (per kind)"		default(20)
+//	@Produce		json
+//	@Success		200	{object}	GetTopStatsResp
+//	@Failure		400
+//	@Router			/v1/stats/top [get]
+func GetTopStats(top *stats.Top) func(*gin.Context) {
+	return func(c *gin.Context) {
+		query := GetTopStatsQuery{Window: stats.WindowHour, Limit: 20}
+		if err := c.ShouldBindQuery(&query); err != nil || query.Limit < 1 || query.Limit > maxTopLimit {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		kinds := stats.Kinds
+		if query.Kind != "" {
+			kinds = []stats.Kind{query.Kind}
+		}
+
+		resp := GetTopStatsResp{
+			Window: query.Window,
+			Top:    make(map[stats.Kind][]TopCount, len(kinds)),
+		}
+		for _, kind := range kinds {
+			counts, err := top.Get(query.Window, kind, query.Limit)
+			if err != nil {
+				c.AbortWithStatus(http.StatusBadRequest)
+				return
+			}
+
+			resp.Top[kind] = make([]TopCount, len(counts))
+			for i, count := range counts {
+				resp.Top[kind][i] = TopCount{Key: count.Key, Count: count.Count}
+			}
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+type GetTopStatsQuery struct {
+	Window stats.Window `form:"window"`
+	Kind   stats.Kind   `form:"kind"`
+	Limit  int          `form:"limit"`
+}
+
+type GetTopStatsResp struct {
+	Window stats.Window `json:"window" example:"hour"`
+	// Top are the counts by kind (most frequent first)
+	Top map[stats.Kind][]TopCount `json:"top"`
+}
+
+type TopCount struct {
+	Key   string `json:"key" example:"1.2.3.4"`
+	Count uint64 `json:"count" example:"1200"`
+}
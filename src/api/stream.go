@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/logger/event"
+	"github.com/cnaize/meds/src/core/stream"
+)
+
+// how often the idle stream is pinged
+const streamPingInterval = 15 * time.Second
+
+// StreamEvents godoc
+//
+//	@Summary		Stream events
+//	@Description	stream live packet verdict events over sse (or websocket if upgrade requested), events are dropped if the client is slow
+//	@Tags			events
+//	@Param			action	query	[]string	false	"packet actions"	collectionFormat(multi)	Enums(accept, drop, trust)
+//	@Param			filter	query	[]string	false	"filter types"		collectionFormat(multi)	example(ip)
+//	@Param			ip		query	string		false	"source ip or subnet"						example(1.2.3.0/24)
+//	@Produce		text/event-stream
+//	@Success		200	{object}	StreamEvent
+//	@Failure		400
+//	@Router			/v1/events/stream [get]
+func StreamEvents(ctx context.Context, hub *stream.Hub) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var query StreamEventsQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		var f stream.Filter
+		for _, action := range query.Action {
+			f.Actions = append(f.Actions, event.ActionType(action))
+		}
+		for _, typ := range query.Filter {
+			f.Filters = append(f.Filters, filter.FilterType(typ))
+		}
+		if query.IP != "" {
+			var ok bool
+			if f.Prefix, ok = parseSubnet(query.IP); !ok {
+				c.AbortWithStatus(http.StatusBadRequest)
+				return
+			}
+		}
+
+		// NOTE: the streams never end by themselves, so they are closed with the server
+		streamCtx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+		stop := context.AfterFunc(ctx, cancel)
+		defer stop()
+
+		sub := hub.Subscribe(f)
+		defer hub.Unsubscribe(sub)
+
+		if c.IsWebsocket() {
+			streamWebsocket(streamCtx, c, sub)
+			return
+		}
+
+		streamSSE(streamCtx, c, sub)
+	}
+}
+
+type StreamEventsQuery struct {
+	Action []string `form:"action"`
+	Filter []string `form:"filter"`
+	IP     string   `form:"ip"`
+}
+
+type StreamEvent struct {
+	Time   time.Time         `json:"time"`
+	Action string            `json:"action" example:"drop" enums:"accept,drop,trust"`
+	Reason string            `json:"reason" example:"FireHOL"`
+	Filter filter.FilterType `json:"filter" example:"ip"`
+	// Target is the packet part checked by the filter
+	Target  string `json:"target" example:"1.2.3.4"`
+	IP      string `json:"ip,omitempty" example:"1.2.3.4"`
+	Port    uint16 `json:"port,omitempty" example:"443"`
+	Country string `json:"country,omitempty" example:"us"`
+	ASN     uint32 `json:"asn,omitempty" example:"13335"`
+	// Dropped is the events count dropped since the previous message (slow client)
+	Dropped uint64 `json:"dropped,omitempty" example:"0"`
+}
+
+func newStreamEvent(record event.Record, dropped uint64) StreamEvent {
+	e := StreamEvent{
+		Time:    record.Time.UTC(),
+		Action:  string(record.Action),
+		Reason:  record.Reason,
+		Filter:  record.Filter,
+		Target:  record.Target,
+		Port:    record.DstPort,
+		Country: record.Country,
+		ASN:     record.ASN,
+		Dropped: dropped,
+	}
+	if record.SrcIP.IsValid() {
+		e.IP = record.SrcIP.String()
+	}
+
+	return e
+}
+
+func streamSSE(ctx context.Context, c *gin.Context, sub *stream.Subscription) {
+	ticker := time.NewTicker(streamPingInterval)
+	defer ticker.Stop()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case record := <-sub.Records():
+			c.SSEvent("event", newStreamEvent(record, sub.Dropped()))
+			return true
+		case <-ticker.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		case <-ctx.Done():
+			return false
+		}
+	})
+}
+
+func streamWebsocket(ctx context.Context, c *gin.Context, sub *stream.Subscription) {
+	server := websocket.Server{
+		Handshake: checkOrigin,
+		Handler: func(ws *websocket.Conn) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			// NOTE: the client messages are ignored, read only to find out the connection close
+			go func() {
+				defer cancel()
+
+				var msg []byte
+				for websocket.Message.Receive(ws, &msg) == nil {
+				}
+			}()
+
+			ticker := time.NewTicker(streamPingInterval)
+			defer ticker.Stop()
+
+			// NOTE: raw writes are used for pings only
+			ws.PayloadType = websocket.PingFrame
+			for {
+				var err error
+				select {
+				case record := <-sub.Records():
+					err = websocket.JSON.Send(ws, newStreamEvent(record, sub.Dropped()))
+				case <-ticker.C:
+					_, err = ws.Write(nil)
+				case <-ctx.Done():
+					return
+				}
+				if err != nil {
+					return
+				}
+			}
+		},
+	}
+
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// checkOrigin rejects the cross-origin browser connections
+func checkOrigin(config *websocket.Config, req *http.Request) error {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return fmt.Errorf("parse origin: %w", err)
+	}
+	if u.Host != req.Host {
+		return fmt.Errorf("cross origin: %s", origin)
+	}
+	config.Origin = u
+
+	return nil
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"net/http"
+	"net/netip"
 	"sync"
 
 	"github.com/gin-gonic/gin"
@@ -15,46 +16,96 @@ import (
 // GetWhiteListSubnets godoc
 //
 //	@Summary		Get whitelisted subnets
-//	@Description	get all whitelisted subnets
+//	@Description	get whitelisted subnets, paginated if limit is set
 //	@Tags			whitelist
+//	@Param			ip		query	string	false	"entries covering the ip or subnet"
+//	@Param			limit	query	int		false	"page size"
+//	@Param			cursor	query	string	false	"next page cursor"
+//	@Param			sort	query	string	false	"sort field"	Enums(value, created_at)	default(value)
+//	@Param			order	query	string	false	"sort order"	Enums(asc, desc)			default(asc)
 //	@Produce		json
 //	@Success		200	{object}	GetSubnetsResp
+//	@Failure		400
+//	@Failure		500
 //	@Router			/v1/whitelist/subnets [get]
-func GetWhiteListSubnets(whitelist *types.SubnetList, mu *sync.Mutex) func(*gin.Context) {
-	return subnetListGetAll(whitelist, mu)
+func GetWhiteListSubnets(db *database.Database) func(*gin.Context) {
+	return subnetListGetAll(db, db.Q.GetWhiteListSubnetPage, whiteListSubnetEntry)
 }
 
 // GetBlackListSubnets godoc
 //
 //	@Summary		Get blacklisted subnets
-//	@Description	get all blacklisted subnets
+//	@Description	get blacklisted subnets, paginated if limit is set
 //	@Tags			blacklist
+//	@Param			ip		query	string	false	"entries covering the ip or subnet"
+//	@Param			limit	query	int		false	"page size"
+//	@Param			cursor	query	string	false	"next page cursor"
+//	@Param			sort	query	string	false	"sort field"	Enums(value, created_at)	default(value)
+//	@Param			order	query	string	false	"sort order"	Enums(asc, desc)			default(asc)
 //	@Produce		json
 //	@Success		200	{object}	GetSubnetsResp
+//	@Failure		400
+//	@Failure		500
 //	@Router			/v1/blacklist/subnets [get]
-func GetBlackListSubnets(blacklist *types.SubnetList, mu *sync.Mutex) func(*gin.Context) {
-	return subnetListGetAll(blacklist, mu)
+func GetBlackListSubnets(db *database.Database) func(*gin.Context) {
+	return subnetListGetAll(db, db.Q.GetBlackListSubnetPage, blackListSubnetEntry)
+}
+
+type GetSubnetsQuery struct {
+	// IP filters the entries covering the ip or subnet
+	IP string `form:"ip"`
+	PageQuery
 }
 
 type GetSubnetsResp struct {
 	Subnets []string `json:"subnets" example:"100.100.100.100/32,200.200.200.0/24"`
+	// Entries are the entries metadata by their values
+	Entries    map[string]Entry `json:"entries,omitempty"`
+	NextCursor string           `json:"next_cursor,omitempty"`
 }
 
-func subnetListGetAll(list *types.SubnetList, mu *sync.Mutex) func(*gin.Context) {
+func subnetListGetAll[R any](
+	db *database.Database,
+	pageFn func(ctx context.Context, db database.DBTX, arg *database.ListPageParams) ([]*R, error),
+	entryFn func(row *R) (string, Entry),
+) func(*gin.Context) {
 	return func(c *gin.Context) {
-		mu.Lock()
-		defer mu.Unlock()
+		var query GetSubnetsQuery
+		if err := c.ShouldBindQuery(&query); err != nil || !query.valid() {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
 
-		all := list.GetAll()
-		subnets := make([]string, len(all))
-		for i, subnet := range all {
-			subnets[i] = subnet.String()
+		var params database.ListPageParams
+		if query.IP != "" {
+			covered, ok := parseSubnet(query.IP)
+			if !ok {
+				c.AbortWithStatus(http.StatusBadRequest)
+				return
+			}
+			params.Values = coveringSubnets(covered)
 		}
 
-		c.JSON(http.StatusOK, GetSubnetsResp{Subnets: subnets})
+		// NOTE: the database is updated before the list, no need to lock it
+		rows, next, err := listPage(c, db, query.PageQuery, params, pageFn, entryFn)
+		if err != nil {
+			c.AbortWithStatus(pageStatus(err))
+			return
+		}
+
+		subnets, entries := pageEntries(rows, entryFn)
+		c.JSON(http.StatusOK, GetSubnetsResp{Subnets: subnets, Entries: entries, NextCursor: next})
 	}
 }
 
+func whiteListSubnetEntry(row *database.SubnetWhitelist) (string, Entry) {
+	return row.Subnet, newEntry(row.ExpiresAt, row.Comment, row.Source, row.CreatedBy, row.CreatedAt, row.LastHitAt)
+}
+
+func blackListSubnetEntry(row *database.SubnetBlacklist) (string, Entry) {
+	return row.Subnet, newEntry(row.ExpiresAt, row.Comment, row.Source, row.CreatedBy, row.CreatedAt, row.LastHitAt)
+}
+
 // CheckWhiteListSubnet godoc
 //
 //	@Summary		Check whitelisted subnet
@@ -112,13 +163,14 @@ func subnetListLookup(list *types.SubnetList, mu *sync.Mutex) func(*gin.Context)
 //	@Tags			whitelist
 //	@Accept			json
 //	@Param			body	body	UpsertSubnetsReq	true	"subnets to add"
-//	@Success		202
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
 //	@Failure		400
-//	@Failure		422
+//	@Failure		422	{object}	MutateResp
 //	@Failure		500
 //	@Router			/v1/whitelist/subnets [post]
 func UpsertWhiteListSubnets(whitelist *types.SubnetList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
-	return subnetListUpsert(whitelist, mu, db, db.Q.UpsertWhiteListSubnet)
+	return subnetListUpsert(whitelist, mu, db, db.Q.CountWhiteListSubnet, upsertWhiteListSubnet(db))
 }
 
 // UpsertBlackListSubnets godoc
@@ -128,24 +180,27 @@ func UpsertWhiteListSubnets(whitelist *types.SubnetList, mu *sync.Mutex, db *dat
 //	@Tags			blacklist
 //	@Accept			json
 //	@Param			body	body	UpsertSubnetsReq	true	"subnets to add"
-//	@Success		202
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
 //	@Failure		400
-//	@Failure		422
+//	@Failure		422	{object}	MutateResp
 //	@Failure		500
 //	@Router			/v1/blacklist/subnets [post]
 func UpsertBlackListSubnets(blacklist *types.SubnetList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
-	return subnetListUpsert(blacklist, mu, db, db.Q.UpsertBlackListSubnet)
+	return subnetListUpsert(blacklist, mu, db, db.Q.CountBlackListSubnet, upsertBlackListSubnet(db))
 }
 
 type UpsertSubnetsReq struct {
 	Subnets []string `json:"subnets" example:"100.100.100.100,200.200.200.0/24"`
+	UpsertMeta
 }
 
 func subnetListUpsert(
 	list *types.SubnetList,
 	mu *sync.Mutex,
 	db *database.Database,
-	upsertFn func(ctx context.Context, db database.DBTX, subnet string) error,
+	countFn func(ctx context.Context, db database.DBTX, subnet string) (int64, error),
+	upsertFn func(ctx context.Context, db database.DBTX, subnet string, meta entryMeta) error,
 ) func(*gin.Context) {
 	return func(c *gin.Context) {
 		var req UpsertSubnetsReq
@@ -154,28 +209,64 @@ func subnetListUpsert(
 			return
 		}
 
-		subnets, err := get.Subnets(req.Subnets)
+		meta, err := req.meta(c)
 		if err != nil {
-			c.AbortWithStatus(http.StatusBadRequest)
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+			return
+		}
+
+		subnets, invalid, ok := parseEntries(req.Subnets, parseSubnet, identity[string])
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, MutateResp{Results: invalid})
 			return
 		}
 
 		mu.Lock()
 		defer mu.Unlock()
 
-		if err := list.Upsert(subnets); err != nil {
-			c.AbortWithStatus(http.StatusUnprocessableEntity)
+		// NOTE: the list is swapped only after the database commit
+		results, err := upsertEntries(c, db, subnets, meta, netip.Prefix.String, netip.Prefix.String, countFn, upsertFn)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
 			return
 		}
 
-		for _, subnet := range subnets {
-			if err := upsertFn(c, db.DB, subnet.String()); err != nil {
-				c.AbortWithStatus(http.StatusInternalServerError)
-				return
-			}
+		if err := list.Upsert(subnets); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
 		}
 
-		c.Status(http.StatusAccepted)
+		c.JSON(http.StatusAccepted, MutateResp{Results: results})
+	}
+}
+
+func upsertWhiteListSubnet(db *database.Database) func(ctx context.Context, dbtx database.DBTX, subnet string, meta entryMeta) error {
+	return func(ctx context.Context, dbtx database.DBTX, subnet string, meta entryMeta) error {
+		return db.Q.UpsertWhiteListSubnet(ctx, dbtx, &database.UpsertWhiteListSubnetParams{
+			Subnet:        subnet,
+			ExpiresAt:     meta.ExpiresAt,
+			Comment:       meta.Comment,
+			Source:        meta.Source,
+			DefaultSource: meta.DefaultSource,
+			CreatedBy:     meta.CreatedBy,
+			CreatedAt:     meta.CreatedAt,
+			LastHitAt:     meta.LastHitAt,
+		})
+	}
+}
+
+func upsertBlackListSubnet(db *database.Database) func(ctx context.Context, dbtx database.DBTX, subnet string, meta entryMeta) error {
+	return func(ctx context.Context, dbtx database.DBTX, subnet string, meta entryMeta) error {
+		return db.Q.UpsertBlackListSubnet(ctx, dbtx, &database.UpsertBlackListSubnetParams{
+			Subnet:        subnet,
+			ExpiresAt:     meta.ExpiresAt,
+			Comment:       meta.Comment,
+			Source:        meta.Source,
+			DefaultSource: meta.DefaultSource,
+			CreatedBy:     meta.CreatedBy,
+			CreatedAt:     meta.CreatedAt,
+			LastHitAt:     meta.LastHitAt,
+		})
 	}
 }
 
@@ -186,9 +277,10 @@ func subnetListUpsert(
 //	@Tags			whitelist
 //	@Accept			json
 //	@Param			body	body	RemoveSubnetsReq	true	"subnets to remove"
-//	@Success		202
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
 //	@Failure		400
-//	@Failure		422
+//	@Failure		422	{object}	MutateResp
 //	@Failure		500
 //	@Router			/v1/whitelist/subnets [delete]
 func RemoveWhiteListSubnets(whitelist *types.SubnetList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
@@ -202,9 +294,10 @@ func RemoveWhiteListSubnets(whitelist *types.SubnetList, mu *sync.Mutex, db *dat
 //	@Tags			blacklist
 //	@Accept			json
 //	@Param			body	body	RemoveSubnetsReq	true	"subnets to remove"
-//	@Success		202
+//	@Produce		json
+//	@Success		202	{object}	MutateResp
 //	@Failure		400
-//	@Failure		422
+//	@Failure		422	{object}	MutateResp
 //	@Failure		500
 //	@Router			/v1/blacklist/subnets [delete]
 func RemoveBlackListSubnets(blacklist *types.SubnetList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
@@ -219,7 +312,7 @@ func subnetListRemove(
 	list *types.SubnetList,
 	mu *sync.Mutex,
 	db *database.Database,
-	removeFn func(ctx context.Context, db database.DBTX, subnet string) error,
+	removeFn func(ctx context.Context, db database.DBTX, subnet string) (int64, error),
 ) func(*gin.Context) {
 	return func(c *gin.Context) {
 		var req RemoveSubnetsReq
@@ -228,27 +321,118 @@ func subnetListRemove(
 			return
 		}
 
-		subnets, err := get.Subnets(req.Subnets)
-		if err != nil {
-			c.AbortWithStatus(http.StatusBadRequest)
+		subnets, invalid, ok := parseEntries(req.Subnets, parseSubnet, identity[string])
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, MutateResp{Results: invalid})
 			return
 		}
 
 		mu.Lock()
 		defer mu.Unlock()
 
-		if err := list.Remove(subnets); err != nil {
-			c.AbortWithStatus(http.StatusUnprocessableEntity)
+		// NOTE: the list is swapped only after the database commit
+		results, err := removeEntries(c, db, subnets, netip.Prefix.String, netip.Prefix.String, removeFn)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
 			return
 		}
 
-		for _, subnet := range subnets {
-			if err := removeFn(c, db.DB, subnet.String()); err != nil {
-				c.AbortWithStatus(http.StatusInternalServerError)
-				return
-			}
+		if err := list.Remove(subnets); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
 		}
 
-		c.Status(http.StatusAccepted)
+		c.JSON(http.StatusAccepted, MutateResp{Results: results})
 	}
 }
+
+// ImportWhiteListSubnets godoc
+//
+//	@Summary		Import whitelisted subnets
+//	@Description	import subnets to whitelist from text, csv, or json, replace mode removes the missing ones
+//	@Tags			whitelist
+//	@Accept			plain,json
+//	@Param			format	query		string	false	"body format"	Enums(text, csv, json)	default(text)
+//	@Param			mode	query		string	false	"import mode"	Enums(merge, replace)	default(merge)
+//	@Param			ttl		query		int		false	"entries ttl (in seconds)"
+//	@Param			comment	query		string	false	"entries comment"
+//	@Param			body	body		string	true	"subnets to import"
+//	@Produce		json
+//	@Success		202		{object}	ImportResp
+//	@Failure		400
+//	@Failure		413
+//	@Failure		422		{object}	ImportResp
+//	@Failure		500
+//	@Router			/v1/whitelist/subnets/import [post]
+func ImportWhiteListSubnets(whitelist *types.SubnetList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return listImport(whitelist, mu, db, listFormats, parseSubnet, netip.Prefix.String, db.Q.EachWhiteListSubnetEntry, whiteListSubnetEntry, db.Q.CountWhiteListSubnet, upsertWhiteListSubnet(db), db.Q.RemoveWhiteListSubnet)
+}
+
+// ExportWhiteListSubnets godoc
+//
+//	@Summary		Export whitelisted subnets
+//	@Description	export whitelisted subnets as text, csv, or json
+//	@Tags			whitelist
+//	@Param			format	query	string	false	"output format"	Enums(text, csv, json)	default(text)
+//	@Produce		plain,json
+//	@Success		200
+//	@Failure		400
+//	@Failure		500
+//	@Router			/v1/whitelist/subnets/export [get]
+func ExportWhiteListSubnets(db *database.Database) func(*gin.Context) {
+	return listExport(db, listFormats, db.Q.EachWhiteListSubnetEntry, whiteListSubnetEntry)
+}
+
+// ImportBlackListSubnets godoc
+//
+//	@Summary		Import blacklisted subnets
+//	@Description	import subnets to blacklist from text, csv, or json, replace mode removes the missing ones
+//	@Tags			blacklist
+//	@Accept			plain,json
+//	@Param			format	query		string	false	"body format"	Enums(text, csv, json)	default(text)
+//	@Param			mode	query		string	false	"import mode"	Enums(merge, replace)	default(merge)
+//	@Param			ttl		query		int		false	"entries ttl (in seconds)"
+//	@Param			comment	query		string	false	"entries comment"
+//	@Param			body	body		string	true	"subnets to import"
+//	@Produce		json
+//	@Success		202		{object}	ImportResp
+//	@Failure		400
+//	@Failure		413
+//	@Failure		422		{object}	ImportResp
+//	@Failure		500
+//	@Router			/v1/blacklist/subnets/import [post]
+func ImportBlackListSubnets(blacklist *types.SubnetList, mu *sync.Mutex, db *database.Database) func(*gin.Context) {
+	return listImport(blacklist, mu, db, listFormats, parseSubnet, netip.Prefix.String, db.Q.EachBlackListSubnetEntry, blackListSubnetEntry, db.Q.CountBlackListSubnet, upsertBlackListSubnet(db), db.Q.RemoveBlackListSubnet)
+}
+
+// ExportBlackListSubnets godoc
+//
+//	@Summary		Export blacklisted subnets
+//	@Description	export blacklisted subnets as text, csv, or json
+//	@Tags			blacklist
+//	@Param			format	query	string	false	"output format"	Enums(text, csv, json)	default(text)
+//	@Produce		plain,json
+//	@Success		200
+//	@Failure		400
+//	@Failure		500
+//	@Router			/v1/blacklist/subnets/export [get]
+func ExportBlackListSubnets(db *database.Database) func(*gin.Context) {
+	return listExport(db, listFormats, db.Q.EachBlackListSubnetEntry, blackListSubnetEntry)
+}
+
+// parseSubnet parses the subnet in its canonical (masked) form
+func parseSubnet(str string) (netip.Prefix, bool) {
+	subnet, ok := get.Subnet(str)
+	return subnet.Masked(), ok
+}
+
+// coveringSubnets returns the subnets containing the subnet (including itself)
+func coveringSubnets(subnet netip.Prefix) []string {
+	subnets := make([]string, 0, subnet.Bits()+1)
+	for bits := range subnet.Bits() + 1 {
+		prefix, _ := subnet.Addr().Prefix(bits)
+		subnets = append(subnets, prefix.String())
+	}
+
+	return subnets
+}
@@ -1,28 +1,216 @@
 package config
 
 import (
+	"fmt"
+	"os"
+	"reflect"
 	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/filter/feed"
+	"github.com/cnaize/meds/src/core/logger"
 )
 
 type Config struct {
-	LogLevel   string
-	DBFilePath string
+	LogLevel       string `yaml:"log-level"`
+	DBFilePath     string `yaml:"db-path" restart:"true"`
+	CacheDirPath   string `yaml:"cache-dir" restart:"true"`
+	ConfigFilePath string `yaml:"-"`
+	// logger
+	LogFormat string        `yaml:"log-format" restart:"true"`
+	LogSinks  []logger.Sink `yaml:"log-sinks" restart:"true"`
+	// log events dedup/sampling
+	LogDedupInterval time.Duration `yaml:"log-dedup-interval" restart:"true"`
+	LogDedupSize     uint          `yaml:"log-dedup-size" restart:"true"`
+	LogSampleBurst   uint          `yaml:"log-sample-burst" restart:"true"`
+	LogSampleRate    uint          `yaml:"log-sample-rate" restart:"true"`
 	// core
-	ReadersCount uint
-	WorkersCount uint
-	LoggersCount uint
-	ReaderQLen   uint
-	LoggerQLen   uint
+	ReadersCount uint `yaml:"readers-count" restart:"true"`
+	WorkersCount uint `yaml:"workers-count" restart:"true"`
+	LoggersCount uint `yaml:"loggers-count" restart:"true"`
+	ReaderQLen   uint `yaml:"reader-queue-len" restart:"true"`
+	LoggerQLen   uint `yaml:"logger-queue-len" restart:"true"`
 	// filters
-	UpdateTimeout  time.Duration
-	UpdateInterval time.Duration
+	UpdateTimeout  time.Duration `yaml:"update-timeout"`
+	UpdateInterval time.Duration `yaml:"update-interval"`
+	UpdateRetries  uint          `yaml:"update-retries" restart:"true"`
+	UpdateBackoff  time.Duration `yaml:"update-backoff" restart:"true"`
+	UpdateMinRatio float64       `yaml:"update-min-ratio" restart:"true"`
+	UpdateParallel uint          `yaml:"update-parallel" restart:"true"`
+	UpdateMemory   uint          `yaml:"update-memory" restart:"true"`
+	HistorySize    uint          `yaml:"history-size" restart:"true"`
+	// events
+	EventsSample    uint          `yaml:"events-sample" restart:"true"`
+	EventsRetention time.Duration `yaml:"events-retention" restart:"true"`
+	EventsMaxCount  uint          `yaml:"events-max-count" restart:"true"`
+	// http client
+	HTTPProxy     string        `yaml:"http-proxy" restart:"true"`
+	HTTPCABundle  string        `yaml:"http-ca-bundle" restart:"true"`
+	HTTPUserAgent string        `yaml:"http-user-agent" restart:"true"`
+	HTTPTimeout   time.Duration `yaml:"http-timeout" restart:"true"`
 	// api server
-	Username      string
-	Password      string
-	APIServerAddr string
+	Username      string `yaml:"-"`
+	Password      string `yaml:"-"`
+	APIServerAddr string `yaml:"api-addr" restart:"true"`
 	// rate limiter
-	LimiterRate      uint
-	LimiterBurst     uint
-	LimiterCacheSize uint
-	LimiterBucketTTL time.Duration
+	LimiterRate      uint          `yaml:"rate-limiter-rate" rebuild:"true"`
+	LimiterBurst     uint          `yaml:"rate-limiter-burst" rebuild:"true"`
+	LimiterCacheSize uint          `yaml:"rate-limiter-cache-size" rebuild:"true"`
+	LimiterBucketTTL time.Duration `yaml:"rate-limiter-cache-ttl" rebuild:"true"`
+	// geo
+	GeoDenyUnknown bool `yaml:"geo-deny-unknown" rebuild:"true"`
+	// feeds
+	IPLocate []string    `yaml:"iplocate" rebuild:"true"`
+	MMDB     []string    `yaml:"mmdb" rebuild:"true"`
+	Feeds    []feed.Feed `yaml:"feeds" rebuild:"true"`
+}
+
+func DefaultFeeds() []feed.Feed {
+	return []feed.Feed{
+		{
+			Name: "FireHOL",
+			Type: filter.FilterTypeIP,
+			Sources: []feed.Source{
+				{URL: "https://raw.githubusercontent.com/firehol/blocklist-ipsets/master/firehol_level1.netset", Format: feed.FormatNetset},
+			},
+		},
+		{
+			Name:   "Spamhaus",
+			Type:   filter.FilterTypeIP,
+			Limits: feed.Limits{NoWhitelisted: true},
+			Sources: []feed.Source{
+				{URL: "https://www.spamhaus.org/drop/drop.txt", Format: feed.FormatNetset},
+			},
+		},
+		{
+			Name: "Abuse",
+			Type: filter.FilterTypeIP,
+			Sources: []feed.Source{
+				{URL: "https://feodotracker.abuse.ch/downloads/ipblocklist.txt", Format: feed.FormatNetset},
+			},
+		},
+		{
+			Name: "Spamhaus",
+			Type: filter.FilterTypeASN,
+			Sources: []feed.Source{
+				{URL: "https://www.spamhaus.org/drop/asndrop.json", Format: feed.FormatJSONL, Field: "asn"},
+			},
+		},
+		{
+			Name: "StevenBlack",
+			Type: filter.FilterTypeDomain,
+			Sources: []feed.Source{
+				{URL: "https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts", Format: feed.FormatHosts},
+			},
+		},
+		{
+			Name: "SomeoneWhoCares",
+			Type: filter.FilterTypeDomain,
+			Sources: []feed.Source{
+				{URL: "https://someonewhocares.org/hosts/hosts", Format: feed.FormatHosts},
+			},
+		},
+		{
+			Name: "Abuse",
+			Type: filter.FilterTypeJA3,
+			Sources: []feed.Source{
+				{URL: "https://sslbl.abuse.ch/blacklist/ja3_fingerprints.csv", Format: feed.FormatCSV, Column: 0},
+			},
+		},
+	}
+}
+
+func DefaultIPLocate() []string {
+	return []string{"https://github.com/iplocate/ip-address-databases/raw/refs/heads/main/ip-to-asn/ip-to-asn.csv.zip"}
+}
+
+// Load reads the config file (if any) on top of the provided config,
+// so values from the file override command-line flags
+func Load(path string, cfg Config) (Config, error) {
+	if len(path) < 1 {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("read file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("validate: %w", err)
+	}
+
+	return cfg, nil
+}
+
+func (c Config) Validate() error {
+	for i, sink := range c.LogSinks {
+		if err := sink.Validate(); err != nil {
+			return fmt.Errorf("log sink %d (%s): %w", i, sink.Type, err)
+		}
+	}
+
+	keys := make(map[string]bool, len(c.Feeds))
+	for _, f := range c.Feeds {
+		if err := f.Validate(); err != nil {
+			return fmt.Errorf("feed %s (%s): %w", f.Name, f.Type, err)
+		}
+
+		key := string(f.Type) + "/" + f.Name
+		if keys[key] {
+			return fmt.Errorf("feed %s (%s): duplicate", f.Name, f.Type)
+		}
+		keys[key] = true
+	}
+
+	return nil
+}
+
+type Diff struct {
+	// applied without restart
+	Reloaded []string `json:"reloaded" example:"rate-limiter-rate,feeds"`
+	// require daemon restart
+	Restart []string `json:"restart" example:"readers-count"`
+	// filters must be rebuilt
+	Rebuild bool `json:"rebuild"`
+}
+
+func (c Config) Diff(other Config) Diff {
+	diff := Diff{
+		Reloaded: []string{},
+		Restart:  []string{},
+	}
+
+	oldVal := reflect.ValueOf(c)
+	newVal := reflect.ValueOf(other)
+	for i := range oldVal.NumField() {
+		field := oldVal.Type().Field(i)
+
+		key := field.Tag.Get("yaml")
+		if key == "-" {
+			continue
+		}
+
+		if reflect.DeepEqual(oldVal.Field(i).Interface(), newVal.Field(i).Interface()) {
+			continue
+		}
+
+		if field.Tag.Get("restart") == "true" {
+			diff.Restart = append(diff.Restart, key)
+		} else {
+			diff.Reloaded = append(diff.Reloaded, key)
+		}
+
+		if field.Tag.Get("rebuild") == "true" {
+			diff.Rebuild = true
+		}
+	}
+
+	return diff
 }
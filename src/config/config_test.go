@@ -0,0 +1,138 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/filter/feed"
+)
+
+func writeConfig(t *testing.T, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "meds.yaml")
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("write: %s", err)
+	}
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	flags := Config{LogLevel: "info", ReadersCount: 4, UpdateInterval: time.Hour, Feeds: DefaultFeeds()}
+
+	t.Run("no file", func(t *testing.T) {
+		cfg, err := Load("", flags)
+		if err != nil || !reflect.DeepEqual(cfg, flags) {
+			t.Errorf("got %+v (%v), want the flags", cfg, err)
+		}
+	})
+
+	t.Run("file overrides flags", func(t *testing.T) {
+		cfg, err := Load(writeConfig(t, `
+log-level: debug
+update-interval: 30m
+feeds:
+  - name: Custom
+    type: domain
+    interval: 2h
+    sources:
+      - url: https://lists.example.com/hosts
+        format: hosts
+    limits:
+      max-entries: 1000
+`), flags)
+		if err != nil {
+			t.Fatalf("load: %s", err)
+		}
+
+		want := flags
+		want.LogLevel = "debug"
+		want.UpdateInterval = 30 * time.Minute
+		want.Feeds = []feed.Feed{{
+			Name:     "Custom",
+			Type:     filter.FilterTypeDomain,
+			Interval: 2 * time.Hour,
+			Sources:  []feed.Source{{URL: "https://lists.example.com/hosts", Format: feed.FormatHosts}},
+			Limits:   feed.Limits{MaxEntries: 1000},
+		}}
+		if !reflect.DeepEqual(cfg, want) {
+			t.Errorf("got %+v, want %+v", cfg, want)
+		}
+	})
+
+	invalid := []struct {
+		name string
+		data string
+	}{
+		{name: "syntax", data: "log-level: [debug"},
+		{name: "feed type", data: "feeds: [{name: Custom, type: geo, sources: [{url: https://a.b/c, format: plain}]}]"},
+		{name: "feed duplicate", data: "feeds: [{name: A, type: ip, sources: [{url: https://a.b/c, format: netset}]}, {name: A, type: ip, sources: [{url: https://a.b/d, format: netset}]}]"},
+		{name: "feed reserved name", data: "feeds: [{name: WhiteList, type: ip, sources: [{url: https://a.b/c, format: netset}]}]"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(writeConfig(t, tt.data), flags); err == nil {
+				t.Errorf("loaded the invalid config")
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml"), flags); err == nil {
+			t.Errorf("loaded the missing file")
+		}
+	})
+}
+
+func TestDiff(t *testing.T) {
+	base := Config{LogLevel: "info", ReadersCount: 4, LimiterRate: 100, Feeds: DefaultFeeds(), Password: "secret"}
+
+	tests := []struct {
+		name   string
+		update func(cfg *Config)
+		want   Diff
+	}{
+		{
+			name:   "unchanged",
+			update: func(cfg *Config) {},
+			want:   Diff{Reloaded: []string{}, Restart: []string{}},
+		},
+		{
+			name:   "reloaded",
+			update: func(cfg *Config) { cfg.LogLevel = "debug"; cfg.UpdateInterval = time.Minute },
+			want:   Diff{Reloaded: []string{"log-level", "update-interval"}, Restart: []string{}},
+		},
+		{
+			name:   "rebuild",
+			update: func(cfg *Config) { cfg.LimiterRate = 10; cfg.Feeds = cfg.Feeds[1:] },
+			want:   Diff{Reloaded: []string{"rate-limiter-rate", "feeds"}, Restart: []string{}, Rebuild: true},
+		},
+		{
+			name:   "restart",
+			update: func(cfg *Config) { cfg.ReadersCount = 8; cfg.DBFilePath = "other.db" },
+			want:   Diff{Reloaded: []string{}, Restart: []string{"db-path", "readers-count"}},
+		},
+		{
+			name:   "ignored",
+			update: func(cfg *Config) { cfg.Password = "other"; cfg.ConfigFilePath = "other.yaml" },
+			want:   Diff{Reloaded: []string{}, Restart: []string{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := base
+			other.Feeds = DefaultFeeds()
+			tt.update(&other)
+
+			if got := base.Diff(other); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"maps"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/types"
+)
+
+// packet decisions
+const (
+	ActionAccept = "accept"
+	ActionDrop   = "drop"
+)
+
+// Verdict is the filter verdict on the explained packet
+type Verdict struct {
+	Name    string
+	Type    filter.FilterType
+	Enabled bool
+	// the packet is in the filter list
+	Matched bool
+	// matched list entry (if known)
+	Match string
+	// the filter decided the packet fate
+	Decisive bool
+}
+
+type Explanation struct {
+	// verdicts in the pipeline order
+	Verdicts []Verdict
+	// accepted or dropped
+	Action string
+	// decisive filter ("default" if accepted by default)
+	Name string
+	Type filter.FilterType
+}
+
+// Explain passes the packet through the pipeline like a worker does,
+// but without side effects and reporting every filter verdict
+func (q *Queue) Explain(packet *types.Packet) Explanation {
+	q.mu.Lock()
+	disabled := maps.Clone(q.disabled)
+	q.mu.Unlock()
+
+	explanation := Explanation{Action: ActionAccept, Name: "default", Type: filter.FilterTypeEmpty}
+	decided := false
+	for _, f := range q.Filters() {
+		explainer, ok := f.(filter.Explainer)
+		if !ok {
+			continue
+		}
+
+		// NOTE: whitelists match on true, others on false
+		passed, match := explainer.Explain(packet)
+		whitelist := f.Name() == filter.FilterNameWhiteList
+		verdict := Verdict{
+			Name:    f.Name(),
+			Type:    f.Type(),
+			Enabled: !disabled[filterKey(f.Type(), f.Name())],
+			Matched: passed == whitelist,
+			Match:   match,
+		}
+
+		if verdict.Enabled && verdict.Matched && !decided {
+			decided = true
+			verdict.Decisive = true
+
+			explanation.Name = f.Name()
+			explanation.Type = f.Type()
+			if !whitelist {
+				explanation.Action = ActionDrop
+			}
+		}
+
+		explanation.Verdicts = append(explanation.Verdicts, verdict)
+	}
+
+	return explanation
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/filter/domain"
+	"github.com/cnaize/meds/src/core/filter/ip"
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/types"
+)
+
+func TestQueueExplain(t *testing.T) {
+	whitelist := types.NewSubnetList()
+	blacklist := types.NewSubnetList()
+	domains := types.NewDomainList()
+	if err := whitelist.Upsert([]netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}); err != nil {
+		t.Fatalf("upsert: %s", err)
+	}
+	if err := blacklist.Upsert([]netip.Prefix{netip.MustParsePrefix("1.2.3.0/24")}); err != nil {
+		t.Fatalf("upsert: %s", err)
+	}
+	if err := domains.Upsert([]string{"bad.com"}); err != nil {
+		t.Fatalf("upsert: %s", err)
+	}
+
+	nop := zerolog.Nop()
+	log := logger.NewLogger(&nop, 1)
+	q := NewQueue(1, 1, 1, 1, 0, []filter.Filter{
+		ip.NewWhiteList(log, whitelist),
+		ip.NewBlackList(log, blacklist),
+		domain.NewBlackList(log, domains),
+	}, log)
+
+	tests := []struct {
+		name     string
+		ip       string
+		sni      string
+		disabled filter.FilterType
+		action   string
+		decisive filter.FilterType
+		match    string
+	}{
+		{name: "default", ip: "5.5.5.5", action: ActionAccept, decisive: filter.FilterTypeEmpty},
+		{name: "ip blacklist", ip: "1.2.3.4", sni: "bad.com", action: ActionDrop, decisive: filter.FilterTypeIP, match: "1.2.3.0/24"},
+		{name: "domain blacklist", ip: "5.5.5.5", sni: "www.bad.com", action: ActionDrop, decisive: filter.FilterTypeDomain, match: "bad.com"},
+		{name: "whitelist first", ip: "10.1.1.1", sni: "bad.com", action: ActionAccept, decisive: filter.FilterTypeIP, match: "10.0.0.0/8"},
+		{name: "disabled", ip: "1.2.3.4", disabled: filter.FilterTypeIP, action: ActionAccept, decisive: filter.FilterTypeEmpty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.disabled != "" {
+				if err := q.SetEnabled(tt.disabled, filter.FilterNameBlackList, false); err != nil {
+					t.Fatalf("disable: %s", err)
+				}
+				t.Cleanup(func() { q.SetEnabled(tt.disabled, filter.FilterNameBlackList, true) })
+			}
+
+			packet, err := types.NewLookupPacket(netip.MustParseAddr(tt.ip), 443, tt.sni, "")
+			if err != nil {
+				t.Fatalf("packet: %s", err)
+			}
+
+			explanation := q.Explain(packet)
+			if explanation.Action != tt.action || explanation.Type != tt.decisive {
+				t.Errorf("got %s by %s, want %s by %s", explanation.Action, explanation.Type, tt.action, tt.decisive)
+			}
+			if len(explanation.Verdicts) != 3 {
+				t.Fatalf("got %d verdicts, want every filter", len(explanation.Verdicts))
+			}
+
+			decisive := 0
+			for _, verdict := range explanation.Verdicts {
+				if verdict.Enabled == (verdict.Type == tt.disabled && verdict.Name == filter.FilterNameBlackList) {
+					t.Errorf("%s (%s): enabled %t", verdict.Name, verdict.Type, verdict.Enabled)
+				}
+				if !verdict.Decisive {
+					continue
+				}
+
+				decisive++
+				if verdict.Type != tt.decisive || verdict.Match != tt.match {
+					t.Errorf("decisive %+v, want %s matching %q", verdict, tt.decisive, tt.match)
+				}
+			}
+			if want := min(1, len(tt.match)); decisive != want {
+				t.Errorf("got %d decisive verdicts, want %d", decisive, want)
+			}
+		})
+	}
+}
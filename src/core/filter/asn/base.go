@@ -2,25 +2,29 @@ package asn
 
 import (
 	"context"
+	"strconv"
 	"sync/atomic"
 
 	"github.com/cnaize/meds/lib/util/get"
 	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/filter/feed"
 	"github.com/cnaize/meds/src/core/logger"
 	"github.com/cnaize/meds/src/types"
 )
 
 type Base struct {
-	urls   []string
-	logger *logger.Logger
+	sources []feed.Source
+	fetcher *feed.Fetcher
+	logger  *logger.Logger
 
 	asnlist   *types.ASNList
 	blacklist atomic.Pointer[map[uint32]bool]
 }
 
-func NewBase(urls []string, logger *logger.Logger, asnlist *types.ASNList) *Base {
+func NewBase(sources []feed.Source, fetcher *feed.Fetcher, logger *logger.Logger, asnlist *types.ASNList) *Base {
 	return &Base{
-		urls:    urls,
+		sources: sources,
+		fetcher: fetcher,
 		logger:  logger,
 		asnlist: asnlist,
 	}
@@ -45,3 +49,17 @@ func (f *Base) Check(packet *types.Packet) bool {
 	list := *f.blacklist.Load()
 	return !list[asn.ASN]
 }
+
+func (f *Base) Explain(packet *types.Packet) (bool, string) {
+	asn, ok := packet.GetASN(f.asnlist)
+	if !ok {
+		return true, ""
+	}
+
+	list := *f.blacklist.Load()
+	if !list[asn.ASN] {
+		return true, ""
+	}
+
+	return false, strconv.FormatUint(uint64(asn.ASN), 10)
+}
@@ -0,0 +1,65 @@
+package asn
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/types"
+)
+
+var (
+	_ filter.Filter    = (*BlackList)(nil)
+	_ filter.Explainer = (*BlackList)(nil)
+)
+
+type BlackList struct {
+	logger    *logger.Logger
+	asnlist   *types.ASNList
+	blacklist *types.ASNumberList
+}
+
+func NewBlackList(logger *logger.Logger, asnlist *types.ASNList, blacklist *types.ASNumberList) *BlackList {
+	return &BlackList{
+		logger:    logger,
+		asnlist:   asnlist,
+		blacklist: blacklist,
+	}
+}
+
+func (f *BlackList) Name() string {
+	return filter.FilterNameBlackList
+}
+
+func (f *BlackList) Type() filter.FilterType {
+	return filter.FilterTypeASN
+}
+
+func (f *BlackList) Load(ctx context.Context) error {
+	defer f.logger.Raw().Info().Str("name", f.Name()).Str("type", string(f.Type())).Msg("Filter loaded")
+
+	return nil
+}
+
+func (f *BlackList) Check(packet *types.Packet) bool {
+	asn, ok := packet.GetASN(f.asnlist)
+	if !ok {
+		return true
+	}
+
+	return !f.blacklist.Hit(asn.ASN)
+}
+
+func (f *BlackList) Explain(packet *types.Packet) (bool, string) {
+	asn, ok := packet.GetASN(f.asnlist)
+	if !ok || !f.blacklist.Lookup(asn.ASN) {
+		return true, ""
+	}
+
+	return false, strconv.FormatUint(uint64(asn.ASN), 10)
+}
+
+func (f *BlackList) Update(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,155 @@
+package asn
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/filter/feed"
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/types"
+)
+
+var (
+	_ filter.Filter    = (*Feed)(nil)
+	_ filter.Reporter  = (*Feed)(nil)
+	_ filter.Versioner = (*Feed)(nil)
+	_ filter.Explainer = (*Feed)(nil)
+)
+
+type Feed struct {
+	*Base
+
+	name     string
+	interval time.Duration
+	limits   feed.Limits
+	bytes    atomic.Int64
+	size     atomic.Int64
+}
+
+func NewFeed(def feed.Feed, fetcher *feed.Fetcher, logger *logger.Logger, asnlist *types.ASNList) *Feed {
+	return &Feed{
+		Base:     NewBase(def.Sources, fetcher, logger, asnlist),
+		name:     def.Name,
+		interval: def.Interval,
+		limits:   def.Limits,
+	}
+}
+
+func (f *Feed) Name() string {
+	return f.name
+}
+
+// Interval overrides the default update interval (if not zero)
+func (f *Feed) Interval() time.Duration {
+	return f.interval
+}
+
+func (f *Feed) Stats() filter.Stats {
+	return filter.Stats{
+		Entries: len(*f.blacklist.Load()),
+		Bytes:   int(f.bytes.Load()),
+		Size:    int(f.size.Load()),
+	}
+}
+
+func (f *Feed) Entries() []string {
+	list := *f.blacklist.Load()
+	entries := make([]string, 0, len(list))
+	for asn := range list {
+		entries = append(entries, strconv.FormatUint(uint64(asn), 10))
+	}
+
+	return entries
+}
+
+func (f *Feed) Restore(entries []string) error {
+	blacklist := make(map[uint32]bool, len(entries))
+	for _, entry := range entries {
+		asn, err := strconv.ParseUint(entry, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid asn: %s", entry)
+		}
+
+		blacklist[uint32(asn)] = true
+	}
+	f.blacklist.Store(&blacklist)
+
+	return nil
+}
+
+func (f *Feed) Load(ctx context.Context) error {
+	defer f.logger.Raw().Info().Str("name", f.Name()).Str("type", string(f.Type())).Msg("Filter loaded")
+
+	if err := f.Base.Load(ctx); err != nil {
+		return err
+	}
+
+	// populate from cache
+	if err := f.update(ctx, f.fetcher.Load); err != nil {
+		f.logger.Raw().
+			Debug().
+			Err(err).
+			Str("name", f.Name()).
+			Str("type", string(f.Type())).
+			Msg("cache load failed")
+	}
+
+	return nil
+}
+
+func (f *Feed) Update(ctx context.Context) error {
+	return f.update(ctx, f.fetcher.Fetch)
+}
+
+func (f *Feed) update(ctx context.Context, fetch feed.FetchFunc) error {
+	blacklist := make(map[uint32]bool)
+	stats, err := fetch(ctx, f.sources, func(entry string) error {
+		// accept both "AS123" and "123"
+		asn, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(entry), "AS"), 10, 32)
+		if err != nil || asn < 1 {
+			return nil
+		}
+
+		blacklist[uint32(asn)] = true
+		return f.limits.CheckSize(len(blacklist))
+	})
+	if err != nil {
+		return err
+	}
+
+	if stats.Warn != nil {
+		f.logger.Raw().
+			Warn().
+			Err(stats.Warn).
+			Str("name", f.Name()).
+			Str("type", string(f.Type())).
+			Msg("Filter update degraded")
+	}
+
+	f.bytes.Store(int64(stats.Bytes))
+	f.size.Store(int64(stats.Size))
+
+	if stats.NotModified {
+		f.logger.Raw().
+			Debug().
+			Str("name", f.Name()).
+			Str("type", string(f.Type())).
+			Msg("Filter not modified")
+		return nil
+	}
+
+	f.logger.Raw().
+		Info().
+		Str("name", f.Name()).
+		Str("type", string(f.Type())).
+		Int("size", len(blacklist)).
+		Msg("Filter updated")
+	f.blacklist.Store(&blacklist)
+
+	return nil
+}
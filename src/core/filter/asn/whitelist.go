@@ -0,0 +1,65 @@
+package asn
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/types"
+)
+
+var (
+	_ filter.Filter    = (*WhiteList)(nil)
+	_ filter.Explainer = (*WhiteList)(nil)
+)
+
+type WhiteList struct {
+	logger    *logger.Logger
+	asnlist   *types.ASNList
+	whitelist *types.ASNumberList
+}
+
+func NewWhiteList(logger *logger.Logger, asnlist *types.ASNList, whitelist *types.ASNumberList) *WhiteList {
+	return &WhiteList{
+		logger:    logger,
+		asnlist:   asnlist,
+		whitelist: whitelist,
+	}
+}
+
+func (f *WhiteList) Name() string {
+	return filter.FilterNameWhiteList
+}
+
+func (f *WhiteList) Type() filter.FilterType {
+	return filter.FilterTypeASN
+}
+
+func (f *WhiteList) Load(ctx context.Context) error {
+	defer f.logger.Raw().Info().Str("name", f.Name()).Str("type", string(f.Type())).Msg("Filter loaded")
+
+	return nil
+}
+
+func (f *WhiteList) Check(packet *types.Packet) bool {
+	asn, ok := packet.GetASN(f.asnlist)
+	if !ok {
+		return false
+	}
+
+	return f.whitelist.Hit(asn.ASN)
+}
+
+func (f *WhiteList) Explain(packet *types.Packet) (bool, string) {
+	asn, ok := packet.GetASN(f.asnlist)
+	if !ok || !f.whitelist.Lookup(asn.ASN) {
+		return false, ""
+	}
+
+	return true, strconv.FormatUint(uint64(asn.ASN), 10)
+}
+
+func (f *WhiteList) Update(ctx context.Context) error {
+	return nil
+}
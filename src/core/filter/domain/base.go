@@ -6,21 +6,25 @@ import (
 
 	"github.com/armon/go-radix"
 
+	"github.com/cnaize/meds/lib/util/get"
 	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/filter/feed"
 	"github.com/cnaize/meds/src/core/logger"
 	"github.com/cnaize/meds/src/types"
 )
 
 type Base struct {
-	urls      []string
+	sources   []feed.Source
+	fetcher   *feed.Fetcher
 	logger    *logger.Logger
 	blacklist atomic.Pointer[radix.Tree]
 }
 
-func NewBase(urls []string, logger *logger.Logger) *Base {
+func NewBase(sources []feed.Source, fetcher *feed.Fetcher, logger *logger.Logger) *Base {
 	return &Base{
-		urls:   urls,
-		logger: logger,
+		sources: sources,
+		fetcher: fetcher,
+		logger:  logger,
 	}
 }
 
@@ -44,3 +48,14 @@ func (f *Base) Check(packet *types.Packet) bool {
 
 	return true
 }
+
+func (f *Base) Explain(packet *types.Packet) (bool, string) {
+	list := f.blacklist.Load()
+	for _, revDomain := range packet.GetReversedDomains() {
+		if match, _, found := list.LongestPrefix(revDomain); found {
+			return false, get.ReversedDomain(match)
+		}
+	}
+
+	return true, ""
+}
@@ -9,7 +9,10 @@ import (
 	"github.com/cnaize/meds/src/types"
 )
 
-var _ filter.Filter = (*BlackList)(nil)
+var (
+	_ filter.Filter    = (*BlackList)(nil)
+	_ filter.Explainer = (*BlackList)(nil)
+)
 
 type BlackList struct {
 	logger    *logger.Logger
@@ -38,7 +41,17 @@ func (f *BlackList) Load(ctx context.Context) error {
 }
 
 func (f *BlackList) Check(packet *types.Packet) bool {
-	return !slices.ContainsFunc(packet.GetDomains(), f.blacklist.Lookup)
+	return !slices.ContainsFunc(packet.GetDomains(), f.blacklist.Hit)
+}
+
+func (f *BlackList) Explain(packet *types.Packet) (bool, string) {
+	for _, domain := range packet.GetDomains() {
+		if match, ok := f.blacklist.Match(domain); ok {
+			return false, match
+		}
+	}
+
+	return true, ""
 }
 
 func (f *BlackList) Update(ctx context.Context) error {
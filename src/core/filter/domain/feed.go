@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/armon/go-radix"
+
+	"github.com/cnaize/meds/lib/util/get"
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/filter/feed"
+	"github.com/cnaize/meds/src/core/logger"
+)
+
+var (
+	_ filter.Filter    = (*Feed)(nil)
+	_ filter.Reporter  = (*Feed)(nil)
+	_ filter.Versioner = (*Feed)(nil)
+	_ filter.Explainer = (*Feed)(nil)
+)
+
+type Feed struct {
+	*Base
+
+	name     string
+	interval time.Duration
+	limits   feed.Limits
+	bytes    atomic.Int64
+	size     atomic.Int64
+}
+
+func NewFeed(def feed.Feed, fetcher *feed.Fetcher, logger *logger.Logger) *Feed {
+	return &Feed{
+		Base:     NewBase(def.Sources, fetcher, logger),
+		name:     def.Name,
+		interval: def.Interval,
+		limits:   def.Limits,
+	}
+}
+
+func (f *Feed) Name() string {
+	return f.name
+}
+
+// Interval overrides the default update interval (if not zero)
+func (f *Feed) Interval() time.Duration {
+	return f.interval
+}
+
+func (f *Feed) Stats() filter.Stats {
+	return filter.Stats{
+		Entries: f.blacklist.Load().Len(),
+		Bytes:   int(f.bytes.Load()),
+		Size:    int(f.size.Load()),
+	}
+}
+
+func (f *Feed) Entries() []string {
+	list := f.blacklist.Load()
+	entries := make([]string, 0, list.Len())
+	list.Walk(func(domain string, _ any) bool {
+		entries = append(entries, get.ReversedDomain(domain))
+		return false
+	})
+
+	return entries
+}
+
+func (f *Feed) Restore(entries []string) error {
+	blacklist := radix.New()
+	for _, entry := range entries {
+		blacklist.Insert(get.ReversedDomain(entry), struct{}{})
+	}
+	f.blacklist.Store(blacklist)
+
+	return nil
+}
+
+func (f *Feed) Load(ctx context.Context) error {
+	defer f.logger.Raw().Info().Str("name", f.Name()).Str("type", string(f.Type())).Msg("Filter loaded")
+
+	if err := f.Base.Load(ctx); err != nil {
+		return err
+	}
+
+	// populate from cache
+	if err := f.update(ctx, f.fetcher.Load); err != nil {
+		f.logger.Raw().
+			Debug().
+			Err(err).
+			Str("name", f.Name()).
+			Str("type", string(f.Type())).
+			Msg("cache load failed")
+	}
+
+	return nil
+}
+
+func (f *Feed) Update(ctx context.Context) error {
+	return f.update(ctx, f.fetcher.Fetch)
+}
+
+func (f *Feed) update(ctx context.Context, fetch feed.FetchFunc) error {
+	blacklist := radix.New()
+	stats, err := fetch(ctx, f.sources, func(entry string) error {
+		blacklist.Insert(get.ReversedDomain(entry), struct{}{})
+		return f.limits.CheckSize(blacklist.Len())
+	})
+	if err != nil {
+		return err
+	}
+
+	if stats.Warn != nil {
+		f.logger.Raw().
+			Warn().
+			Err(stats.Warn).
+			Str("name", f.Name()).
+			Str("type", string(f.Type())).
+			Msg("Filter update degraded")
+	}
+
+	f.bytes.Store(int64(stats.Bytes))
+	f.size.Store(int64(stats.Size))
+
+	if stats.NotModified {
+		f.logger.Raw().
+			Debug().
+			Str("name", f.Name()).
+			Str("type", string(f.Type())).
+			Msg("Filter not modified")
+		return nil
+	}
+
+	f.logger.Raw().
+		Info().
+		Str("name", f.Name()).
+		Str("type", string(f.Type())).
+		Int("size", blacklist.Len()).
+		Msg("Filter updated")
+	f.blacklist.Store(blacklist)
+
+	return nil
+}
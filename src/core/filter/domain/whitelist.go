@@ -9,7 +9,10 @@ import (
 	"github.com/cnaize/meds/src/types"
 )
 
-var _ filter.Filter = (*WhiteList)(nil)
+var (
+	_ filter.Filter    = (*WhiteList)(nil)
+	_ filter.Explainer = (*WhiteList)(nil)
+)
 
 type WhiteList struct {
 	logger    *logger.Logger
@@ -38,7 +41,17 @@ func (f *WhiteList) Load(ctx context.Context) error {
 }
 
 func (f *WhiteList) Check(packet *types.Packet) bool {
-	return slices.ContainsFunc(packet.GetDomains(), f.whitelist.Lookup)
+	return slices.ContainsFunc(packet.GetDomains(), f.whitelist.Hit)
+}
+
+func (f *WhiteList) Explain(packet *types.Packet) (bool, string) {
+	for _, domain := range packet.GetDomains() {
+		if match, ok := f.whitelist.Match(domain); ok {
+			return true, match
+		}
+	}
+
+	return false, ""
 }
 
 func (f *WhiteList) Update(ctx context.Context) error {
@@ -0,0 +1,124 @@
+package feed
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type Meta struct {
+	URL     string    `json:"url"`
+	Fetched time.Time `json:"fetched"`
+	Size    int       `json:"size"`
+	SHA256  string    `json:"sha256"`
+	// parsed entries count
+	Entries int `json:"entries"`
+	// conditional request validators
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// Cache keeps the last successful download of every source on disk (gzipped)
+type Cache struct {
+	dir string
+}
+
+func NewCache(dir string) *Cache {
+	return &Cache{
+		dir: dir,
+	}
+}
+
+func (c *Cache) Get(url string) ([]byte, Meta, error) {
+	var meta Meta
+
+	data, err := os.ReadFile(c.path(url, ".json"))
+	if err != nil {
+		return nil, meta, fmt.Errorf("read meta: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, meta, fmt.Errorf("unmarshal meta: %w", err)
+	}
+
+	file, err := os.Open(c.path(url, ".gz"))
+	if err != nil {
+		return nil, meta, fmt.Errorf("open body: %w", err)
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, meta, fmt.Errorf("new gzip reader: %w", err)
+	}
+	defer reader.Close()
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, meta, fmt.Errorf("read body: %w", err)
+	}
+
+	if sum := sha256.Sum256(body); hex.EncodeToString(sum[:]) != meta.SHA256 {
+		return nil, meta, fmt.Errorf("checksum mismatch")
+	}
+
+	return body, meta, nil
+}
+
+func (c *Cache) Put(url string, body []byte, meta Meta) error {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+
+	sum := sha256.Sum256(body)
+	meta.URL = url
+	meta.Size = len(body)
+	meta.SHA256 = hex.EncodeToString(sum[:])
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(body); err != nil {
+		return fmt.Errorf("gzip write: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("gzip close: %w", err)
+	}
+
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("marshal meta: %w", err)
+	}
+
+	// body first: meta is written only for a complete body
+	if err := writeFile(c.path(url, ".gz"), buf.Bytes()); err != nil {
+		return fmt.Errorf("write body: %w", err)
+	}
+
+	if err := writeFile(c.path(url, ".json"), data); err != nil {
+		return fmt.Errorf("write meta: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Cache) path(url, ext string) string {
+	key := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(key[:16])+ext)
+}
+
+// writeFile replaces the file atomically
+func writeFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
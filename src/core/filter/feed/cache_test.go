@@ -0,0 +1,115 @@
+package feed
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	cache := NewCache(filepath.Join(t.TempDir(), "feeds"))
+	url := "https://example.com/list.txt"
+
+	if _, _, err := cache.Get(url); err == nil {
+		t.Fatalf("got missing body")
+	}
+
+	body := []byte("1.2.3.4\n5.6.7.8\n")
+	meta := Meta{Fetched: time.Now().UTC().Truncate(time.Second), Entries: 2, ETag: `"abc"`}
+	if err := cache.Put(url, body, meta); err != nil {
+		t.Fatalf("put: %s", err)
+	}
+
+	got, gotMeta, err := cache.Get(url)
+	if err != nil {
+		t.Fatalf("get: %s", err)
+	}
+	if !bytes.Equal(got, body) {
+		t.Errorf("body %q, want %q", got, body)
+	}
+	if gotMeta.URL != url || gotMeta.Size != len(body) || gotMeta.Entries != 2 || gotMeta.ETag != meta.ETag || !gotMeta.Fetched.Equal(meta.Fetched) {
+		t.Errorf("meta %+v", gotMeta)
+	}
+
+	// NOTE: the other urls don't collide
+	if _, _, err := cache.Get(url + "?v=2"); err == nil {
+		t.Errorf("got body of the other url")
+	}
+}
+
+func TestCacheCorrupted(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewCache(dir)
+	url := "https://example.com/list.txt"
+
+	if err := cache.Put(url, []byte("1.2.3.4\n"), Meta{}); err != nil {
+		t.Fatalf("put: %s", err)
+	}
+
+	// replace the body with another valid gzip
+	other := NewCache(t.TempDir())
+	if err := other.Put(url, []byte("5.6.7.8\n"), Meta{}); err != nil {
+		t.Fatalf("put other: %s", err)
+	}
+	data, err := os.ReadFile(other.path(url, ".gz"))
+	if err != nil {
+		t.Fatalf("read other: %s", err)
+	}
+	if err := os.WriteFile(cache.path(url, ".gz"), data, 0o644); err != nil {
+		t.Fatalf("write: %s", err)
+	}
+
+	if _, _, err := cache.Get(url); err == nil {
+		t.Errorf("got mismatched body")
+	}
+
+	if err := os.WriteFile(cache.path(url, ".gz"), []byte("not gzip"), 0o644); err != nil {
+		t.Fatalf("write: %s", err)
+	}
+	if _, _, err := cache.Get(url); err == nil {
+		t.Errorf("got invalid body")
+	}
+}
+
+func TestParseBodyZip(t *testing.T) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	if _, err := archive.Create("lists/"); err != nil {
+		t.Fatalf("create dir: %s", err)
+	}
+	for name, data := range map[string]string{"lists/a.txt": "1.1.1.1\n", "lists/b.txt": "# comment\n2.2.2.2\n"} {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("create %s: %s", name, err)
+		}
+		if _, err := w.Write([]byte(data)); err != nil {
+			t.Fatalf("write %s: %s", name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("close: %s", err)
+	}
+
+	var got []string
+	if err := ParseBody(buf.Bytes(), Source{Format: FormatNetset, Zip: true}, func(entry string) error {
+		got = append(got, entry)
+		return nil
+	}); err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+
+	slices.Sort(got)
+	if want := []string{"1.1.1.1", "2.2.2.2"}; !slices.Equal(got, want) {
+		t.Errorf("parsed %q, want %q", got, want)
+	}
+
+	if err := ParseBody([]byte("1.1.1.1\n"), Source{Format: FormatNetset, Zip: true}, func(entry string) error {
+		return nil
+	}); err == nil {
+		t.Errorf("parsed not a zip")
+	}
+}
@@ -0,0 +1,77 @@
+package feed
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+type ClientConfig struct {
+	// proxy url, environment proxy is used if empty
+	Proxy string
+	// pem file with extra root certificates
+	CABundle  string
+	UserAgent string
+	// per request timeout
+	Timeout time.Duration
+}
+
+// NewClient creates the http client shared by all feeds
+func NewClient(cfg ClientConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if len(cfg.Proxy) > 0 {
+		proxy, err := url.Parse(cfg.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("parse proxy: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if len(cfg.CABundle) > 0 {
+		data, err := os.ReadFile(cfg.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("read ca bundle: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, errors.New("ca bundle: no certificates found")
+		}
+
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	var rt http.RoundTripper = transport
+	if len(cfg.UserAgent) > 0 {
+		rt = &userAgentTransport{
+			next:      transport,
+			userAgent: cfg.UserAgent,
+		}
+	}
+
+	return &http.Client{
+		Transport: rt,
+		Timeout:   cfg.Timeout,
+	}, nil
+}
+
+type userAgentTransport struct {
+	next      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+
+	return t.next.RoundTrip(req)
+}
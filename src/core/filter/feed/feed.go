@@ -0,0 +1,125 @@
+package feed
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cnaize/meds/src/core/filter"
+)
+
+type Format string
+
+const (
+	// ip/cidr per line, "#" and ";" comments, trailing fields ignored
+	FormatNetset Format = "netset"
+	// "<ip> <domain>" per line, "#" comments
+	FormatHosts Format = "hosts"
+	// comma separated values, entry taken from Source.Column
+	FormatCSV Format = "csv"
+	// json object per line, entry taken from Source.Field
+	FormatJSONL Format = "jsonl"
+	// whole line is an entry, "#" comments
+	FormatPlain Format = "plain"
+)
+
+var formats = []Format{FormatNetset, FormatHosts, FormatCSV, FormatJSONL, FormatPlain}
+
+// filter types which can be populated by a feed
+var targets = []filter.FilterType{filter.FilterTypeIP, filter.FilterTypeASN, filter.FilterTypeDomain, filter.FilterTypeJA3}
+
+type Source struct {
+	URL    string `yaml:"url" json:"url" example:"https://www.spamhaus.org/drop/drop.txt"`
+	Format Format `yaml:"format" json:"format" example:"netset"`
+	// csv column (starting from 0)
+	Column int `yaml:"column" json:"column,omitempty"`
+	// jsonl field name
+	Field string `yaml:"field" json:"field,omitempty"`
+	// list is packed into a zip archive
+	Zip bool `yaml:"zip" json:"zip,omitempty"`
+	// url of a published checksum file (sha256)
+	Checksum string `yaml:"checksum" json:"checksum,omitempty"`
+	// url of a detached minisign signature
+	Signature string `yaml:"signature" json:"signature,omitempty"`
+	// minisign public key
+	PublicKey string `yaml:"public-key" json:"public_key,omitempty"`
+}
+
+type Feed struct {
+	Name    string            `yaml:"name" json:"name" example:"Spamhaus"`
+	Type    filter.FilterType `yaml:"type" json:"type" example:"ip"`
+	Sources []Source          `yaml:"sources" json:"sources"`
+	// overrides the default update interval
+	Interval time.Duration `yaml:"interval" json:"interval,omitempty" swaggertype:"integer" example:"3600000000000"`
+	// updates violating the limits are refused
+	Limits Limits `yaml:"limits" json:"limits"`
+}
+
+func (f Feed) Validate() error {
+	if len(f.Name) < 1 {
+		return errors.New("empty name")
+	}
+
+	if f.Name == filter.FilterNameWhiteList || f.Name == filter.FilterNameBlackList {
+		return fmt.Errorf("reserved name: %s", f.Name)
+	}
+
+	if !slices.Contains(targets, f.Type) {
+		return fmt.Errorf("unsupported type: %s", f.Type)
+	}
+
+	if f.Interval != 0 && f.Interval < time.Minute {
+		return fmt.Errorf("interval too short: %s", f.Interval)
+	}
+
+	if f.Limits.MaxEntries < 0 {
+		return errors.New("negative max entries")
+	}
+
+	if f.Limits.NoWhitelisted && f.Type != filter.FilterTypeIP {
+		return fmt.Errorf("no-whitelisted is not supported by %s feeds", f.Type)
+	}
+
+	if f.Limits.MinPrefixV4 < 0 || f.Limits.MinPrefixV4 > 32 {
+		return fmt.Errorf("invalid min ipv4 prefix: %d", f.Limits.MinPrefixV4)
+	}
+
+	if f.Limits.MinPrefixV6 < 0 || f.Limits.MinPrefixV6 > 128 {
+		return fmt.Errorf("invalid min ipv6 prefix: %d", f.Limits.MinPrefixV6)
+	}
+
+	if (f.Limits.MinPrefixV4 > 0 || f.Limits.MinPrefixV6 > 0) && f.Type != filter.FilterTypeIP {
+		return fmt.Errorf("min prefix is not supported by %s feeds", f.Type)
+	}
+
+	if len(f.Sources) < 1 {
+		return errors.New("no sources")
+	}
+
+	for _, src := range f.Sources {
+		if len(src.URL) < 1 {
+			return errors.New("empty url")
+		}
+
+		if !slices.Contains(formats, src.Format) {
+			return fmt.Errorf("%s: unsupported format: %s", src.URL, src.Format)
+		}
+
+		if src.Format == FormatJSONL && len(src.Field) < 1 {
+			return fmt.Errorf("%s: empty jsonl field", src.URL)
+		}
+
+		if src.Column < 0 {
+			return fmt.Errorf("%s: negative csv column", src.URL)
+		}
+
+		if len(src.Signature) > 0 {
+			if _, err := ParsePublicKey(src.PublicKey); err != nil {
+				return fmt.Errorf("%s: public key: %w", src.URL, err)
+			}
+		}
+	}
+
+	return nil
+}
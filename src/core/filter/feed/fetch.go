@@ -0,0 +1,371 @@
+package feed
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+type Stats struct {
+	// downloaded bytes
+	Bytes int
+	// raw lists size (downloaded or cached)
+	Size int
+	// all sources are unchanged since the last update
+	NotModified bool
+	// non-fatal errors (e.g. download failed, cached copy used)
+	Warn error
+}
+
+type FetchFunc func(ctx context.Context, sources []Source, fn func(entry string) error) (Stats, error)
+
+// Body is a downloaded or cached source
+type Body struct {
+	Data []byte
+	Meta Meta
+	// previously cached meta (empty if none)
+	Prev Meta
+	// served from cache, nothing to store
+	Cached bool
+	// server responded with "304 Not Modified"
+	NotModified bool
+	// non-fatal error
+	Warn error
+}
+
+type Fetcher struct {
+	client *http.Client
+	cache  *Cache
+
+	retries  uint
+	backoff  time.Duration
+	minRatio float64
+}
+
+func NewFetcher(client *http.Client, cache *Cache, retries uint, backoff time.Duration, minRatio float64) *Fetcher {
+	return &Fetcher{
+		client:   client,
+		cache:    cache,
+		retries:  retries,
+		backoff:  backoff,
+		minRatio: minRatio,
+	}
+}
+
+// Fetch downloads all sources and calls fn for every parsed entry,
+// a source falls back to its cached copy if the download fails.
+// Sources are cached only if fn accepts all the entries.
+func (f *Fetcher) Fetch(ctx context.Context, sources []Source, fn func(entry string) error) (Stats, error) {
+	var stats Stats
+
+	bodies := make([]*Body, 0, len(sources))
+	notModified := true
+	for _, src := range sources {
+		body, err := f.FetchBody(ctx, src)
+		if err != nil {
+			return stats, fmt.Errorf("%s: %w", src.URL, err)
+		}
+		if !body.Cached {
+			stats.Bytes += len(body.Data)
+		}
+		stats.Size += len(body.Data)
+		if body.Warn != nil {
+			stats.Warn = errors.Join(stats.Warn, fmt.Errorf("%s: %w", src.URL, body.Warn))
+		}
+		notModified = notModified && body.NotModified
+
+		bodies = append(bodies, body)
+	}
+
+	// nothing changed, keep the current list
+	if notModified {
+		stats.NotModified = true
+		return stats, nil
+	}
+
+	// parse all sources before accepting any of them
+	entries := make([][]string, len(sources))
+	for i, src := range sources {
+		if err := ParseBody(bodies[i].Data, src, func(entry string) error {
+			entries[i] = append(entries[i], entry)
+			return nil
+		}); err != nil {
+			return stats, fmt.Errorf("%s: %w", src.URL, err)
+		}
+
+		if err := f.Check(bodies[i], len(entries[i])); err != nil {
+			return stats, fmt.Errorf("%s: %w", src.URL, err)
+		}
+	}
+
+	for i, src := range sources {
+		for _, entry := range entries[i] {
+			if err := fn(entry); err != nil {
+				return stats, fmt.Errorf("%s: %w", src.URL, err)
+			}
+		}
+	}
+
+	for i, src := range sources {
+		if err := f.Store(src.URL, bodies[i], len(entries[i])); err != nil {
+			stats.Warn = errors.Join(stats.Warn, fmt.Errorf("%s: %w", src.URL, err))
+		}
+	}
+
+	return stats, nil
+}
+
+// Load calls fn for every entry of the cached sources
+func (f *Fetcher) Load(ctx context.Context, sources []Source, fn func(entry string) error) (Stats, error) {
+	var stats Stats
+	for _, src := range sources {
+		body, err := f.Cached(src.URL)
+		if err != nil {
+			return stats, fmt.Errorf("%s: %w", src.URL, err)
+		}
+		stats.Size += len(body)
+
+		if err := ParseBody(body, src, fn); err != nil {
+			return stats, fmt.Errorf("%s: %w", src.URL, err)
+		}
+	}
+
+	return stats, nil
+}
+
+// FetchBody downloads the source (conditionally, if cached) with fallback to its cached copy,
+// the result must be passed to Store to update the cache
+func (f *Fetcher) FetchBody(ctx context.Context, src Source) (*Body, error) {
+	cached, prev, cacheErr := f.cache.Get(src.URL)
+	if cacheErr != nil {
+		prev = Meta{}
+	}
+
+	data, meta, err := f.Download(ctx, src.URL, prev)
+	if err != nil {
+		if cacheErr != nil {
+			return nil, errors.Join(err, fmt.Errorf("cache get: %w", cacheErr))
+		}
+
+		return &Body{
+			Data:   cached,
+			Meta:   prev,
+			Prev:   prev,
+			Cached: true,
+			Warn:   fmt.Errorf("cached copy used: %w", err),
+		}, nil
+	}
+
+	if data == nil {
+		return &Body{
+			Data:        cached,
+			Meta:        prev,
+			Prev:        prev,
+			Cached:      true,
+			NotModified: true,
+		}, nil
+	}
+
+	// refuse unverified downloads
+	if err := f.Verify(ctx, src, data); err != nil {
+		return nil, fmt.Errorf("verify: %w", err)
+	}
+
+	return &Body{
+		Data: data,
+		Meta: meta,
+		Prev: prev,
+	}, nil
+}
+
+// Verify checks the body against the source checksum and signature (if any)
+func (f *Fetcher) Verify(ctx context.Context, src Source, body []byte) error {
+	if len(src.Checksum) > 0 {
+		sums, _, err := f.Download(ctx, src.Checksum, Meta{})
+		if err != nil {
+			return fmt.Errorf("checksum download: %w", err)
+		}
+
+		if err := VerifyChecksum(body, sums); err != nil {
+			return err
+		}
+	}
+
+	if len(src.Signature) > 0 {
+		key, err := ParsePublicKey(src.PublicKey)
+		if err != nil {
+			return fmt.Errorf("parse public key: %w", err)
+		}
+
+		sig, _, err := f.Download(ctx, src.Signature, Meta{})
+		if err != nil {
+			return fmt.Errorf("signature download: %w", err)
+		}
+
+		if err := key.Verify(body, sig); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Check rejects a body which is suspiciously smaller than the previous one
+func (f *Fetcher) Check(body *Body, entries int) error {
+	if body.Cached || body.Prev.Entries < 1 {
+		return nil
+	}
+
+	if float64(entries) < float64(body.Prev.Entries)*f.minRatio {
+		return fmt.Errorf("suspicious size: %d entries (was %d)", entries, body.Prev.Entries)
+	}
+
+	return nil
+}
+
+// Store caches a freshly downloaded body
+func (f *Fetcher) Store(url string, body *Body, entries int) error {
+	if body.Cached {
+		return nil
+	}
+
+	body.Meta.Entries = entries
+	if err := f.cache.Put(url, body.Data, body.Meta); err != nil {
+		return fmt.Errorf("cache put: %w", err)
+	}
+
+	return nil
+}
+
+// Download gets the url retrying with exponential backoff,
+// nil body is returned if the cached copy (prev) is still valid
+func (f *Fetcher) Download(ctx context.Context, url string, prev Meta) ([]byte, Meta, error) {
+	for attempt := uint(0); ; attempt++ {
+		body, meta, err := f.download(ctx, url, prev)
+		if err == nil || attempt >= f.retries || !retryable(err) {
+			return body, meta, err
+		}
+
+		select {
+		case <-time.After(f.backoff << attempt):
+		case <-ctx.Done():
+			return nil, meta, errors.Join(err, ctx.Err())
+		}
+	}
+}
+
+func (f *Fetcher) download(ctx context.Context, url string, prev Meta) ([]byte, Meta, error) {
+	meta := Meta{Fetched: time.Now()}
+
+	// create request
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, meta, fmt.Errorf("new request: %w", err)
+	}
+	if len(prev.ETag) > 0 {
+		req.Header.Set("If-None-Match", prev.ETag)
+	}
+	if len(prev.LastModified) > 0 {
+		req.Header.Set("If-Modified-Since", prev.LastModified)
+	}
+
+	// do request
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, meta, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && len(prev.SHA256) > 0 {
+		return nil, prev, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, meta, &StatusError{Code: resp.StatusCode, Status: resp.Status}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, meta, fmt.Errorf("read all: %w", err)
+	}
+	meta.ETag = resp.Header.Get("ETag")
+	meta.LastModified = resp.Header.Get("Last-Modified")
+
+	return body, meta, nil
+}
+
+func (f *Fetcher) Cached(url string) ([]byte, error) {
+	body, _, err := f.cache.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("cache get: %w", err)
+	}
+
+	return body, nil
+}
+
+type StatusError struct {
+	Code   int
+	Status string
+}
+
+func (e *StatusError) Error() string {
+	return "bad status: " + e.Status
+}
+
+// retryable reports whether the request may succeed later
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code == http.StatusTooManyRequests || statusErr.Code >= http.StatusInternalServerError
+	}
+
+	return true
+}
+
+// ParseBody parses the (optionally zipped) body using the source format
+func ParseBody(body []byte, src Source, fn func(entry string) error) error {
+	if !src.Zip {
+		return Parse(bytes.NewReader(body), src, fn)
+	}
+
+	return Unzip(body, func(r io.Reader) error {
+		return Parse(r, src, fn)
+	})
+}
+
+// Unzip calls fn for every file in the archive
+func Unzip(body []byte, fn func(r io.Reader) error) error {
+	reader := bytes.NewReader(body)
+	archive, err := zip.NewReader(reader, reader.Size())
+	if err != nil {
+		return fmt.Errorf("new zip reader: %w", err)
+	}
+
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		data, err := file.Open()
+		if err != nil {
+			return fmt.Errorf("%s: open zip file: %w", file.Name, err)
+		}
+
+		err = fn(data)
+		data.Close()
+		if err != nil {
+			return fmt.Errorf("%s: read zip file: %w", file.Name, err)
+		}
+	}
+
+	return nil
+}
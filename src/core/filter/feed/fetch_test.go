@@ -0,0 +1,195 @@
+package feed
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestFetcher(t *testing.T, handler http.HandlerFunc) (*Fetcher, string) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return NewFetcher(server.Client(), NewCache(t.TempDir()), 0, time.Millisecond, 0), server.URL
+}
+
+func collect(entries *[]string) func(entry string) error {
+	return func(entry string) error {
+		*entries = append(*entries, entry)
+		return nil
+	}
+}
+
+func TestFetcherFallback(t *testing.T) {
+	var down atomic.Bool
+	fetcher, url := newTestFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Write([]byte("1.1.1.1\n2.2.2.2\n"))
+	})
+	sources := []Source{{URL: url, Format: FormatNetset}}
+	want := []string{"1.1.1.1", "2.2.2.2"}
+
+	if _, err := fetcher.Load(context.Background(), sources, collect(new([]string))); err == nil {
+		t.Fatalf("loaded without cache")
+	}
+
+	var got []string
+	stats, err := fetcher.Fetch(context.Background(), sources, collect(&got))
+	if err != nil {
+		t.Fatalf("fetch: %s", err)
+	}
+	if !slices.Equal(got, want) || stats.Bytes != 16 || stats.Warn != nil {
+		t.Errorf("fetched %q (%+v), want %q", got, stats, want)
+	}
+
+	// NOTE: the cached copy is used if the download fails
+	down.Store(true)
+	got = nil
+	stats, err = fetcher.Fetch(context.Background(), sources, collect(&got))
+	if err != nil {
+		t.Fatalf("fetch cached: %s", err)
+	}
+	if !slices.Equal(got, want) || stats.Bytes != 0 || stats.Warn == nil {
+		t.Errorf("fetched cached %q (%+v), want %q with a warning", got, stats, want)
+	}
+
+	got = nil
+	if _, err := fetcher.Load(context.Background(), sources, collect(&got)); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("loaded %q, want %q", got, want)
+	}
+
+	// nothing cached for the other source
+	sources = append(sources, Source{URL: url + "/other", Format: FormatNetset})
+	if _, err := fetcher.Fetch(context.Background(), sources, collect(new([]string))); err == nil {
+		t.Errorf("fetched the failed source without cache")
+	}
+}
+
+func TestFetcherNotModified(t *testing.T) {
+	var requests atomic.Int32
+	fetcher, url := newTestFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` && r.Header.Get("If-Modified-Since") == "Mon, 19 Oct 2026 00:00:00 GMT" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 19 Oct 2026 00:00:00 GMT")
+		w.Write([]byte("1.1.1.1\n"))
+	})
+	sources := []Source{{URL: url, Format: FormatNetset}}
+
+	var got []string
+	stats, err := fetcher.Fetch(context.Background(), sources, collect(&got))
+	if err != nil {
+		t.Fatalf("fetch: %s", err)
+	}
+	if len(got) != 1 || stats.NotModified {
+		t.Errorf("fetched %q (%+v), want modified", got, stats)
+	}
+
+	// NOTE: the entries aren't passed if nothing changed
+	got = nil
+	stats, err = fetcher.Fetch(context.Background(), sources, collect(&got))
+	if err != nil {
+		t.Fatalf("fetch not modified: %s", err)
+	}
+	if len(got) != 0 || !stats.NotModified || stats.Bytes != 0 || stats.Size != 8 {
+		t.Errorf("fetched %q (%+v), want not modified", got, stats)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("requested %d times, want 2", requests.Load())
+	}
+}
+
+func TestFetcherRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		requests int32
+	}{
+		{name: "server error", status: http.StatusBadGateway, requests: 3},
+		{name: "too many requests", status: http.StatusTooManyRequests, requests: 3},
+		{name: "not found", status: http.StatusNotFound, requests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			fetcher, url := newTestFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.WriteHeader(tt.status)
+			})
+			fetcher.retries = 2
+
+			if _, _, err := fetcher.Download(context.Background(), url, Meta{}); err == nil {
+				t.Errorf("downloaded with status %d", tt.status)
+			}
+			if requests.Load() != tt.requests {
+				t.Errorf("requested %d times, want %d", requests.Load(), tt.requests)
+			}
+		})
+	}
+
+	t.Run("recovered", func(t *testing.T) {
+		var requests atomic.Int32
+		fetcher, url := newTestFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+			if requests.Add(1) < 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			w.Write([]byte("1.1.1.1\n"))
+		})
+		fetcher.retries = 2
+
+		body, _, err := fetcher.Download(context.Background(), url, Meta{})
+		if err != nil || string(body) != "1.1.1.1\n" {
+			t.Errorf("downloaded %q: %v", body, err)
+		}
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	var userAgent atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent.Store(r.Header.Get("User-Agent"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{UserAgent: "meds/test", Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("new client: %s", err)
+	}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("get: %s", err)
+	}
+	resp.Body.Close()
+
+	if userAgent.Load() != "meds/test" {
+		t.Errorf("user agent %q, want meds/test", userAgent.Load())
+	}
+
+	if _, err := NewClient(ClientConfig{Proxy: "://invalid"}); err == nil {
+		t.Errorf("created client with invalid proxy")
+	}
+	if _, err := NewClient(ClientConfig{CABundle: "/nonexistent/ca.pem"}); err == nil {
+		t.Errorf("created client with missing ca bundle")
+	}
+}
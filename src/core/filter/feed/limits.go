@@ -0,0 +1,75 @@
+package feed
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+var ErrLimitViolated = errors.New("sanity limit violated")
+
+// default min prefixes of ip feeds
+const (
+	DefaultMinPrefixV4 = 8
+	DefaultMinPrefixV6 = 16
+)
+
+// special-purpose ranges, lists may block them regardless of the min prefix (e.g. FireHOL bogons)
+var reserved = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("169.254.0.0/16"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("224.0.0.0/3"),
+	netip.MustParsePrefix("2001:db8::/32"),
+	netip.MustParsePrefix("fc00::/7"),
+	netip.MustParsePrefix("fe80::/10"),
+	netip.MustParsePrefix("ff00::/8"),
+}
+
+type Limits struct {
+	// max entries count (0 - unlimited)
+	MaxEntries int `yaml:"max-entries" json:"max_entries,omitempty" example:"100000"`
+	// refuse lists intersecting the whitelist (ip feeds only)
+	NoWhitelisted bool `yaml:"no-whitelisted" json:"no_whitelisted,omitempty"`
+	// refuse lists with wider subnets outside the special-purpose ranges (ip feeds only, 0 - /8 and /16)
+	MinPrefixV4 int `yaml:"min-prefix-v4" json:"min_prefix_v4,omitempty" example:"8"`
+	MinPrefixV6 int `yaml:"min-prefix-v6" json:"min_prefix_v6,omitempty" example:"16"`
+}
+
+func (l Limits) CheckSize(size int) error {
+	if l.MaxEntries > 0 && size > l.MaxEntries {
+		return fmt.Errorf("%w: more than %d entries", ErrLimitViolated, l.MaxEntries)
+	}
+
+	return nil
+}
+
+// CheckSubnet checks the subnet is not too wide
+func (l Limits) CheckSubnet(subnet netip.Prefix) error {
+	minBits := cmp.Or(l.MinPrefixV4, DefaultMinPrefixV4)
+	if subnet.Addr().Is6() {
+		minBits = cmp.Or(l.MinPrefixV6, DefaultMinPrefixV6)
+	}
+
+	if subnet.Bits() >= minBits {
+		return nil
+	}
+
+	for _, r := range reserved {
+		if r.Bits() <= subnet.Bits() && r.Contains(subnet.Addr()) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s is wider than /%d", ErrLimitViolated, subnet, minBits)
+}
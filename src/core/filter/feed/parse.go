@@ -0,0 +1,129 @@
+package feed
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cnaize/meds/lib/util"
+)
+
+// Parse scans the list line by line and calls fn for every entry,
+// scanning stops on the first fn error
+func Parse(r io.Reader, src Source, fn func(entry string) error) error {
+	var parse func(line string) (string, bool)
+	switch src.Format {
+	case FormatNetset:
+		parse = parseNetset
+	case FormatHosts:
+		parse = parseHosts
+	case FormatCSV:
+		parse = func(line string) (string, bool) {
+			return parseCSV(line, src.Column)
+		}
+	case FormatJSONL:
+		parse = func(line string) (string, bool) {
+			return parseJSONL(line, src.Field)
+		}
+	case FormatPlain:
+		parse = parsePlain
+	default:
+		return fmt.Errorf("unsupported format: %s", src.Format)
+	}
+
+	scanner := bufio.NewScanner(r)
+	// some lists have very long lines
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) < 1 {
+			continue
+		}
+
+		entry, ok := parse(line)
+		if !ok {
+			continue
+		}
+
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func parseNetset(line string) (string, bool) {
+	if strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+		return "", false
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) < 1 {
+		return "", false
+	}
+
+	return strings.TrimSuffix(fields[0], ";"), true
+}
+
+func parseHosts(line string) (string, bool) {
+	if strings.HasPrefix(line, "#") {
+		return "", false
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) < 1 {
+		return "", false
+	}
+
+	if len(fields) < 2 {
+		return fields[0], true
+	}
+
+	return fields[1], true
+}
+
+func parseCSV(line string, column int) (string, bool) {
+	if strings.HasPrefix(line, "#") {
+		return "", false
+	}
+
+	fields := strings.Split(line, ",")
+	if len(fields) <= column {
+		return "", false
+	}
+
+	entry := strings.Trim(strings.TrimSpace(fields[column]), `"`)
+	if len(entry) < 1 {
+		return "", false
+	}
+
+	return entry, true
+}
+
+func parseJSONL(line string, field string) (string, bool) {
+	var obj map[string]any
+	if err := json.Unmarshal(util.StringToBytes(line), &obj); err != nil {
+		return "", false
+	}
+
+	switch value := obj[field].(type) {
+	case string:
+		return value, len(value) > 0
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), true
+	}
+
+	return "", false
+}
+
+func parsePlain(line string) (string, bool) {
+	if strings.HasPrefix(line, "#") {
+		return "", false
+	}
+
+	return line, true
+}
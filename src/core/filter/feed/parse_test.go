@@ -0,0 +1,125 @@
+package feed
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cnaize/meds/src/core/filter"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		src  Source
+		body string
+		want []string
+	}{
+		{
+			name: "netset",
+			src:  Source{Format: FormatNetset},
+			body: "# comment\n; comment\n1.2.3.0/24 ; SBL123\n\n  5.6.7.8\t# host\n9.9.9.9;\n",
+			want: []string{"1.2.3.0/24", "5.6.7.8", "9.9.9.9"},
+		},
+		{
+			name: "hosts",
+			src:  Source{Format: FormatHosts},
+			body: "# comment\n0.0.0.0 ads.example.com\n127.0.0.1\ttracker.example.com # inline\nbare.example.com\n",
+			want: []string{"ads.example.com", "tracker.example.com", "bare.example.com"},
+		},
+		{
+			name: "csv",
+			src:  Source{Format: FormatCSV, Column: 1},
+			body: "# id,ip\n1,\"1.2.3.4\"\n2, 5.6.7.8 ,extra\n3\n4,\n",
+			want: []string{"1.2.3.4", "5.6.7.8"},
+		},
+		{
+			name: "jsonl",
+			src:  Source{Format: FormatJSONL, Field: "asn"},
+			body: "{\"asn\": 13335}\n{\"asn\": \"AS15169\"}\n{\"asn\": \"\"}\n{\"other\": 1}\nnot json\n{\"asn\": true}\n",
+			want: []string{"13335", "AS15169"},
+		},
+		{
+			name: "plain",
+			src:  Source{Format: FormatPlain},
+			body: "# comment\n  e7d705a3286e19ea42f587b344ee6865  \n\nexample.com\n",
+			want: []string{"e7d705a3286e19ea42f587b344ee6865", "example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			if err := Parse(strings.NewReader(tt.body), tt.src, func(entry string) error {
+				got = append(got, entry)
+				return nil
+			}); err != nil {
+				t.Fatalf("parse: %s", err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("parsed %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFailed(t *testing.T) {
+	if err := Parse(strings.NewReader("1.2.3.4\n"), Source{Format: "xml"}, func(entry string) error {
+		return nil
+	}); err == nil {
+		t.Errorf("parsed unsupported format")
+	}
+
+	// NOTE: scanning stops on the first error
+	errStop := errors.New("stop")
+	var got []string
+	err := Parse(strings.NewReader("1.1.1.1\n2.2.2.2\n3.3.3.3\n"), Source{Format: FormatNetset}, func(entry string) error {
+		got = append(got, entry)
+		if len(got) > 1 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) || len(got) != 2 {
+		t.Errorf("parsed %q: %v, want stopped at the second entry", got, err)
+	}
+}
+
+func TestFeedValidate(t *testing.T) {
+	valid := Feed{
+		Name:    "Spamhaus",
+		Type:    filter.FilterTypeIP,
+		Sources: []Source{{URL: "https://www.spamhaus.org/drop/drop.txt", Format: FormatNetset}},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("validate: %s", err)
+	}
+
+	tests := []struct {
+		name   string
+		update func(f *Feed)
+	}{
+		{name: "empty name", update: func(f *Feed) { f.Name = "" }},
+		{name: "reserved name", update: func(f *Feed) { f.Name = filter.FilterNameBlackList }},
+		{name: "unsupported type", update: func(f *Feed) { f.Type = filter.FilterTypeGeo }},
+		{name: "no sources", update: func(f *Feed) { f.Sources = nil }},
+		{name: "empty url", update: func(f *Feed) { f.Sources[0].URL = "" }},
+		{name: "unsupported format", update: func(f *Feed) { f.Sources[0].Format = "xml" }},
+		{name: "empty jsonl field", update: func(f *Feed) { f.Sources[0].Format = FormatJSONL }},
+		{name: "negative csv column", update: func(f *Feed) { f.Sources[0].Format, f.Sources[0].Column = FormatCSV, -1 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := valid
+			f.Sources = slices.Clone(valid.Sources)
+			tt.update(&f)
+
+			if err := f.Validate(); err == nil {
+				t.Errorf("validated %+v", f)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package feed
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// minisign algorithms
+var (
+	algEd25519       = []byte("Ed")
+	algEd25519Hashed = []byte("ED")
+)
+
+type PublicKey struct {
+	id  []byte
+	key ed25519.PublicKey
+}
+
+// ParsePublicKey parses a minisign public key (base64 line of the ".pub" file)
+func ParsePublicKey(str string) (*PublicKey, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(str))
+	if err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	if len(data) != 2+8+ed25519.PublicKeySize || !bytes.Equal(data[:2], algEd25519) {
+		return nil, errors.New("invalid public key")
+	}
+
+	return &PublicKey{
+		id:  data[2:10],
+		key: ed25519.PublicKey(data[10:]),
+	}, nil
+}
+
+// Verify checks a minisign signature (".minisig" file) of the body
+func (k *PublicKey) Verify(body, sig []byte) error {
+	lines := strings.Split(strings.TrimSpace(string(sig)), "\n")
+	if len(lines) < 4 {
+		return errors.New("invalid signature file")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+	if len(data) != 2+8+ed25519.SignatureSize {
+		return errors.New("invalid signature")
+	}
+	if !bytes.Equal(data[2:10], k.id) {
+		return errors.New("key id mismatch")
+	}
+
+	// prehashed signatures are made over the blake2b digest
+	msg := body
+	switch {
+	case bytes.Equal(data[:2], algEd25519):
+	case bytes.Equal(data[:2], algEd25519Hashed):
+		digest := blake2b.Sum512(body)
+		msg = digest[:]
+	default:
+		return errors.New("unsupported signature algorithm")
+	}
+
+	if !ed25519.Verify(k.key, msg, data[10:]) {
+		return errors.New("invalid signature")
+	}
+
+	// global signature covers the trusted comment
+	comment, ok := strings.CutPrefix(strings.TrimSpace(lines[2]), "trusted comment: ")
+	if !ok {
+		return errors.New("invalid trusted comment")
+	}
+	global, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil {
+		return fmt.Errorf("decode global signature: %w", err)
+	}
+	if !ed25519.Verify(k.key, append(slices.Clone(data[10:]), comment...), global) {
+		return errors.New("invalid global signature")
+	}
+
+	return nil
+}
+
+// VerifyChecksum looks up the body sha256 in a checksum file ("sha256sum" or bsd style)
+func VerifyChecksum(body, sums []byte) error {
+	sum := sha256.Sum256(body)
+	expected := hex.EncodeToString(sum[:])
+
+	for _, field := range strings.Fields(string(sums)) {
+		if strings.EqualFold(field, expected) {
+			return nil
+		}
+	}
+
+	return errors.New("checksum mismatch")
+}
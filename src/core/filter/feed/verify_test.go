@@ -0,0 +1,268 @@
+package feed
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+type testKey struct {
+	id   []byte
+	priv ed25519.PrivateKey
+	pub  string
+}
+
+func newTestKey(t *testing.T) testKey {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %s", err)
+	}
+
+	id := make([]byte, 8)
+	rand.Read(id)
+
+	return testKey{
+		id:   id,
+		priv: priv,
+		pub:  base64.StdEncoding.EncodeToString(slices.Concat(algEd25519, id, pub)),
+	}
+}
+
+// sign makes a minisign signature file of the body
+func (k testKey) sign(body []byte, hashed bool) []byte {
+	alg, msg := algEd25519, body
+	if hashed {
+		digest := blake2b.Sum512(body)
+		alg, msg = algEd25519Hashed, digest[:]
+	}
+
+	sig := ed25519.Sign(k.priv, msg)
+	comment := "timestamp:1760832000\tfile:list.txt"
+	global := ed25519.Sign(k.priv, slices.Concat(sig, []byte(comment)))
+
+	return []byte(strings.Join([]string{
+		"untrusted comment: signature from minisign secret key",
+		base64.StdEncoding.EncodeToString(slices.Concat(alg, k.id, sig)),
+		"trusted comment: " + comment,
+		base64.StdEncoding.EncodeToString(global),
+	}, "\n") + "\n")
+}
+
+func TestPublicKeyVerify(t *testing.T) {
+	body := []byte("1.2.3.4\n")
+	key := newTestKey(t)
+
+	pub, err := ParsePublicKey(key.pub + "\n")
+	if err != nil {
+		t.Fatalf("parse public key: %s", err)
+	}
+
+	for _, hashed := range []bool{false, true} {
+		if err := pub.Verify(body, key.sign(body, hashed)); err != nil {
+			t.Errorf("verify (hashed %t): %s", hashed, err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		body []byte
+		sig  []byte
+	}{
+		{name: "modified body", body: []byte("5.6.7.8\n"), sig: key.sign(body, true)},
+		{name: "other key", body: body, sig: newTestKey(t).sign(body, true)},
+		{name: "truncated", body: body, sig: key.sign(body, true)[:40]},
+		{name: "empty", body: body},
+		{
+			name: "modified trusted comment",
+			body: body,
+			sig:  []byte(strings.Replace(string(key.sign(body, true)), "file:list.txt", "file:other.txt", 1)),
+		},
+		{
+			name: "other key same id",
+			body: body,
+			sig: func() []byte {
+				other := newTestKey(t)
+				other.id = key.id
+				return other.sign(body, false)
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := pub.Verify(tt.body, tt.sig); err == nil {
+				t.Errorf("verified")
+			}
+		})
+	}
+}
+
+func TestParsePublicKeyInvalid(t *testing.T) {
+	key := newTestKey(t)
+	data, _ := base64.StdEncoding.DecodeString(key.pub)
+
+	for _, str := range []string{
+		"",
+		"not base64!",
+		base64.StdEncoding.EncodeToString(data[:20]),
+		base64.StdEncoding.EncodeToString(slices.Concat([]byte("XX"), data[2:])),
+	} {
+		if _, err := ParsePublicKey(str); err == nil {
+			t.Errorf("parsed %q", str)
+		}
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	body := []byte("1.2.3.4\n")
+	sum := sha256.Sum256(body)
+	expected := hex.EncodeToString(sum[:])
+
+	for _, sums := range []string{
+		expected + "  list.txt\n",
+		"0000  other.txt\n" + strings.ToUpper(expected) + " *list.txt\n",
+		"SHA256 (list.txt) = " + expected + "\n",
+	} {
+		if err := VerifyChecksum(body, []byte(sums)); err != nil {
+			t.Errorf("verify %q: %s", sums, err)
+		}
+	}
+
+	if err := VerifyChecksum([]byte("5.6.7.8\n"), []byte(expected+"  list.txt\n")); err == nil {
+		t.Errorf("verified modified body")
+	}
+}
+
+func TestFetcherVerify(t *testing.T) {
+	body := []byte("1.1.1.1\n")
+	key := newTestKey(t)
+	sum := sha256.Sum256(body)
+
+	fetcher, url := newTestFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/list.txt":
+			w.Write(body)
+		case "/list.txt.sha256":
+			w.Write([]byte(hex.EncodeToString(sum[:]) + "  list.txt\n"))
+		case "/list.txt.minisig":
+			w.Write(key.sign(body, true))
+		case "/other.txt.minisig":
+			w.Write(key.sign([]byte("2.2.2.2\n"), true))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	valid := Source{URL: url + "/list.txt", Format: FormatNetset, Checksum: url + "/list.txt.sha256", Signature: url + "/list.txt.minisig", PublicKey: key.pub}
+	var got []string
+	if _, err := fetcher.Fetch(context.Background(), []Source{valid}, collect(&got)); err != nil || len(got) != 1 {
+		t.Fatalf("fetched %q: %v", got, err)
+	}
+
+	tests := []struct {
+		name   string
+		update func(src *Source)
+	}{
+		{name: "checksum missing", update: func(src *Source) { src.Checksum = url + "/missing.sha256" }},
+		{name: "checksum mismatch", update: func(src *Source) { src.Checksum = url + "/list.txt.minisig" }},
+		{name: "signature mismatch", update: func(src *Source) { src.Signature = url + "/other.txt.minisig" }},
+		{name: "other key", update: func(src *Source) { src.PublicKey = newTestKey(t).pub }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := valid
+			src.URL = url + "/list.txt?" + tt.name
+			tt.update(&src)
+
+			// NOTE: unverified downloads are refused even with no cached copy
+			if _, err := fetcher.Fetch(context.Background(), []Source{src}, collect(new([]string))); err == nil {
+				t.Errorf("fetched unverified source")
+			}
+			if _, err := fetcher.Cached(src.URL); err == nil {
+				t.Errorf("cached unverified source")
+			}
+		})
+	}
+}
+
+func TestFetcherCheck(t *testing.T) {
+	var entries string
+	fetcher, url := newTestFetcher(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(entries))
+	})
+	fetcher.minRatio = 0.5
+	sources := []Source{{URL: url, Format: FormatNetset}}
+
+	entries = "1.1.1.1\n2.2.2.2\n3.3.3.3\n4.4.4.4\n"
+	if _, err := fetcher.Fetch(context.Background(), sources, collect(new([]string))); err != nil {
+		t.Fatalf("fetch: %s", err)
+	}
+
+	// NOTE: the refused list isn't cached, the check is against the last accepted one
+	entries = "1.1.1.1\n"
+	if _, err := fetcher.Fetch(context.Background(), sources, collect(new([]string))); err == nil {
+		t.Errorf("fetched suspiciously small list")
+	}
+
+	entries = "1.1.1.1\n2.2.2.2\n"
+	if _, err := fetcher.Fetch(context.Background(), sources, collect(new([]string))); err != nil {
+		t.Errorf("fetch half: %s", err)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	limits := Limits{MaxEntries: 2}
+	if err := limits.CheckSize(2); err != nil {
+		t.Errorf("check size: %s", err)
+	}
+	if err := limits.CheckSize(3); !errors.Is(err, ErrLimitViolated) {
+		t.Errorf("check size: %v, want violated", err)
+	}
+	if err := (Limits{}).CheckSize(1 << 20); err != nil {
+		t.Errorf("check unlimited size: %s", err)
+	}
+
+	tests := []struct {
+		limits Limits
+		subnet string
+		ok     bool
+	}{
+		{subnet: "1.0.0.0/8", ok: true},
+		{subnet: "0.0.0.0/0"},
+		{subnet: "64.0.0.0/2"},
+		{subnet: "224.0.0.0/3", ok: true},
+		{subnet: "240.0.0.0/4", ok: true},
+		{subnet: "192.0.0.0/3"},
+		{subnet: "2001::/16", ok: true},
+		{subnet: "2000::/3"},
+		{subnet: "fc00::/7", ok: true},
+		{limits: Limits{MinPrefixV4: 24}, subnet: "1.2.0.0/16"},
+		{limits: Limits{MinPrefixV4: 24}, subnet: "192.168.0.0/16", ok: true},
+		{limits: Limits{MinPrefixV6: 48}, subnet: "2001:db8::/32", ok: true},
+		{limits: Limits{MinPrefixV6: 48}, subnet: "2a00::/32"},
+	}
+
+	for _, tt := range tests {
+		err := tt.limits.CheckSubnet(netip.MustParsePrefix(tt.subnet))
+		if tt.ok != (err == nil) {
+			t.Errorf("check %s (%+v): %v", tt.subnet, tt.limits, err)
+		}
+		if err != nil && !errors.Is(err, ErrLimitViolated) {
+			t.Errorf("check %s: %v, want violated", tt.subnet, err)
+		}
+	}
+}
@@ -2,26 +2,45 @@ package geo
 
 import (
 	"context"
+	"strings"
+	"sync/atomic"
 
 	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/filter/feed"
 	"github.com/cnaize/meds/src/core/logger"
 	"github.com/cnaize/meds/src/types"
 )
 
 type Base struct {
-	urls   []string
-	logger *logger.Logger
+	urls    []string
+	fetcher *feed.Fetcher
+	logger  *logger.Logger
 
-	asnlist   *types.ASNList
+	asnlist *types.ASNList
+	// NOTE: the lists and the policy are global, shared by all geo filters
+	whitelist *types.CountryList
 	blacklist *types.CountryList
+	// drop ips with unknown country
+	denyUnknown *atomic.Bool
 }
 
-func NewBase(urls []string, logger *logger.Logger, asnlist *types.ASNList, blacklist *types.CountryList) *Base {
+func NewBase(
+	urls []string,
+	fetcher *feed.Fetcher,
+	logger *logger.Logger,
+	asnlist *types.ASNList,
+	whitelist *types.CountryList,
+	blacklist *types.CountryList,
+	denyUnknown *atomic.Bool,
+) *Base {
 	return &Base{
-		urls:      urls,
-		logger:    logger,
-		asnlist:   asnlist,
-		blacklist: blacklist,
+		urls:        urls,
+		fetcher:     fetcher,
+		logger:      logger,
+		asnlist:     asnlist,
+		whitelist:   whitelist,
+		blacklist:   blacklist,
+		denyUnknown: denyUnknown,
 	}
 }
 
@@ -35,9 +54,32 @@ func (f *Base) Load(ctx context.Context) error {
 
 func (f *Base) Check(packet *types.Packet) bool {
 	asn, ok := packet.GetASN(f.asnlist)
-	if !ok {
-		return true
+	if !ok || len(asn.Country) < 1 {
+		// NOTE: everything is unknown till the list is loaded
+		return !f.denyUnknown.Load() || f.asnlist.Load().Size() < 1
 	}
 
-	return !f.blacklist.Lookup(asn.Country)
+	// allow only whitelisted countries (if any)
+	if f.whitelist.Len() > 0 && !f.whitelist.Hit(asn.Country) {
+		return false
+	}
+
+	return !f.blacklist.Hit(asn.Country)
+}
+
+func (f *Base) Explain(packet *types.Packet) (bool, string) {
+	asn, ok := packet.GetASN(f.asnlist)
+	if !ok || len(asn.Country) < 1 {
+		return !f.denyUnknown.Load() || f.asnlist.Load().Size() < 1, ""
+	}
+
+	if f.whitelist.Len() > 0 && !f.whitelist.Lookup(asn.Country) {
+		return false, ""
+	}
+
+	if f.blacklist.Lookup(asn.Country) {
+		return false, strings.ToLower(asn.Country)
+	}
+
+	return true, ""
 }
@@ -1,33 +1,47 @@
 package geo
 
 import (
-	"archive/zip"
 	"bufio"
-	"bytes"
 	"context"
 	"fmt"
 	"io"
-	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/gaissmai/bart"
 
 	"github.com/cnaize/meds/lib/util/get"
 	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/filter/feed"
 	"github.com/cnaize/meds/src/core/logger"
 	"github.com/cnaize/meds/src/types"
 )
 
-var _ filter.Filter = (*IPLocate)(nil)
+var (
+	_ filter.Filter    = (*IPLocate)(nil)
+	_ filter.Reporter  = (*IPLocate)(nil)
+	_ filter.Explainer = (*IPLocate)(nil)
+)
 
 type IPLocate struct {
 	*Base
+
+	bytes atomic.Int64
+	size  atomic.Int64
 }
 
-func NewIPLocate(urls []string, logger *logger.Logger, asnlist *types.ASNList, blacklist *types.CountryList) *IPLocate {
+func NewIPLocate(
+	urls []string,
+	fetcher *feed.Fetcher,
+	logger *logger.Logger,
+	asnlist *types.ASNList,
+	whitelist *types.CountryList,
+	blacklist *types.CountryList,
+	denyUnknown *atomic.Bool,
+) *IPLocate {
 	return &IPLocate{
-		Base: NewBase(urls, logger, asnlist, blacklist),
+		Base: NewBase(urls, fetcher, logger, asnlist, whitelist, blacklist, denyUnknown),
 	}
 }
 
@@ -35,52 +49,94 @@ func (f *IPLocate) Name() string {
 	return "IPLocate"
 }
 
+func (f *IPLocate) Stats() filter.Stats {
+	return filter.Stats{
+		Entries: f.asnlist.Load().Size(),
+		Bytes:   int(f.bytes.Load()),
+		Size:    int(f.size.Load()),
+	}
+}
+
 func (f *IPLocate) Load(ctx context.Context) error {
 	defer f.logger.Raw().Info().Str("name", f.Name()).Str("type", string(f.Type())).Msg("Filter loaded")
 
-	return f.Base.Load(ctx)
-}
+	if err := f.Base.Load(ctx); err != nil {
+		return err
+	}
 
-func (f *IPLocate) Update(ctx context.Context) error {
-	asnlist := new(bart.Table[types.ASN])
-	for _, url := range f.urls {
-		// create request
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	// populate from cache
+	if err := f.update(ctx, func(ctx context.Context, src feed.Source) (*feed.Body, error) {
+		data, err := f.fetcher.Cached(src.URL)
 		if err != nil {
-			return fmt.Errorf("%s: new request: %w", url, err)
+			return nil, err
 		}
 
-		// do request
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return fmt.Errorf("%s: do request: %w", url, err)
-		}
-		defer resp.Body.Close()
+		return &feed.Body{Data: data, Cached: true}, nil
+	}); err != nil {
+		f.logger.Raw().
+			Debug().
+			Err(err).
+			Str("name", f.Name()).
+			Str("type", string(f.Type())).
+			Msg("cache load failed")
+	}
 
-		// unzip body
-		body, err := io.ReadAll(resp.Body)
+	return nil
+}
+
+func (f *IPLocate) Update(ctx context.Context) error {
+	return f.update(ctx, f.fetcher.FetchBody)
+}
+
+func (f *IPLocate) update(ctx context.Context, fetch func(ctx context.Context, src feed.Source) (*feed.Body, error)) error {
+	bodies := make([]*feed.Body, 0, len(f.urls))
+	notModified := true
+	bytes, size := 0, 0
+	for _, url := range f.urls {
+		body, err := fetch(ctx, feed.Source{URL: url})
 		if err != nil {
-			return fmt.Errorf("read all: %w", err)
+			return fmt.Errorf("%s: %w", url, err)
 		}
 
-		reader := bytes.NewReader(body)
-		archive, err := zip.NewReader(reader, reader.Size())
-		if err != nil {
-			return fmt.Errorf("new zip reader: %w", err)
+		if body.Warn != nil {
+			f.logger.Raw().
+				Warn().
+				Err(body.Warn).
+				Str("name", f.Name()).
+				Str("type", string(f.Type())).
+				Str("url", url).
+				Msg("Filter update degraded")
+		}
+		notModified = notModified && body.NotModified
+		if !body.Cached {
+			bytes += len(body.Data)
 		}
+		size += len(body.Data)
 
-		for _, file := range archive.File {
-			if file.FileInfo().IsDir() {
-				continue
-			}
+		bodies = append(bodies, body)
+	}
 
-			data, err := file.Open()
-			if err != nil {
-				return fmt.Errorf("%s: open zip file: %w", file.Name, err)
-			}
+	f.bytes.Store(int64(bytes))
+	f.size.Store(int64(size))
+
+	// nothing changed, keep the current list
+	if notModified {
+		f.logger.Raw().
+			Debug().
+			Str("name", f.Name()).
+			Str("type", string(f.Type())).
+			Msg("Filter not modified")
+		return nil
+	}
 
+	asnlist := new(bart.Table[types.ASN])
+	names := make(map[uint32]string)
+	entries := make([]int, len(f.urls))
+	for i, url := range f.urls {
+		// unzip body
+		if err := feed.Unzip(bodies[i].Data, func(r io.Reader) error {
 			// scan list
-			scanner := bufio.NewScanner(data)
+			scanner := bufio.NewScanner(r)
 			for scanner.Scan() {
 				line := strings.TrimSpace(scanner.Text())
 				if len(line) < 1 {
@@ -106,7 +162,32 @@ func (f *IPLocate) Update(ctx context.Context) error {
 					ASN:     uint32(asn),
 					Country: strings.ToLower(fields[2]),
 				})
+				// "network,asn,country_code,name,..."
+				if len(fields) > 3 && len(names[uint32(asn)]) < 1 {
+					names[uint32(asn)] = strings.Trim(fields[3], `"`)
+				}
+				entries[i]++
 			}
+
+			return scanner.Err()
+		}); err != nil {
+			return fmt.Errorf("%s: %w", url, err)
+		}
+
+		if err := f.fetcher.Check(bodies[i], entries[i]); err != nil {
+			return fmt.Errorf("%s: %w", url, err)
+		}
+	}
+
+	for i, url := range f.urls {
+		if err := f.fetcher.Store(url, bodies[i], entries[i]); err != nil {
+			f.logger.Raw().
+				Warn().
+				Err(err).
+				Str("name", f.Name()).
+				Str("type", string(f.Type())).
+				Str("url", url).
+				Msg("Filter update degraded")
 		}
 	}
 
@@ -117,6 +198,7 @@ func (f *IPLocate) Update(ctx context.Context) error {
 		Int("size", asnlist.Size()).
 		Msg("Filter updated")
 	f.asnlist.Store(asnlist)
+	f.asnlist.StoreNames(names)
 
 	return nil
 }
@@ -0,0 +1,224 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gaissmai/bart"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/filter/geo/mmdb"
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/types"
+)
+
+// files are checked for changes every minute
+const mmdbWatchInterval = time.Minute
+
+var (
+	_ filter.Filter    = (*MMDB)(nil)
+	_ filter.Reporter  = (*MMDB)(nil)
+	_ filter.Scheduler = (*MMDB)(nil)
+	_ filter.Explainer = (*MMDB)(nil)
+)
+
+// MMDB populates the ASNList from local MaxMind DB files
+// (GeoLite2/GeoIP2 Country and ASN, DB-IP, etc.), works offline
+type MMDB struct {
+	*Base
+
+	paths []string
+
+	mu     sync.Mutex
+	stamps []string
+	bytes  atomic.Int64
+	size   atomic.Int64
+}
+
+func NewMMDB(
+	paths []string,
+	logger *logger.Logger,
+	asnlist *types.ASNList,
+	whitelist *types.CountryList,
+	blacklist *types.CountryList,
+	denyUnknown *atomic.Bool,
+) *MMDB {
+	return &MMDB{
+		Base:  NewBase(nil, nil, logger, asnlist, whitelist, blacklist, denyUnknown),
+		paths: paths,
+	}
+}
+
+func (f *MMDB) Name() string {
+	return "MMDB"
+}
+
+// Interval makes the files watched for changes
+func (f *MMDB) Interval() time.Duration {
+	return mmdbWatchInterval
+}
+
+func (f *MMDB) Stats() filter.Stats {
+	return filter.Stats{
+		Entries: f.asnlist.Load().Size(),
+		Bytes:   int(f.bytes.Load()),
+		Size:    int(f.size.Load()),
+	}
+}
+
+func (f *MMDB) Load(ctx context.Context) error {
+	defer f.logger.Raw().Info().Str("name", f.Name()).Str("type", string(f.Type())).Msg("Filter loaded")
+
+	if err := f.Base.Load(ctx); err != nil {
+		return err
+	}
+
+	// NOTE: the error is reported by the following update
+	if err := f.Update(ctx); err != nil {
+		f.logger.Raw().
+			Warn().
+			Err(err).
+			Str("name", f.Name()).
+			Str("type", string(f.Type())).
+			Msg("files load failed")
+	}
+
+	return nil
+}
+
+func (f *MMDB) Update(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// check files for changes
+	stamps := make([]string, len(f.paths))
+	size := 0
+	for i, path := range f.paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("stat: %w", err)
+		}
+
+		stamps[i] = fmt.Sprintf("%d/%d", info.ModTime().UnixNano(), info.Size())
+		size += int(info.Size())
+	}
+
+	f.size.Store(int64(size))
+
+	if slices.Equal(stamps, f.stamps) {
+		f.bytes.Store(0)
+		f.logger.Raw().
+			Debug().
+			Str("name", f.Name()).
+			Str("type", string(f.Type())).
+			Msg("Filter not modified")
+		return nil
+	}
+
+	// networks may be split differently by asn and country databases
+	asns := new(bart.Table[uint32])
+	countries := new(bart.Table[string])
+	names := make(map[uint32]string)
+	for _, path := range f.paths {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := f.read(path, asns, countries, names); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	asnlist := merge(asns, countries)
+
+	f.stamps = stamps
+	f.bytes.Store(int64(size))
+
+	f.logger.Raw().
+		Info().
+		Str("name", f.Name()).
+		Str("type", string(f.Type())).
+		Int("size", asnlist.Size()).
+		Msg("Filter updated")
+	f.asnlist.Store(asnlist)
+	f.asnlist.StoreNames(names)
+
+	return nil
+}
+
+func (f *MMDB) read(path string, asns *bart.Table[uint32], countries *bart.Table[string], names map[uint32]string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read file: %w", err)
+	}
+
+	reader, err := mmdb.NewReader(data)
+	if err != nil {
+		return fmt.Errorf("new reader: %w", err)
+	}
+
+	type record struct {
+		asn     uint32
+		country string
+	}
+
+	// records are shared by many networks
+	records := make(map[int]record)
+	return reader.Networks(func(prefix netip.Prefix, offset int) error {
+		rec, ok := records[offset]
+		if !ok {
+			value, err := reader.Decode(offset)
+			if err != nil {
+				return fmt.Errorf("decode: %w", err)
+			}
+
+			rec.asn = uint32(mmdb.Uint(mmdb.Field(value, "autonomous_system_number")))
+			if name, ok := mmdb.Field(value, "autonomous_system_organization").(string); ok && rec.asn > 0 {
+				names[rec.asn] = name
+			}
+			rec.country, _ = mmdb.Field(value, "country", "iso_code").(string)
+			if len(rec.country) < 1 {
+				rec.country, _ = mmdb.Field(value, "registered_country", "iso_code").(string)
+			}
+			rec.country = strings.ToLower(rec.country)
+
+			records[offset] = rec
+		}
+
+		if rec.asn > 0 {
+			asns.Insert(prefix, rec.asn)
+		}
+		if len(rec.country) > 0 {
+			countries.Insert(prefix, rec.country)
+		}
+
+		return nil
+	})
+}
+
+// merge combines the asn and country networks: the most specific network of both wins for any address,
+// the value of the other one is taken from its most specific network containing the whole winner
+// (not just its first address), so the differently split ranges don't leak into each other
+func merge(asns *bart.Table[uint32], countries *bart.Table[string]) *bart.Table[types.ASN] {
+	asnlist := new(bart.Table[types.ASN])
+	insert := func(prefix netip.Prefix) {
+		asn, _ := asns.LookupPrefix(prefix)
+		country, _ := countries.LookupPrefix(prefix)
+		asnlist.Insert(prefix, types.ASN{ASN: asn, Country: country})
+	}
+	for prefix := range asns.All() {
+		insert(prefix)
+	}
+	for prefix := range countries.All() {
+		insert(prefix)
+	}
+
+	return asnlist
+}
@@ -0,0 +1,237 @@
+package mmdb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// data section field types
+const (
+	typeExtended  = 0
+	typePointer   = 1
+	typeString    = 2
+	typeDouble    = 3
+	typeBytes     = 4
+	typeUint16    = 5
+	typeUint32    = 6
+	typeMap       = 7
+	typeInt32     = 8
+	typeUint64    = 9
+	typeUint128   = 10
+	typeArray     = 11
+	typeContainer = 12
+	typeEnd       = 13
+	typeBool      = 14
+	typeFloat     = 15
+)
+
+// max nesting of maps/arrays
+const maxDepth = 64
+
+var ErrInvalidData = errors.New("invalid data")
+
+// decoder decodes the data section values,
+// offsets (and pointers) are relative to the section start
+type decoder struct {
+	buf []byte
+}
+
+// decode returns the value at offset and the offset of the next value,
+// values are: map[string]any, []any, string, []byte, float64, int64, uint64, *big.Int, bool
+func (d *decoder) decode(offset int, depth int) (any, int, error) {
+	if depth > maxDepth {
+		return nil, 0, fmt.Errorf("%w: max depth exceeded", ErrInvalidData)
+	}
+
+	typ, size, offset, err := d.control(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if typ == typePointer {
+		target, next, err := d.pointer(size, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// NOTE: pointer to pointer is invalid
+		value, _, err := d.decode(target, depth+1)
+		return value, next, err
+	}
+
+	// NOTE: every map/array item takes at least a byte, don't allocate for the malformed sizes
+	if (typ == typeMap || typ == typeArray) && size > len(d.buf)-offset {
+		return nil, 0, fmt.Errorf("%w: container out of bounds", ErrInvalidData)
+	}
+
+	switch typ {
+	case typeMap:
+		value := make(map[string]any, size)
+		for range size {
+			var key any
+			key, offset, err = d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			str, ok := key.(string)
+			if !ok {
+				return nil, 0, fmt.Errorf("%w: map key is not a string", ErrInvalidData)
+			}
+
+			value[str], offset, err = d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+
+		return value, offset, nil
+	case typeArray:
+		value := make([]any, size)
+		for i := range size {
+			value[i], offset, err = d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+
+		return value, offset, nil
+	case typeBool:
+		if size > 1 {
+			return nil, 0, fmt.Errorf("%w: bool size %d", ErrInvalidData, size)
+		}
+
+		return size == 1, offset, nil
+	}
+
+	// fixed size values
+	if offset+size > len(d.buf) {
+		return nil, 0, fmt.Errorf("%w: value out of bounds", ErrInvalidData)
+	}
+	data := d.buf[offset : offset+size]
+	next := offset + size
+
+	switch typ {
+	case typeString:
+		return string(data), next, nil
+	case typeBytes:
+		return data, next, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("%w: double size %d", ErrInvalidData, size)
+		}
+
+		return math.Float64frombits(binary.BigEndian.Uint64(data)), next, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("%w: float size %d", ErrInvalidData, size)
+		}
+
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data))), next, nil
+	case typeUint16, typeUint32, typeUint64:
+		if size > 8 {
+			return nil, 0, fmt.Errorf("%w: uint size %d", ErrInvalidData, size)
+		}
+
+		return uintOf(data), next, nil
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, fmt.Errorf("%w: int32 size %d", ErrInvalidData, size)
+		}
+
+		value := uintOf(data)
+		if size == 4 {
+			return int64(int32(uint32(value))), next, nil
+		}
+
+		return int64(value), next, nil
+	case typeUint128:
+		if size > 16 {
+			return nil, 0, fmt.Errorf("%w: uint128 size %d", ErrInvalidData, size)
+		}
+
+		return new(big.Int).SetBytes(data), next, nil
+	}
+
+	return nil, 0, fmt.Errorf("%w: unsupported type %d", ErrInvalidData, typ)
+}
+
+// control parses the control byte(s) and returns the value type, size and offset
+func (d *decoder) control(offset int) (int, int, int, error) {
+	if offset < 0 || offset >= len(d.buf) {
+		return 0, 0, 0, fmt.Errorf("%w: offset out of bounds", ErrInvalidData)
+	}
+
+	ctrl := d.buf[offset]
+	offset++
+
+	typ := int(ctrl >> 5)
+	if typ == typePointer {
+		// pointer size is parsed separately
+		return typ, int(ctrl & 0x1f), offset, nil
+	}
+	if typ == typeExtended {
+		if offset >= len(d.buf) {
+			return 0, 0, 0, fmt.Errorf("%w: offset out of bounds", ErrInvalidData)
+		}
+
+		typ = 7 + int(d.buf[offset])
+		offset++
+		if typ < typeInt32 || typ > typeFloat {
+			return 0, 0, 0, fmt.Errorf("%w: extended type %d", ErrInvalidData, typ)
+		}
+	}
+
+	size := int(ctrl & 0x1f)
+	if size < 29 {
+		return typ, size, offset, nil
+	}
+
+	n := size - 28
+	if offset+n > len(d.buf) {
+		return 0, 0, 0, fmt.Errorf("%w: size out of bounds", ErrInvalidData)
+	}
+
+	extra := int(uintOf(d.buf[offset : offset+n]))
+	switch size {
+	case 29:
+		size = 29 + extra
+	case 30:
+		size = 285 + extra
+	default:
+		size = 65821 + extra
+	}
+
+	return typ, size, offset + n, nil
+}
+
+// pointer returns the pointer target and the offset after the pointer
+func (d *decoder) pointer(ctrl int, offset int) (int, int, error) {
+	n := (ctrl>>3)&0x3 + 1
+	if offset+n > len(d.buf) {
+		return 0, 0, fmt.Errorf("%w: pointer out of bounds", ErrInvalidData)
+	}
+
+	value := uintOf(d.buf[offset : offset+n])
+	switch n {
+	case 1:
+		value |= uint64(ctrl&0x7) << 8
+	case 2:
+		value = (value | uint64(ctrl&0x7)<<16) + 2048
+	case 3:
+		value = (value | uint64(ctrl&0x7)<<24) + 526336
+	}
+
+	return int(value), offset + n, nil
+}
+
+func uintOf(data []byte) uint64 {
+	var value uint64
+	for _, b := range data {
+		value = value<<8 | uint64(b)
+	}
+
+	return value
+}
@@ -0,0 +1,115 @@
+package mmdb
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	tests := []struct {
+		name   string
+		buf    []byte
+		offset int
+		want   any
+		next   int
+	}{
+		{name: "string", buf: []byte{0x42, 'u', 's'}, want: "us", next: 3},
+		{name: "string 29+", buf: append([]byte{0x5d, 1}, strings.Repeat("a", 30)...), want: strings.Repeat("a", 30), next: 32},
+		{name: "string 285+", buf: append([]byte{0x5e, 0, 15}, long...), want: long, next: 303},
+		{name: "bytes", buf: []byte{0x82, 1, 2}, want: []byte{1, 2}, next: 3},
+		{name: "uint16", buf: []byte{0xa2, 0x01, 0x00}, want: uint64(256), next: 3},
+		{name: "uint32 empty", buf: []byte{0xc0}, want: uint64(0), next: 1},
+		{name: "uint64", buf: []byte{0x08, 0x02, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, want: uint64(1<<64 - 1), next: 10},
+		{name: "uint128", buf: []byte{0x02, 0x03, 0x01, 0x00}, want: big.NewInt(256), next: 4},
+		{name: "int32 negative", buf: []byte{0x04, 0x01, 0xff, 0xff, 0xff, 0xfe}, want: int64(-2), next: 6},
+		{name: "double", buf: []byte{0x68, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}, want: 1.5, next: 9},
+		{name: "float", buf: []byte{0x04, 0x08, 0x3f, 0xc0, 0, 0}, want: 1.5, next: 6},
+		{name: "bool", buf: []byte{0x01, 0x07}, want: true, next: 2},
+		{name: "array", buf: []byte{0x02, 0x04, 0xa1, 0x01, 0x41, 'a'}, want: []any{uint64(1), "a"}, next: 6},
+		{name: "map", buf: []byte{0xe1, 0x42, 'i', 'd', 0xa1, 0x07}, want: map[string]any{"id": uint64(7)}, next: 6},
+		{name: "pointer", buf: []byte{0x42, 'u', 's', 0x20, 0x00}, offset: 3, want: "us", next: 5},
+		{
+			name:   "map pointer values",
+			buf:    []byte{0x42, 'u', 's', 0xe2, 0x41, 'a', 0x20, 0x00, 0x41, 'b', 0x20, 0x00},
+			offset: 3,
+			want:   map[string]any{"a": "us", "b": "us"},
+			next:   12,
+		},
+		{name: "pointer 11 bits", buf: append(append(make([]byte, 256), 0x41, 'x'), 0x21, 0x00), offset: 258, want: "x", next: 260},
+		{name: "pointer 2 bytes", buf: append(append(make([]byte, 2048), 0x41, 'x'), 0x28, 0x00, 0x00), offset: 2050, want: "x", next: 2053},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := decoder{buf: tt.buf}
+			got, next, err := d.decode(tt.offset, 0)
+			if err != nil {
+				t.Fatalf("decode: %s", err)
+			}
+
+			if want, ok := tt.want.(*big.Int); ok {
+				if got, ok := got.(*big.Int); !ok || got.Cmp(want) != 0 {
+					t.Errorf("got %v, want %v", got, want)
+				}
+			} else if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+			if next != tt.next {
+				t.Errorf("next %d, want %d", next, tt.next)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		buf  []byte
+	}{
+		{name: "empty", buf: nil},
+		{name: "string out of bounds", buf: []byte{0x45, 'a'}},
+		{name: "size out of bounds", buf: []byte{0x5e, 0x01}},
+		{name: "extended type missing", buf: []byte{0x00}},
+		{name: "extended type unknown", buf: []byte{0x00, 0x20}},
+		{name: "map key not string", buf: []byte{0xe1, 0xa1, 0x01, 0xa1, 0x01}},
+		{name: "map out of bounds", buf: []byte{0xff, 0xff, 0xff, 0xff}},
+		{name: "array out of bounds", buf: []byte{0x1f, 0x04, 0xff, 0xff, 0xff}},
+		{name: "map truncated", buf: []byte{0xe2, 0x41, 'a', 0xa1, 0x01}},
+		{name: "bool size", buf: []byte{0x02, 0x07}},
+		{name: "double size", buf: []byte{0x64, 0, 0, 0, 0}},
+		{name: "uint size", buf: append([]byte{0xa9}, make([]byte, 9)...)},
+		{name: "pointer out of bounds", buf: []byte{0x20, 0x10}},
+		{name: "pointer truncated", buf: []byte{0x38, 0x00}},
+		{name: "pointer loop", buf: []byte{0x20, 0x00}},
+		{name: "nested too deep", buf: append(bytes.Repeat([]byte{0x01, 0x04}, maxDepth+1), 0xc0)},
+		{name: "container type", buf: []byte{0x00, 0x05}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := decoder{buf: tt.buf}
+			if _, _, err := d.decode(0, 0); !errors.Is(err, ErrInvalidData) {
+				t.Errorf("decode: %v, want invalid data", err)
+			}
+		})
+	}
+}
+
+func FuzzDecode(f *testing.F) {
+	f.Add([]byte{0xe1, 0x42, 'i', 'd', 0xa1, 0x07})
+	f.Add([]byte{0x42, 'u', 's', 0x20, 0x00})
+	f.Add(encodeTestValue(map[string]any{"country": map[string]any{"iso_code": "US"}, "list": []any{1.5, true}}))
+
+	f.Fuzz(func(t *testing.T, buf []byte) {
+		d := decoder{buf: buf}
+		_, next, err := d.decode(0, 0)
+		if err == nil && (next < 0 || next > len(buf)) {
+			t.Errorf("next %d out of bounds %d", next, len(buf))
+		}
+	})
+}
@@ -0,0 +1,21 @@
+package mmdb
+
+// Field returns the nested map value by path (e.g. "country", "iso_code")
+func Field(value any, path ...string) any {
+	for _, key := range path {
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		value = fields[key]
+	}
+
+	return value
+}
+
+// Uint returns the unsigned integer value (zero if not an unsigned integer)
+func Uint(value any) uint64 {
+	v, _ := value.(uint64)
+	return v
+}
@@ -0,0 +1,207 @@
+package mmdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+)
+
+// metadata is stored at the end of the file after the marker
+var metadataMarker = []byte("\xab\xcd\xefMaxMind.com")
+
+// metadata max size
+const metadataMaxSize = 128 << 10
+
+// zero bytes between the search tree and the data section
+const dataSeparatorSize = 16
+
+type Metadata struct {
+	NodeCount    uint
+	RecordSize   uint
+	IPVersion    uint
+	DatabaseType string
+	BuildEpoch   uint
+}
+
+// Reader reads MaxMind DB files (GeoLite2, GeoIP2, DB-IP, etc.)
+type Reader struct {
+	meta     Metadata
+	tree     []byte
+	data     decoder
+	nodeSize int
+	ipv4Root uint
+}
+
+func NewReader(buf []byte) (*Reader, error) {
+	start := bytes.LastIndex(buf[max(0, len(buf)-metadataMaxSize):], metadataMarker)
+	if start < 0 {
+		return nil, fmt.Errorf("%w: metadata not found", ErrInvalidData)
+	}
+	start += max(0, len(buf)-metadataMaxSize) + len(metadataMarker)
+
+	meta, err := parseMetadata(buf[start:])
+	if err != nil {
+		return nil, fmt.Errorf("parse metadata: %w", err)
+	}
+
+	switch meta.RecordSize {
+	case 24, 28, 32:
+	default:
+		return nil, fmt.Errorf("%w: unsupported record size %d", ErrInvalidData, meta.RecordSize)
+	}
+	if meta.IPVersion != 4 && meta.IPVersion != 6 {
+		return nil, fmt.Errorf("%w: unsupported ip version %d", ErrInvalidData, meta.IPVersion)
+	}
+
+	if meta.NodeCount < 1 {
+		return nil, fmt.Errorf("%w: empty search tree", ErrInvalidData)
+	}
+
+	nodeSize := int(meta.RecordSize) / 4
+	treeSize := int(meta.NodeCount) * nodeSize
+	if treeSize+dataSeparatorSize > start-len(metadataMarker) {
+		return nil, fmt.Errorf("%w: search tree out of bounds", ErrInvalidData)
+	}
+
+	r := &Reader{
+		meta:     meta,
+		tree:     buf[:treeSize],
+		data:     decoder{buf: buf[treeSize+dataSeparatorSize : start-len(metadataMarker)]},
+		nodeSize: nodeSize,
+	}
+
+	// ipv4 addresses are stored in "::/96" of ipv6 trees
+	if meta.IPVersion == 6 {
+		node := uint(0)
+		for i := 0; i < 96 && node < meta.NodeCount; i++ {
+			node, _ = r.node(node)
+		}
+		r.ipv4Root = node
+	}
+
+	return r, nil
+}
+
+func (r *Reader) Metadata() Metadata {
+	return r.meta
+}
+
+// Networks walks all networks with data, ipv4 networks of ipv6 trees are
+// returned as ipv4 prefixes, aliases of the ipv4 subtree (e.g. "::ffff:0:0/96") are skipped.
+// The offset identifies the record (shared by many networks), see Decode.
+func (r *Reader) Networks(fn func(prefix netip.Prefix, offset int) error) error {
+	type item struct {
+		node  uint
+		addr  [16]byte
+		depth int
+	}
+
+	bits := 32
+	if r.meta.IPVersion == 6 {
+		bits = 128
+	}
+
+	// NOTE: nodes are shared by the malformed trees only, the walk would be exponential
+	visited := make([]bool, r.meta.NodeCount)
+	stack := []item{{node: 0}}
+	for len(stack) > 0 {
+		curr := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		left, right := r.node(curr.node)
+		for bit, record := range [2]uint{right, left} {
+			addr := curr.addr
+			if bit == 0 {
+				addr[curr.depth/8] |= 0x80 >> (curr.depth % 8)
+			}
+			depth := curr.depth + 1
+
+			switch {
+			case record < r.meta.NodeCount:
+				if depth >= bits {
+					return fmt.Errorf("%w: search tree too deep", ErrInvalidData)
+				}
+				if r.meta.IPVersion == 6 && record == r.ipv4Root && (depth != 96 || addr != [16]byte{}) {
+					continue
+				}
+				if visited[record] {
+					return fmt.Errorf("%w: search tree node reused", ErrInvalidData)
+				}
+				visited[record] = true
+
+				stack = append(stack, item{node: record, addr: addr, depth: depth})
+			case record == r.meta.NodeCount:
+				// no data
+			default:
+				offset := int(record-r.meta.NodeCount) - dataSeparatorSize
+				if offset < 0 || offset >= len(r.data.buf) {
+					return fmt.Errorf("%w: data pointer out of bounds", ErrInvalidData)
+				}
+
+				if err := fn(r.prefix(addr, depth), offset); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Decode returns the record at offset
+func (r *Reader) Decode(offset int) (any, error) {
+	value, _, err := r.data.decode(offset, 0)
+	return value, err
+}
+
+func (r *Reader) prefix(addr [16]byte, depth int) netip.Prefix {
+	if r.meta.IPVersion == 4 {
+		return netip.PrefixFrom(netip.AddrFrom4([4]byte(addr[:4])), depth)
+	}
+
+	if depth >= 96 && [12]byte(addr[:12]) == [12]byte{} {
+		return netip.PrefixFrom(netip.AddrFrom4([4]byte(addr[12:])), depth-96)
+	}
+
+	return netip.PrefixFrom(netip.AddrFrom16(addr), depth)
+}
+
+// node returns the left (0 bit) and right (1 bit) records
+func (r *Reader) node(n uint) (uint, uint) {
+	b := r.tree[int(n)*r.nodeSize:][:r.nodeSize]
+
+	switch r.meta.RecordSize {
+	case 24:
+		return uint(uintOf(b[:3])), uint(uintOf(b[3:]))
+	case 28:
+		left := uint(b[3]&0xf0)<<20 | uint(uintOf(b[:3]))
+		right := uint(b[3]&0x0f)<<24 | uint(uintOf(b[4:]))
+		return left, right
+	default:
+		return uint(binary.BigEndian.Uint32(b[:4])), uint(binary.BigEndian.Uint32(b[4:]))
+	}
+}
+
+func parseMetadata(buf []byte) (Metadata, error) {
+	d := decoder{buf: buf}
+	value, _, err := d.decode(0, 0)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	fields, ok := value.(map[string]any)
+	if !ok {
+		return Metadata{}, fmt.Errorf("%w: metadata is not a map", ErrInvalidData)
+	}
+
+	meta := Metadata{
+		NodeCount:  uint(Uint(fields["node_count"])),
+		RecordSize: uint(Uint(fields["record_size"])),
+		IPVersion:  uint(Uint(fields["ip_version"])),
+		BuildEpoch: uint(Uint(fields["build_epoch"])),
+	}
+	meta.DatabaseType, _ = fields["database_type"].(string)
+
+	return meta, nil
+}
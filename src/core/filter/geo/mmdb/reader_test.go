@@ -0,0 +1,161 @@
+package mmdb
+
+import (
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func testNetworks(ipVersion int) []testNetwork {
+	networks := []testNetwork{
+		{prefix: "1.2.3.0/24", value: map[string]any{"country": map[string]any{"iso_code": "US"}}},
+		{prefix: "5.0.0.0/8", value: map[string]any{"autonomous_system_number": uint64(64512)}},
+		{prefix: "9.9.9.9/32", value: map[string]any{"registered_country": map[string]any{"iso_code": "CH"}}},
+	}
+	if ipVersion == 6 {
+		networks = append(networks,
+			testNetwork{prefix: "2001:db8::/32", value: map[string]any{"country": map[string]any{"iso_code": "DE"}}},
+			testNetwork{prefix: "2a00::/12", value: map[string]any{"autonomous_system_number": uint64(3320)}},
+		)
+	}
+
+	return networks
+}
+
+func TestReaderNetworks(t *testing.T) {
+	for _, ipVersion := range []int{4, 6} {
+		for _, recordSize := range []int{24, 28, 32} {
+			networks := testNetworks(ipVersion)
+			want := make(map[netip.Prefix]any, len(networks))
+			for _, network := range networks {
+				want[netip.MustParsePrefix(network.prefix)] = network.value
+			}
+
+			r, err := NewReader(writeTestDB(recordSize, ipVersion, networks))
+			if err != nil {
+				t.Fatalf("ipv%d/%d: new reader: %s", ipVersion, recordSize, err)
+			}
+			if meta := r.Metadata(); meta.RecordSize != uint(recordSize) || meta.IPVersion != uint(ipVersion) || meta.DatabaseType != "Test" {
+				t.Errorf("ipv%d/%d: metadata %+v", ipVersion, recordSize, meta)
+			}
+
+			// NOTE: the ipv4 networks are returned once, the aliases are skipped
+			got := make(map[netip.Prefix]any)
+			if err := r.Networks(func(prefix netip.Prefix, offset int) error {
+				if _, ok := got[prefix]; ok {
+					t.Errorf("ipv%d/%d: %s returned twice", ipVersion, recordSize, prefix)
+				}
+
+				got[prefix], err = r.Decode(offset)
+				return err
+			}); err != nil {
+				t.Fatalf("ipv%d/%d: networks: %s", ipVersion, recordSize, err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ipv%d/%d: got %v, want %v", ipVersion, recordSize, got, want)
+			}
+		}
+	}
+}
+
+func TestReaderNode(t *testing.T) {
+	tests := []struct {
+		recordSize  uint
+		node        []byte
+		left, right uint
+	}{
+		{recordSize: 24, node: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06}, left: 0x010203, right: 0x040506},
+		{recordSize: 28, node: []byte{0x01, 0x02, 0x03, 0xab, 0x04, 0x05, 0x06}, left: 0xa010203, right: 0xb040506},
+		{recordSize: 32, node: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}, left: 0x01020304, right: 0x05060708},
+	}
+
+	for _, tt := range tests {
+		r := Reader{meta: Metadata{RecordSize: tt.recordSize}, tree: tt.node, nodeSize: len(tt.node)}
+		if left, right := r.node(0); left != tt.left || right != tt.right {
+			t.Errorf("%d bits: got %x/%x, want %x/%x", tt.recordSize, left, right, tt.left, tt.right)
+		}
+	}
+}
+
+func TestNewReaderInvalid(t *testing.T) {
+	valid := writeTestDB(24, 6, testNetworks(6))
+	meta := func(fields map[string]any) []byte {
+		return append(append(make([]byte, 64), metadataMarker...), encodeTestValue(fields)...)
+	}
+
+	tests := []struct {
+		name string
+		buf  []byte
+	}{
+		{name: "empty", buf: nil},
+		{name: "no metadata", buf: valid[:len(valid)-200]},
+		{name: "metadata not map", buf: append(append([]byte{}, metadataMarker...), 0x41, 'a')},
+		{name: "record size", buf: meta(map[string]any{"node_count": uint64(1), "record_size": uint64(26), "ip_version": uint64(4)})},
+		{name: "ip version", buf: meta(map[string]any{"node_count": uint64(1), "record_size": uint64(24), "ip_version": uint64(5)})},
+		{name: "no nodes", buf: meta(map[string]any{"node_count": uint64(0), "record_size": uint64(24), "ip_version": uint64(4)})},
+		{name: "tree out of bounds", buf: meta(map[string]any{"node_count": uint64(100), "record_size": uint64(24), "ip_version": uint64(4)})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewReader(tt.buf); !errors.Is(err, ErrInvalidData) {
+				t.Errorf("new reader: %v, want invalid data", err)
+			}
+		})
+	}
+}
+
+func TestReaderNetworksInvalid(t *testing.T) {
+	meta := encodeTestValue(map[string]any{"node_count": uint64(1), "record_size": uint64(24), "ip_version": uint64(4)})
+	file := func(node ...byte) []byte {
+		buf := append(node, make([]byte, dataSeparatorSize)...)
+		buf = append(buf, 0x41, 'a')
+		buf = append(buf, metadataMarker...)
+
+		return append(buf, meta...)
+	}
+
+	tests := []struct {
+		name string
+		buf  []byte
+	}{
+		// the node points to itself
+		{name: "tree loop", buf: file(0, 0, 0, 0, 0, 1)},
+		{name: "data out of bounds", buf: file(0, 0, 0xff, 0, 0, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewReader(tt.buf)
+			if err != nil {
+				t.Fatalf("new reader: %s", err)
+			}
+
+			if err := r.Networks(func(netip.Prefix, int) error { return nil }); !errors.Is(err, ErrInvalidData) {
+				t.Errorf("networks: %v, want invalid data", err)
+			}
+		})
+	}
+}
+
+func FuzzReader(f *testing.F) {
+	for _, ipVersion := range []int{4, 6} {
+		for _, recordSize := range []int{24, 28, 32} {
+			f.Add(writeTestDB(recordSize, ipVersion, testNetworks(ipVersion)))
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, buf []byte) {
+		r, err := NewReader(buf)
+		if err != nil {
+			return
+		}
+
+		_ = r.Networks(func(prefix netip.Prefix, offset int) error {
+			_, err := r.Decode(offset)
+			return err
+		})
+	})
+}
@@ -0,0 +1,188 @@
+package mmdb
+
+import (
+	"encoding/binary"
+	"math"
+	"net/netip"
+	"slices"
+)
+
+// testNetwork is the fixture network with its record
+type testNetwork struct {
+	prefix string
+	value  any
+}
+
+// writeTestDB builds the MaxMind DB file, ipv4 networks of ipv6 trees are stored in "::/96"
+// and aliased by "::ffff:0:0/96" and "2002::/16" like the MaxMind databases do
+func writeTestDB(recordSize, ipVersion int, networks []testNetwork) []byte {
+	// records: < 0 - empty, >= 0 with data - data offset, otherwise node index
+	type record struct {
+		value int
+		data  bool
+	}
+	nodes := [][2]record{{{value: -1}, {value: -1}}}
+
+	// insert walks the bits creating the nodes, returns the last node and bit
+	insert := func(addr [16]byte, bits int) (int, int) {
+		node := 0
+		for i := range bits - 1 {
+			bit := int(addr[i/8]>>(7-i%8)) & 1
+			next := nodes[node][bit]
+			if next.value < 0 || next.data {
+				nodes = append(nodes, [2]record{{value: -1}, {value: -1}})
+				next = record{value: len(nodes) - 1}
+				nodes[node][bit] = next
+			}
+			node = next.value
+		}
+
+		return node, int(addr[(bits-1)/8]>>(7-(bits-1)%8)) & 1
+	}
+
+	var data []byte
+	for _, network := range networks {
+		prefix := netip.MustParsePrefix(network.prefix)
+
+		var addr [16]byte
+		bits := prefix.Bits()
+		switch {
+		case ipVersion == 4:
+			a4 := prefix.Addr().As4()
+			copy(addr[:], a4[:])
+		case prefix.Addr().Is4():
+			// NOTE: "::a.b.c.d/96+bits"
+			a4 := prefix.Addr().As4()
+			copy(addr[12:], a4[:])
+			bits += 96
+		default:
+			addr = prefix.Addr().As16()
+		}
+
+		node, bit := insert(addr, bits)
+		nodes[node][bit] = record{value: len(data), data: true}
+		data = append(data, encodeTestValue(network.value)...)
+	}
+
+	if ipVersion == 6 {
+		// the ipv4 subtree root is the node of "::/96"
+		v4, bit := insert([16]byte{}, 96)
+		if root := nodes[v4][bit]; root.value >= 0 && !root.data {
+			for _, alias := range []string{"::ffff:0:0/96", "2002::/16"} {
+				prefix := netip.MustParsePrefix(alias)
+				node, bit := insert(prefix.Addr().As16(), prefix.Bits())
+				nodes[node][bit] = root
+			}
+		}
+	}
+
+	count := len(nodes)
+	value := func(r record) uint64 {
+		switch {
+		case r.data:
+			return uint64(count + dataSeparatorSize + r.value)
+		case r.value < 0:
+			return uint64(count)
+		default:
+			return uint64(r.value)
+		}
+	}
+
+	var buf []byte
+	for _, node := range nodes {
+		left, right := value(node[0]), value(node[1])
+		switch recordSize {
+		case 24:
+			buf = append(buf, byte(left>>16), byte(left>>8), byte(left), byte(right>>16), byte(right>>8), byte(right))
+		case 28:
+			buf = append(buf, byte(left>>16), byte(left>>8), byte(left), byte(left>>24)<<4|byte(right>>24)&0x0f, byte(right>>16), byte(right>>8), byte(right))
+		default:
+			buf = binary.BigEndian.AppendUint32(buf, uint32(left))
+			buf = binary.BigEndian.AppendUint32(buf, uint32(right))
+		}
+	}
+	buf = append(buf, make([]byte, dataSeparatorSize)...)
+	buf = append(buf, data...)
+	buf = append(buf, metadataMarker...)
+	buf = append(buf, encodeTestValue(map[string]any{
+		"node_count":                  uint64(count),
+		"record_size":                 uint64(recordSize),
+		"ip_version":                  uint64(ipVersion),
+		"database_type":               "Test",
+		"build_epoch":                 uint64(1700000000),
+		"binary_format_major_version": uint64(2),
+	})...)
+
+	return buf
+}
+
+// encodeTestValue encodes the data section value
+func encodeTestValue(value any) []byte {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		buf := encodeTestControl(typeMap, len(v))
+		for _, key := range keys {
+			buf = append(buf, encodeTestValue(key)...)
+			buf = append(buf, encodeTestValue(v[key])...)
+		}
+		return buf
+	case []any:
+		buf := encodeTestControl(typeArray, len(v))
+		for _, item := range v {
+			buf = append(buf, encodeTestValue(item)...)
+		}
+		return buf
+	case string:
+		return append(encodeTestControl(typeString, len(v)), v...)
+	case []byte:
+		return append(encodeTestControl(typeBytes, len(v)), v...)
+	case uint64:
+		typ := typeUint32
+		if v > math.MaxUint32 {
+			typ = typeUint64
+		}
+		data := binary.BigEndian.AppendUint64(nil, v)
+		for len(data) > 0 && data[0] == 0 {
+			data = data[1:]
+		}
+		return append(encodeTestControl(typ, len(data)), data...)
+	case bool:
+		size := 0
+		if v {
+			size = 1
+		}
+		return encodeTestControl(typeBool, size)
+	case float64:
+		return binary.BigEndian.AppendUint64(encodeTestControl(typeDouble, 8), math.Float64bits(v))
+	}
+
+	panic("unsupported test value")
+}
+
+func encodeTestControl(typ, size int) []byte {
+	var ctrl []byte
+	switch {
+	case size < 29:
+		ctrl = []byte{byte(size)}
+	case size < 285:
+		ctrl = []byte{29, byte(size - 29)}
+	case size < 65821:
+		ctrl = []byte{30, byte((size - 285) >> 8), byte(size - 285)}
+	default:
+		ctrl = []byte{31, byte((size - 65821) >> 16), byte((size - 65821) >> 8), byte(size - 65821)}
+	}
+
+	if typ > typeMap {
+		// NOTE: the extended type follows the control byte
+		return append([]byte{ctrl[0]}, append([]byte{byte(typ - 7)}, ctrl[1:]...)...)
+	}
+	ctrl[0] |= byte(typ << 5)
+
+	return ctrl
+}
@@ -0,0 +1,47 @@
+package geo
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/gaissmai/bart"
+
+	"github.com/cnaize/meds/src/types"
+)
+
+func TestMerge(t *testing.T) {
+	asns := new(bart.Table[uint32])
+	asns.Insert(netip.MustParsePrefix("1.0.0.0/16"), 1)
+	asns.Insert(netip.MustParsePrefix("2001:db8::/32"), 3)
+
+	countries := new(bart.Table[string])
+	countries.Insert(netip.MustParsePrefix("1.0.0.0/24"), "us")
+	countries.Insert(netip.MustParsePrefix("1.0.1.0/24"), "de")
+	countries.Insert(netip.MustParsePrefix("8.0.0.0/8"), "nl")
+	countries.Insert(netip.MustParsePrefix("2001:db8::/48"), "fr")
+
+	tests := []struct {
+		ip   string
+		want types.ASN
+		ok   bool
+	}{
+		{ip: "1.0.0.1", want: types.ASN{ASN: 1, Country: "us"}, ok: true},
+		{ip: "1.0.1.1", want: types.ASN{ASN: 1, Country: "de"}, ok: true},
+		// NOTE: the first address of the asn network is in "us", the rest isn't
+		{ip: "1.0.200.1", want: types.ASN{ASN: 1}, ok: true},
+		{ip: "8.8.8.8", want: types.ASN{Country: "nl"}, ok: true},
+		{ip: "2001:db8::1", want: types.ASN{ASN: 3, Country: "fr"}, ok: true},
+		{ip: "2001:db8:1::1", want: types.ASN{ASN: 3}, ok: true},
+		{ip: "9.9.9.9"},
+	}
+
+	asnlist := merge(asns, countries)
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			got, ok := asnlist.Lookup(netip.MustParseAddr(tt.ip))
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("got %+v %t, want %+v %t", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package filter
 
 import (
 	"context"
+	"time"
 
 	"github.com/cnaize/meds/src/types"
 )
@@ -39,10 +40,41 @@ type Checker interface {
 	Check(packet *types.Packet) bool
 }
 
+// Explainer is implemented by filters which can check the packet without side effects
+// (no hits, no rate limiting), the result is the same as Check with the matched list entry
+type Explainer interface {
+	Explain(packet *types.Packet) (bool, string)
+}
+
 type Updater interface {
 	Update(ctx context.Context) error
 }
 
+type Stats struct {
+	// list size
+	Entries int
+	// downloaded by the last update
+	Bytes int
+	// raw lists size
+	Size int
+}
+
+// Reporter is implemented by filters with downloadable lists
+type Reporter interface {
+	Stats() Stats
+}
+
+// Versioner is implemented by filters which lists can be diffed and restored
+type Versioner interface {
+	Entries() []string
+	Restore(entries []string) error
+}
+
+// Scheduler is implemented by filters with their own update interval
+type Scheduler interface {
+	Interval() time.Duration
+}
+
 type Filter interface {
 	Namer
 	Typer
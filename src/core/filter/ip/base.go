@@ -2,25 +2,29 @@ package ip
 
 import (
 	"context"
+	"net/netip"
 	"sync/atomic"
 
 	"github.com/gaissmai/bart"
 
 	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/filter/feed"
 	"github.com/cnaize/meds/src/core/logger"
 	"github.com/cnaize/meds/src/types"
 )
 
 type Base struct {
-	urls      []string
+	sources   []feed.Source
+	fetcher   *feed.Fetcher
 	logger    *logger.Logger
 	blacklist atomic.Pointer[bart.Lite]
 }
 
-func NewBase(urls []string, logger *logger.Logger) *Base {
+func NewBase(sources []feed.Source, fetcher *feed.Fetcher, logger *logger.Logger) *Base {
 	return &Base{
-		urls:   urls,
-		logger: logger,
+		sources: sources,
+		fetcher: fetcher,
+		logger:  logger,
 	}
 }
 
@@ -43,3 +47,17 @@ func (f *Base) Check(packet *types.Packet) bool {
 	list := f.blacklist.Load()
 	return !list.Contains(srcIP)
 }
+
+func (f *Base) Explain(packet *types.Packet) (bool, string) {
+	srcIP, ok := packet.GetSrcIP()
+	if !ok {
+		return true, ""
+	}
+
+	match, ok := f.blacklist.Load().LookupPrefixLPM(netip.PrefixFrom(srcIP, srcIP.BitLen()))
+	if !ok {
+		return true, ""
+	}
+
+	return false, match.String()
+}
@@ -2,14 +2,16 @@ package ip
 
 import (
 	"context"
-	"net/netip"
 
 	"github.com/cnaize/meds/src/core/filter"
 	"github.com/cnaize/meds/src/core/logger"
 	"github.com/cnaize/meds/src/types"
 )
 
-var _ filter.Filter = (*BlackList)(nil)
+var (
+	_ filter.Filter    = (*BlackList)(nil)
+	_ filter.Explainer = (*BlackList)(nil)
+)
 
 type BlackList struct {
 	logger    *logger.Logger
@@ -43,7 +45,21 @@ func (f *BlackList) Check(packet *types.Packet) bool {
 		return true
 	}
 
-	return !f.blacklist.Lookup(netip.PrefixFrom(srcIP, 32))
+	return !f.blacklist.Hit(srcIP)
+}
+
+func (f *BlackList) Explain(packet *types.Packet) (bool, string) {
+	srcIP, ok := packet.GetSrcIP()
+	if !ok {
+		return true, ""
+	}
+
+	match, ok := f.blacklist.Match(srcIP)
+	if !ok {
+		return true, ""
+	}
+
+	return false, match.String()
 }
 
 func (f *BlackList) Update(ctx context.Context) error {
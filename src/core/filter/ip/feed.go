@@ -0,0 +1,165 @@
+package ip
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/gaissmai/bart"
+
+	"github.com/cnaize/meds/lib/util/get"
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/filter/feed"
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/types"
+)
+
+var (
+	_ filter.Filter    = (*Feed)(nil)
+	_ filter.Reporter  = (*Feed)(nil)
+	_ filter.Versioner = (*Feed)(nil)
+	_ filter.Explainer = (*Feed)(nil)
+)
+
+type Feed struct {
+	*Base
+
+	name     string
+	interval time.Duration
+	limits   feed.Limits
+	bytes    atomic.Int64
+	size     atomic.Int64
+
+	whitelist *types.SubnetList
+}
+
+func NewFeed(def feed.Feed, fetcher *feed.Fetcher, logger *logger.Logger, whitelist *types.SubnetList) *Feed {
+	return &Feed{
+		Base:      NewBase(def.Sources, fetcher, logger),
+		name:      def.Name,
+		interval:  def.Interval,
+		limits:    def.Limits,
+		whitelist: whitelist,
+	}
+}
+
+func (f *Feed) Name() string {
+	return f.name
+}
+
+// Interval overrides the default update interval (if not zero)
+func (f *Feed) Interval() time.Duration {
+	return f.interval
+}
+
+func (f *Feed) Stats() filter.Stats {
+	return filter.Stats{
+		Entries: f.blacklist.Load().Size(),
+		Bytes:   int(f.bytes.Load()),
+		Size:    int(f.size.Load()),
+	}
+}
+
+func (f *Feed) Entries() []string {
+	list := f.blacklist.Load()
+	entries := make([]string, 0, list.Size())
+	for subnet := range list.All() {
+		entries = append(entries, subnet.String())
+	}
+
+	return entries
+}
+
+func (f *Feed) Restore(entries []string) error {
+	blacklist := new(bart.Lite)
+	for _, entry := range entries {
+		subnet, ok := get.Subnet(entry)
+		if !ok {
+			return fmt.Errorf("invalid subnet: %s", entry)
+		}
+
+		blacklist.Insert(subnet)
+	}
+	f.blacklist.Store(blacklist)
+
+	return nil
+}
+
+func (f *Feed) Load(ctx context.Context) error {
+	defer f.logger.Raw().Info().Str("name", f.Name()).Str("type", string(f.Type())).Msg("Filter loaded")
+
+	if err := f.Base.Load(ctx); err != nil {
+		return err
+	}
+
+	// populate from cache
+	if err := f.update(ctx, f.fetcher.Load); err != nil {
+		f.logger.Raw().
+			Debug().
+			Err(err).
+			Str("name", f.Name()).
+			Str("type", string(f.Type())).
+			Msg("cache load failed")
+	}
+
+	return nil
+}
+
+func (f *Feed) Update(ctx context.Context) error {
+	return f.update(ctx, f.fetcher.Fetch)
+}
+
+func (f *Feed) update(ctx context.Context, fetch feed.FetchFunc) error {
+	blacklist := new(bart.Lite)
+	stats, err := fetch(ctx, f.sources, func(entry string) error {
+		subnet, ok := get.Subnet(entry)
+		if !ok {
+			return nil
+		}
+
+		if err := f.limits.CheckSubnet(subnet); err != nil {
+			return err
+		}
+		if f.limits.NoWhitelisted && f.whitelist.Lookup(subnet) {
+			return fmt.Errorf("%w: %s is whitelisted", feed.ErrLimitViolated, subnet)
+		}
+
+		blacklist.Insert(subnet)
+		return f.limits.CheckSize(blacklist.Size())
+	})
+	if err != nil {
+		return err
+	}
+
+	if stats.Warn != nil {
+		f.logger.Raw().
+			Warn().
+			Err(stats.Warn).
+			Str("name", f.Name()).
+			Str("type", string(f.Type())).
+			Msg("Filter update degraded")
+	}
+
+	f.bytes.Store(int64(stats.Bytes))
+	f.size.Store(int64(stats.Size))
+
+	if stats.NotModified {
+		f.logger.Raw().
+			Debug().
+			Str("name", f.Name()).
+			Str("type", string(f.Type())).
+			Msg("Filter not modified")
+		return nil
+	}
+
+	f.logger.Raw().
+		Info().
+		Str("name", f.Name()).
+		Str("type", string(f.Type())).
+		Int("size", blacklist.Size()).
+		Msg("Filter updated")
+	f.blacklist.Store(blacklist)
+
+	return nil
+}
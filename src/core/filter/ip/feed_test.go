@@ -0,0 +1,98 @@
+package ip
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/cnaize/meds/src/core/filter/feed"
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/types"
+)
+
+func fetchEntries(entries ...string) feed.FetchFunc {
+	return func(ctx context.Context, sources []feed.Source, fn func(entry string) error) (feed.Stats, error) {
+		for _, entry := range entries {
+			if err := fn(entry); err != nil {
+				return feed.Stats{}, err
+			}
+		}
+
+		return feed.Stats{}, nil
+	}
+}
+
+func TestFeedUpdateWidePrefix(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  feed.Limits
+		entries []string
+		blocked []string
+		err     bool
+	}{
+		{
+			name:    "default reserved",
+			entries: []string{"224.0.0.0/3", "10.0.0.0/8", "1.2.3.0/24", "fc00::/7"},
+			blocked: []string{"224.0.0.1", "10.1.1.1", "1.2.3.4", "fd00::1"},
+		},
+		{
+			name:    "default ipv4",
+			entries: []string{"1.2.3.0/24", "64.0.0.0/2"},
+			err:     true,
+		},
+		{
+			name:    "default ipv6",
+			entries: []string{"1.2.3.0/24", "2000::/3"},
+			err:     true,
+		},
+		{
+			name:    "min prefix",
+			limits:  feed.Limits{MinPrefixV4: 16, MinPrefixV6: 48},
+			entries: []string{"1.2.3.0/24", "2001:db8::/32"},
+			blocked: []string{"1.2.3.4", "2001:db8::1"},
+		},
+		{
+			name:    "min prefix violated",
+			limits:  feed.Limits{MinPrefixV4: 16},
+			entries: []string{"1.2.3.0/24", "5.0.0.0/12"},
+			err:     true,
+		},
+	}
+
+	nop := zerolog.Nop()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFeed(feed.Feed{Name: "FireHOL", Limits: tt.limits}, nil, logger.NewLogger(&nop, 1), types.NewSubnetList())
+			if err := f.Base.Load(context.Background()); err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if err := f.update(context.Background(), fetchEntries("9.9.9.9")); err != nil {
+				t.Fatalf("initial update: %v", err)
+			}
+
+			err := f.update(context.Background(), fetchEntries(tt.entries...))
+			if tt.err {
+				if !errors.Is(err, feed.ErrLimitViolated) {
+					t.Fatalf("update: %v, want limit violated", err)
+				}
+				// NOTE: the previous list is kept
+				if !f.blacklist.Load().Contains(netip.MustParseAddr("9.9.9.9")) {
+					t.Errorf("previous list replaced")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("update: %v", err)
+			}
+
+			for _, ip := range tt.blocked {
+				if !f.blacklist.Load().Contains(netip.MustParseAddr(ip)) {
+					t.Errorf("%s: not blocked", ip)
+				}
+			}
+		})
+	}
+}
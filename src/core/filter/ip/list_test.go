@@ -0,0 +1,62 @@
+package ip
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/cnaize/meds/src/core/filter/feed"
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/types"
+)
+
+func TestListExplain(t *testing.T) {
+	list := types.NewSubnetList()
+	if err := list.Upsert([]netip.Prefix{netip.MustParsePrefix("1.2.3.0/24")}); err != nil {
+		t.Fatalf("upsert: %s", err)
+	}
+
+	nop := zerolog.Nop()
+	log := logger.NewLogger(&nop, 1)
+	f := NewFeed(feed.Feed{Name: "FireHOL"}, nil, log, types.NewSubnetList())
+	if err := f.update(context.Background(), fetchEntries("1.2.3.0/24")); err != nil {
+		t.Fatalf("update: %s", err)
+	}
+
+	tests := []struct {
+		ip      string
+		matched bool
+	}{
+		{ip: "1.2.3.4", matched: true},
+		{ip: "1.2.4.4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			packet, err := types.NewLookupPacket(netip.MustParseAddr(tt.ip), 443, "", "")
+			if err != nil {
+				t.Fatalf("packet: %s", err)
+			}
+
+			match := ""
+			if tt.matched {
+				match = "1.2.3.0/24"
+			}
+
+			if ok, got := NewBlackList(log, list).Explain(packet); ok == tt.matched || got != match {
+				t.Errorf("blacklist %t %q", ok, got)
+			}
+			if ok, got := NewWhiteList(log, list).Explain(packet); ok != tt.matched || got != match {
+				t.Errorf("whitelist %t %q", ok, got)
+			}
+			if ok, got := f.Explain(packet); ok == tt.matched || got != match {
+				t.Errorf("feed %t %q", ok, got)
+			}
+			if ok := f.Check(packet); ok == tt.matched {
+				t.Errorf("feed check %t", ok)
+			}
+		})
+	}
+}
@@ -2,14 +2,16 @@ package ip
 
 import (
 	"context"
-	"net/netip"
 
 	"github.com/cnaize/meds/src/core/filter"
 	"github.com/cnaize/meds/src/core/logger"
 	"github.com/cnaize/meds/src/types"
 )
 
-var _ filter.Filter = (*WhiteList)(nil)
+var (
+	_ filter.Filter    = (*WhiteList)(nil)
+	_ filter.Explainer = (*WhiteList)(nil)
+)
 
 type WhiteList struct {
 	logger    *logger.Logger
@@ -43,7 +45,21 @@ func (f *WhiteList) Check(packet *types.Packet) bool {
 		return false
 	}
 
-	return f.whitelist.Lookup(netip.PrefixFrom(srcIP, 32))
+	return f.whitelist.Hit(srcIP)
+}
+
+func (f *WhiteList) Explain(packet *types.Packet) (bool, string) {
+	srcIP, ok := packet.GetSrcIP()
+	if !ok {
+		return false, ""
+	}
+
+	match, ok := f.whitelist.Match(srcIP)
+	if !ok {
+		return false, ""
+	}
+
+	return true, match.String()
 }
 
 func (f *WhiteList) Update(ctx context.Context) error {
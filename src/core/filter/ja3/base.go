@@ -5,20 +5,23 @@ import (
 	"sync/atomic"
 
 	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/filter/feed"
 	"github.com/cnaize/meds/src/core/logger"
 	"github.com/cnaize/meds/src/types"
 )
 
 type Base struct {
-	urls      []string
+	sources   []feed.Source
+	fetcher   *feed.Fetcher
 	logger    *logger.Logger
 	blacklist atomic.Pointer[map[string]bool]
 }
 
-func NewBase(urls []string, logger *logger.Logger) *Base {
+func NewBase(sources []feed.Source, fetcher *feed.Fetcher, logger *logger.Logger) *Base {
 	return &Base{
-		urls:   urls,
-		logger: logger,
+		sources: sources,
+		fetcher: fetcher,
+		logger:  logger,
 	}
 }
 
@@ -41,3 +44,17 @@ func (f *Base) Check(packet *types.Packet) bool {
 	list := f.blacklist.Load()
 	return !(*list)[hash]
 }
+
+func (f *Base) Explain(packet *types.Packet) (bool, string) {
+	hash, ok := packet.GetJA3()
+	if !ok {
+		return true, ""
+	}
+
+	list := f.blacklist.Load()
+	if !(*list)[hash] {
+		return true, ""
+	}
+
+	return false, hash
+}
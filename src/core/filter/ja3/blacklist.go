@@ -0,0 +1,62 @@
+package ja3
+
+import (
+	"context"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/types"
+)
+
+var (
+	_ filter.Filter    = (*BlackList)(nil)
+	_ filter.Explainer = (*BlackList)(nil)
+)
+
+type BlackList struct {
+	logger    *logger.Logger
+	blacklist *types.JA3List
+}
+
+func NewBlackList(logger *logger.Logger, blacklist *types.JA3List) *BlackList {
+	return &BlackList{
+		logger:    logger,
+		blacklist: blacklist,
+	}
+}
+
+func (f *BlackList) Name() string {
+	return filter.FilterNameBlackList
+}
+
+func (f *BlackList) Type() filter.FilterType {
+	return filter.FilterTypeJA3
+}
+
+func (f *BlackList) Load(ctx context.Context) error {
+	defer f.logger.Raw().Info().Str("name", f.Name()).Str("type", string(f.Type())).Msg("Filter loaded")
+
+	return nil
+}
+
+func (f *BlackList) Check(packet *types.Packet) bool {
+	hash, ok := packet.GetJA3()
+	if !ok {
+		return true
+	}
+
+	return !f.blacklist.Hit(hash)
+}
+
+func (f *BlackList) Explain(packet *types.Packet) (bool, string) {
+	hash, ok := packet.GetJA3()
+	if !ok || len(hash) < 1 || !f.blacklist.Lookup(hash) {
+		return true, ""
+	}
+
+	return false, hash
+}
+
+func (f *BlackList) Update(ctx context.Context) error {
+	return nil
+}
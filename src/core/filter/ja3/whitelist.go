@@ -0,0 +1,66 @@
+package ja3
+
+import (
+	"context"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/types"
+)
+
+var (
+	_ filter.Filter    = (*WhiteList)(nil)
+	_ filter.Explainer = (*WhiteList)(nil)
+)
+
+type WhiteList struct {
+	logger    *logger.Logger
+	whitelist *types.JA3List
+	// observed hashes
+	observed *types.TopCounter
+}
+
+func NewWhiteList(logger *logger.Logger, whitelist *types.JA3List, observed *types.TopCounter) *WhiteList {
+	return &WhiteList{
+		logger:    logger,
+		whitelist: whitelist,
+		observed:  observed,
+	}
+}
+
+func (f *WhiteList) Name() string {
+	return filter.FilterNameWhiteList
+}
+
+func (f *WhiteList) Type() filter.FilterType {
+	return filter.FilterTypeJA3
+}
+
+func (f *WhiteList) Load(ctx context.Context) error {
+	defer f.logger.Raw().Info().Str("name", f.Name()).Str("type", string(f.Type())).Msg("Filter loaded")
+
+	return nil
+}
+
+func (f *WhiteList) Check(packet *types.Packet) bool {
+	hash, ok := packet.GetJA3()
+	if !ok || len(hash) < 1 {
+		return false
+	}
+	f.observed.Observe(hash)
+
+	return f.whitelist.Hit(hash)
+}
+
+func (f *WhiteList) Explain(packet *types.Packet) (bool, string) {
+	hash, ok := packet.GetJA3()
+	if !ok || len(hash) < 1 || !f.whitelist.Lookup(hash) {
+		return false, ""
+	}
+
+	return true, hash
+}
+
+func (f *WhiteList) Update(ctx context.Context) error {
+	return nil
+}
@@ -44,3 +44,11 @@ func (b *Bucket) Allow(rate, burst uint) bool {
 
 	return true
 }
+
+// Peek reports if Allow would pass without taking a token
+func (b *Bucket) Peek(rate, burst uint) bool {
+	elapsed := time.Now().UnixNano() - b.updated.Load()
+	add := (elapsed * int64(rate)) / int64(time.Second)
+
+	return min(b.balance.Load()+add, int64(burst)) > 0
+}
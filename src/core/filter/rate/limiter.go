@@ -15,7 +15,10 @@ import (
 	"github.com/cnaize/meds/src/types"
 )
 
-var _ filter.Filter = (*Limiter)(nil)
+var (
+	_ filter.Filter    = (*Limiter)(nil)
+	_ filter.Explainer = (*Limiter)(nil)
+)
 
 type Limiter struct {
 	rate      uint
@@ -97,6 +100,20 @@ func (f *Limiter) Check(packet *types.Packet) bool {
 	return bucket.Allow(f.rate, f.burst)
 }
 
+func (f *Limiter) Explain(packet *types.Packet) (bool, string) {
+	srcIP, ok := packet.GetSrcIP()
+	if !ok {
+		return true, ""
+	}
+
+	bucket, ok := f.cache.GetIfPresent(srcIP)
+	if !ok {
+		return true, ""
+	}
+
+	return bucket.Peek(f.rate, f.burst), ""
+}
+
 func (f *Limiter) Update(ctx context.Context) error {
 	return nil
 }
@@ -0,0 +1,146 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/cnaize/meds/src/core/filter"
+)
+
+var (
+	ErrFilterBusy = errors.New("filter is updating")
+	ErrNoHistory  = errors.New("no history")
+)
+
+// History stores the filter list changes, records are ordered by id
+type History interface {
+	Record(ctx context.Context, typ filter.FilterType, name string, added, removed []string) error
+	// Last returns ErrNoHistory if there is nothing recorded
+	Last(ctx context.Context, typ filter.FilterType, name string) (HistoryRecord, error)
+	Remove(ctx context.Context, id int64) error
+}
+
+type HistoryRecord struct {
+	ID      int64
+	Added   []string
+	Removed []string
+}
+
+// SetHistory enables the filter list changes recording (must be called before Update)
+func (q *Queue) SetHistory(history History) {
+	q.history = history
+}
+
+// Rollback restores the filter list version before the last recorded update.
+// The record is removed, so subsequent calls walk further back.
+// The restored list is kept till the upstream list changes.
+func (q *Queue) Rollback(ctx context.Context, typ filter.FilterType, name string) error {
+	filters := q.Filters()
+	i := slices.IndexFunc(filters, func(f filter.Filter) bool {
+		return f.Type() == typ && f.Name() == name
+	})
+	if i < 0 {
+		return ErrFilterNotFound
+	}
+
+	versioner, ok := filters[i].(filter.Versioner)
+	if !ok || q.history == nil {
+		return ErrNoHistory
+	}
+
+	// block updates while restoring
+	q.mu.Lock()
+	status := q.statusOf(filters[i])
+	if status.Updating {
+		q.mu.Unlock()
+		return ErrFilterBusy
+	}
+	status.Updating = true
+	q.mu.Unlock()
+
+	defer func() {
+		q.mu.Lock()
+		status.Updating = false
+		q.mu.Unlock()
+	}()
+
+	record, err := q.history.Last(ctx, typ, name)
+	if err != nil {
+		return fmt.Errorf("history last: %w", err)
+	}
+
+	entries := make(map[string]bool)
+	for _, entry := range versioner.Entries() {
+		entries[entry] = true
+	}
+	for _, entry := range record.Added {
+		delete(entries, entry)
+	}
+	for _, entry := range record.Removed {
+		entries[entry] = true
+	}
+
+	if err := versioner.Restore(slices.Collect(maps.Keys(entries))); err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+
+	if err := q.history.Remove(ctx, record.ID); err != nil {
+		return fmt.Errorf("history remove: %w", err)
+	}
+
+	q.logger.Raw().
+		Info().
+		Str("name", name).
+		Str("type", string(typ)).
+		Int("size", len(entries)).
+		Msg("Filter rolled back")
+
+	return nil
+}
+
+// record stores the filter list changes since "before"
+func (q *Queue) record(ctx context.Context, f filter.Filter, before []string) error {
+	versioner, ok := f.(filter.Versioner)
+	if !ok || len(before) < 1 {
+		return nil
+	}
+
+	prev := make(map[string]bool, len(before))
+	for _, entry := range before {
+		prev[entry] = true
+	}
+
+	var added []string
+	for _, entry := range versioner.Entries() {
+		if prev[entry] {
+			delete(prev, entry)
+			continue
+		}
+
+		added = append(added, entry)
+	}
+	removed := slices.Collect(maps.Keys(prev))
+
+	if len(added) < 1 && len(removed) < 1 {
+		return nil
+	}
+
+	return q.history.Record(ctx, f.Type(), f.Name(), added, removed)
+}
+
+// entriesOf returns the filter list if the history is enabled
+func (q *Queue) entriesOf(f filter.Filter) []string {
+	if q.history == nil {
+		return nil
+	}
+
+	versioner, ok := f.(filter.Versioner)
+	if !ok {
+		return nil
+	}
+
+	return versioner.Entries()
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/cnaize/meds/src/core/filter"
+)
+
+// testHistory is the in-memory history of a single filter
+type testHistory struct {
+	records []HistoryRecord
+}
+
+func (h *testHistory) Record(ctx context.Context, typ filter.FilterType, name string, added, removed []string) error {
+	slices.Sort(added)
+	slices.Sort(removed)
+	h.records = append(h.records, HistoryRecord{ID: int64(len(h.records) + 1), Added: added, Removed: removed})
+	return nil
+}
+
+func (h *testHistory) Last(ctx context.Context, typ filter.FilterType, name string) (HistoryRecord, error) {
+	if len(h.records) < 1 {
+		return HistoryRecord{}, ErrNoHistory
+	}
+
+	return h.records[len(h.records)-1], nil
+}
+
+func (h *testHistory) Remove(ctx context.Context, id int64) error {
+	h.records = slices.DeleteFunc(h.records, func(record HistoryRecord) bool { return record.ID == id })
+	return nil
+}
+
+func TestQueueRollback(t *testing.T) {
+	ctx := context.Background()
+	f := &testFilter{name: "FireHOL"}
+	history := &testHistory{}
+	q := newTestQueue(t, f)
+	q.SetHistory(history)
+
+	versions := [][]string{
+		{"1.1.1.1", "2.2.2.2"},
+		{"2.2.2.2", "3.3.3.3"},
+		{"3.3.3.3", "4.4.4.4", "5.5.5.5"},
+	}
+	for _, version := range versions {
+		f.update = version
+		if err := q.update(ctx, f); err != nil {
+			t.Fatalf("update: %s", err)
+		}
+	}
+
+	// NOTE: the first load has nothing to diff against
+	if len(history.records) != 2 {
+		t.Fatalf("recorded %d, want 2", len(history.records))
+	}
+	if record := history.records[0]; !slices.Equal(record.Added, []string{"3.3.3.3"}) || !slices.Equal(record.Removed, []string{"1.1.1.1"}) {
+		t.Errorf("first record %+v, want 3.3.3.3 added and 1.1.1.1 removed", record)
+	}
+
+	// walk back to the first version
+	for i := len(versions) - 2; i >= 0; i-- {
+		if err := q.Rollback(ctx, f.Type(), f.name); err != nil {
+			t.Fatalf("rollback: %s", err)
+		}
+		if !slices.Equal(f.entries, versions[i]) {
+			t.Errorf("rolled back to %v, want %v", f.entries, versions[i])
+		}
+		if len(history.records) != i {
+			t.Errorf("history size %d, want %d", len(history.records), i)
+		}
+	}
+
+	if err := q.Rollback(ctx, f.Type(), f.name); !errors.Is(err, ErrNoHistory) {
+		t.Errorf("rollback with no history: %v", err)
+	}
+	if !slices.Equal(f.entries, versions[0]) {
+		t.Errorf("entries %v changed with no history", f.entries)
+	}
+}
+
+func TestQueueRollbackFailed(t *testing.T) {
+	ctx := context.Background()
+	f := &testFilter{name: "FireHOL"}
+	q := newTestQueue(t, f)
+
+	if err := q.Rollback(ctx, f.Type(), "Missing"); !errors.Is(err, ErrFilterNotFound) {
+		t.Errorf("rollback missing: %v, want not found", err)
+	}
+	if err := q.Rollback(ctx, f.Type(), f.name); !errors.Is(err, ErrNoHistory) {
+		t.Errorf("rollback without history: %v, want no history", err)
+	}
+
+	q.SetHistory(&testHistory{})
+	q.statusOf(f).Updating = true
+	if err := q.Rollback(ctx, f.Type(), f.name); !errors.Is(err, ErrFilterBusy) {
+		t.Errorf("rollback while updating: %v, want busy", err)
+	}
+}
@@ -0,0 +1,107 @@
+package logger
+
+import (
+	"hash/maphash"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/logger/event"
+)
+
+// dedupShards spreads the packet workers over the locks
+const dedupShards = 64
+
+// dedup counts the repeated packet verdict events within the interval
+type dedup struct {
+	interval time.Duration
+	seed     maphash.Seed
+	shards   [dedupShards]dedupShard
+}
+
+type dedupShard struct {
+	mu      sync.Mutex
+	size    int
+	entries map[dedupKey]*dedupEntry
+}
+
+type dedupKey struct {
+	srcIP  netip.Addr
+	action event.ActionType
+	reason string
+	filter filter.FilterType
+}
+
+type dedupEntry struct {
+	level zerolog.Level
+	msg   string
+	// events not written
+	count uint64
+}
+
+func newDedup(interval time.Duration, size int) *dedup {
+	d := &dedup{
+		interval: interval,
+		seed:     maphash.MakeSeed(),
+	}
+	for i := range d.shards {
+		shard := &d.shards[i]
+		shard.size = max(1, size/dedupShards)
+		shard.entries = make(map[dedupKey]*dedupEntry)
+	}
+
+	return d
+}
+
+// observe returns true if the event must be written,
+// "sample" is checked for the first event of the interval only
+func (d *dedup) observe(verdict event.Verdict, sample func() bool) bool {
+	key := dedupKey{
+		srcIP:  verdict.SrcIP,
+		action: verdict.Action,
+		reason: verdict.Reason,
+		filter: verdict.Filter,
+	}
+
+	shard := &d.shards[maphash.Comparable(d.seed, key)%dedupShards]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if entry, ok := shard.entries[key]; ok {
+		entry.count++
+		return false
+	}
+
+	if !sample() {
+		return false
+	}
+
+	// NOTE: distinct events above the size are written as is
+	if len(shard.entries) < shard.size {
+		shard.entries[key] = &dedupEntry{level: verdict.Level, msg: verdict.Msg}
+	}
+
+	return true
+}
+
+// reset returns the interval counts and starts a new interval
+func (d *dedup) reset() map[dedupKey]*dedupEntry {
+	entries := make(map[dedupKey]*dedupEntry)
+	for i := range d.shards {
+		shard := &d.shards[i]
+
+		shard.mu.Lock()
+		curr := shard.entries
+		shard.entries = make(map[dedupKey]*dedupEntry, len(curr))
+		shard.mu.Unlock()
+
+		for key, entry := range curr {
+			entries[key] = entry
+		}
+	}
+
+	return entries
+}
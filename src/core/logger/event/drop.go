@@ -1,9 +1,6 @@
 package event
 
 import (
-	"strconv"
-	"strings"
-
 	"github.com/rs/zerolog"
 
 	"github.com/cnaize/meds/src/core/filter"
@@ -11,7 +8,10 @@ import (
 	"github.com/cnaize/meds/src/types"
 )
 
-var _ Sender = Drop{}
+var (
+	_ Sender   = Drop{}
+	_ Recorder = Drop{}
+)
 
 type Drop struct {
 	Message
@@ -32,35 +32,12 @@ func NewDrop(lvl zerolog.Level, msg, reason string, filter filter.FilterType, pa
 
 func (e Drop) Send(logger *zerolog.Logger) {
 	// handle metrics
-	defer func() {
-		metrics.Get().PacketsDroppedTotal.WithLabelValues(e.Reason, string(e.Filter)).Inc()
-		metrics.Get().PacketsProcessedTotal.Inc()
-	}()
+	defer e.Count()
 
 	if e.Packet != nil {
-		var target string
-		switch e.Filter {
-		case filter.FilterTypeIP, filter.FilterTypeRate:
-			if srcIP, ok := e.Packet.GetSrcIP(); ok {
-				target = srcIP.String()
-			}
-		case filter.FilterTypeGeo:
-			if asn, ok := e.Packet.GetASN(nil); ok {
-				target = asn.Country
-			}
-		case filter.FilterTypeASN:
-			if asn, ok := e.Packet.GetASN(nil); ok {
-				target = strconv.FormatUint(uint64(asn.ASN), 10)
-			}
-		case filter.FilterTypeDomain:
-			target = strings.Join(e.Packet.GetDomains(), ",")
-		case filter.FilterTypeJA3:
-			target, _ = e.Packet.GetJA3()
-		}
-
 		logger.
 			WithLevel(e.Lvl).
-			Str("target", target).
+			Str("target", target(e.Filter, e.Packet)).
 			Str("action", string(ActionTypeDrop)).
 			Str("reason", e.Reason).
 			Str("filter", string(e.Filter)).
@@ -77,3 +54,23 @@ func (e Drop) Send(logger *zerolog.Logger) {
 		Str("filter", string(e.Filter)).
 		Msg(e.Msg)
 }
+
+func (e Drop) Record() Record {
+	record := newRecord(e.Message, ActionTypeDrop, e.Reason, e.Filter, e.Packet)
+	// NOTE: the dropped packet isn't shared with other events, so it's safe to parse
+	if e.Packet != nil {
+		record.Domains = e.Packet.GetDomains()
+		record.JA3, _ = e.Packet.GetJA3()
+	}
+
+	return record
+}
+
+func (e Drop) Verdict() Verdict {
+	return newVerdict(e.Message, ActionTypeDrop, e.Reason, e.Filter, e.Packet)
+}
+
+func (e Drop) Count() {
+	metrics.Get().PacketsDroppedTotal.WithLabelValues(e.Reason, string(e.Filter)).Inc()
+	metrics.Get().PacketsProcessedTotal.Inc()
+}
@@ -0,0 +1,129 @@
+package event
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/types"
+)
+
+// Record is the packet verdict data
+type Record struct {
+	Level   zerolog.Level
+	Msg     string
+	Time    time.Time
+	Action  ActionType
+	Reason  string
+	Filter  filter.FilterType
+	Target  string
+	SrcIP   netip.Addr
+	DstPort uint16
+	// known if looked up by the geo/asn filters
+	Country string
+	ASN     uint32
+	// known for the dropped packets with dns or tls payload
+	Domains []string
+	JA3     string
+}
+
+// Verdict is the packet verdict summary, cheap enough to get on the packet path
+type Verdict struct {
+	Level  zerolog.Level
+	Msg    string
+	Action ActionType
+	Reason string
+	Filter filter.FilterType
+	SrcIP  netip.Addr
+}
+
+// Recorder is implemented by the packet verdict events
+type Recorder interface {
+	Sender
+	Verdict() Verdict
+	// Record parses the packet, so it's called off the packet path
+	Record() Record
+	// Count updates the verdict metrics without logging
+	Count()
+}
+
+// Verdict returns the record summary
+func (r Record) Verdict() Verdict {
+	return Verdict{
+		Level:  r.Level,
+		Msg:    r.Msg,
+		Action: r.Action,
+		Reason: r.Reason,
+		Filter: r.Filter,
+		SrcIP:  r.SrcIP,
+	}
+}
+
+func newVerdict(msg Message, action ActionType, reason string, typ filter.FilterType, packet *types.Packet) Verdict {
+	verdict := Verdict{
+		Level:  msg.Lvl,
+		Msg:    msg.Msg,
+		Action: action,
+		Reason: reason,
+		Filter: typ,
+	}
+	if packet != nil {
+		verdict.SrcIP, _ = packet.GetSrcIP()
+	}
+
+	return verdict
+}
+
+func newRecord(msg Message, action ActionType, reason string, typ filter.FilterType, packet *types.Packet) Record {
+	record := Record{
+		Level:  msg.Lvl,
+		Msg:    msg.Msg,
+		Time:   time.Now(),
+		Action: action,
+		Reason: reason,
+		Filter: typ,
+		Target: "empty packet",
+	}
+	if packet == nil {
+		return record
+	}
+
+	record.Target = target(typ, packet)
+	record.SrcIP, _ = packet.GetSrcIP()
+	record.DstPort, _ = packet.GetDstPort()
+	if asn, ok := packet.GetASN(nil); ok {
+		record.Country = strings.ToLower(asn.Country)
+		record.ASN = asn.ASN
+	}
+
+	return record
+}
+
+// target returns the packet part checked by the filter
+func target(typ filter.FilterType, packet *types.Packet) string {
+	var target string
+	switch typ {
+	case filter.FilterTypeIP, filter.FilterTypeRate:
+		if srcIP, ok := packet.GetSrcIP(); ok {
+			target = srcIP.String()
+		}
+	case filter.FilterTypeGeo:
+		if asn, ok := packet.GetASN(nil); ok {
+			target = asn.Country
+		}
+	case filter.FilterTypeASN:
+		if asn, ok := packet.GetASN(nil); ok {
+			target = strconv.FormatUint(uint64(asn.ASN), 10)
+		}
+	case filter.FilterTypeDomain:
+		target = strings.Join(packet.GetDomains(), ",")
+	case filter.FilterTypeJA3:
+		target, _ = packet.GetJA3()
+	}
+
+	return target
+}
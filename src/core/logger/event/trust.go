@@ -3,11 +3,15 @@ package event
 import (
 	"github.com/rs/zerolog"
 
+	"github.com/cnaize/meds/src/core/filter"
 	"github.com/cnaize/meds/src/core/metrics"
 	"github.com/cnaize/meds/src/types"
 )
 
-var _ Sender = Trust{}
+var (
+	_ Sender   = Trust{}
+	_ Recorder = Trust{}
+)
 
 type Trust struct {
 	Message
@@ -26,9 +30,7 @@ func NewTrust(lvl zerolog.Level, msg, reason string, packet *types.Packet) Trust
 
 func (e Trust) Send(logger *zerolog.Logger) {
 	// handle metrics
-	defer func() {
-		metrics.Get().TrustConnectionsTotal.WithLabelValues(e.Reason).Inc()
-	}()
+	defer e.Count()
 
 	if e.Packet != nil {
 		var target string
@@ -53,3 +55,15 @@ func (e Trust) Send(logger *zerolog.Logger) {
 		Str("reason", e.Reason).
 		Msg(e.Msg)
 }
+
+func (e Trust) Record() Record {
+	return newRecord(e.Message, ActionTypeTrust, e.Reason, filter.FilterTypeEmpty, e.Packet)
+}
+
+func (e Trust) Verdict() Verdict {
+	return newVerdict(e.Message, ActionTypeTrust, e.Reason, filter.FilterTypeEmpty, e.Packet)
+}
+
+func (e Trust) Count() {
+	metrics.Get().TrustConnectionsTotal.WithLabelValues(e.Reason).Inc()
+}
@@ -0,0 +1,208 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	productVendor = "cnaize"
+	productName   = "meds"
+)
+
+var productVersion = func() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+
+	return "dev"
+}()
+
+// cefKeys are the event fields mapped to the CEF extension keys
+var cefKeys = map[string]string{
+	"action": "act",
+	"reason": "reason",
+	"target": "cs1",
+	"filter": "cs2",
+}
+
+// cefLabels are the CEF custom fields labels
+var cefLabels = map[string]string{
+	"cs1": "target",
+	"cs2": "filter",
+}
+
+func consoleFormat(color bool) func(p []byte) ([]byte, error) {
+	return func(p []byte) ([]byte, error) {
+		var buf bytes.Buffer
+		w := zerolog.ConsoleWriter{Out: &buf, NoColor: !color}
+		if _, err := w.Write(p); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	}
+}
+
+// cefFormat converts the json event to the ArcSight Common Event Format
+func cefFormat(p []byte) ([]byte, error) {
+	e, err := parseEvent(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "CEF:0|%s|%s|%s|%s|%s|%d|",
+		cefHeader(productVendor),
+		cefHeader(productName),
+		cefHeader(productVersion),
+		cefHeader(e.id()),
+		cefHeader(e.message),
+		e.severity(),
+	)
+
+	ext := []string{"rt=" + strconv.FormatInt(e.time.UnixMilli(), 10)}
+	if len(e.message) > 0 {
+		ext = append(ext, "msg="+cefValue(e.message))
+	}
+	for _, key := range e.keys() {
+		extKey, ok := cefKeys[key]
+		if !ok {
+			extKey = key
+		}
+		ext = append(ext, extKey+"="+cefValue(e.fields[key]))
+		if label, ok := cefLabels[extKey]; ok {
+			ext = append(ext, extKey+"Label="+label)
+		}
+	}
+	buf.WriteString(strings.Join(ext, " "))
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
+}
+
+// leefFormat converts the json event to the IBM QRadar Log Event Extended Format
+func leefFormat(p []byte) ([]byte, error) {
+	e, err := parseEvent(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "LEEF:1.0|%s|%s|%s|%s|",
+		leefHeader(productVendor),
+		leefHeader(productName),
+		leefHeader(productVersion),
+		leefHeader(e.id()),
+	)
+
+	attrs := []string{
+		"devTime=" + strconv.FormatInt(e.time.UnixMilli(), 10),
+		"devTimeFormat=epoch",
+		"sev=" + strconv.Itoa(e.severity()),
+		"cat=" + leefValue(e.level.String()),
+	}
+	if len(e.message) > 0 {
+		attrs = append(attrs, "msg="+leefValue(e.message))
+	}
+	for _, key := range e.keys() {
+		attrs = append(attrs, key+"="+leefValue(e.fields[key]))
+	}
+	buf.WriteString(strings.Join(attrs, "\t"))
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
+}
+
+// logEvent is the parsed zerolog json event
+type logEvent struct {
+	level   zerolog.Level
+	time    time.Time
+	message string
+	fields  map[string]string
+}
+
+func parseEvent(p []byte) (logEvent, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(p, &raw); err != nil {
+		return logEvent{}, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	e := logEvent{
+		level:  zerolog.NoLevel,
+		time:   time.Now(),
+		fields: make(map[string]string, len(raw)),
+	}
+	for key, value := range raw {
+		str, ok := value.(string)
+		if !ok {
+			data, _ := json.Marshal(value)
+			str = string(data)
+		}
+
+		switch key {
+		case zerolog.LevelFieldName:
+			if level, err := zerolog.ParseLevel(str); err == nil {
+				e.level = level
+			}
+		case zerolog.TimestampFieldName:
+			if t, err := time.Parse(zerolog.TimeFieldFormat, str); err == nil {
+				e.time = t
+			}
+		case zerolog.MessageFieldName:
+			e.message = str
+		default:
+			e.fields[key] = str
+		}
+	}
+
+	return e, nil
+}
+
+// id is the event class: action for the packet events, level for the others
+func (e logEvent) id() string {
+	if action, ok := e.fields["action"]; ok {
+		return action
+	}
+
+	return e.level.String()
+}
+
+// severity is the event importance from 0 to 10
+func (e logEvent) severity() int {
+	switch e.level {
+	case zerolog.TraceLevel, zerolog.DebugLevel:
+		return 1
+	case zerolog.InfoLevel:
+		return 3
+	case zerolog.WarnLevel:
+		return 6
+	case zerolog.ErrorLevel:
+		return 8
+	case zerolog.FatalLevel, zerolog.PanicLevel:
+		return 10
+	default:
+		return 5
+	}
+}
+
+func (e logEvent) keys() []string {
+	return slices.Sorted(maps.Keys(e.fields))
+}
+
+// escapers of the CEF/LEEF header fields and extension values
+var (
+	cefHeader  = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ").Replace
+	cefValue   = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`).Replace
+	leefHeader = strings.NewReplacer(`|`, " ", "\n", " ", "\r", " ").Replace
+	leefValue  = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace
+)
@@ -2,21 +2,56 @@ package logger
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/rs/zerolog"
 
 	"github.com/cnaize/meds/src/core/logger/event"
+	"github.com/cnaize/meds/src/core/metrics"
 )
 
+// how often the suppressed events are reported if dedup is disabled
+const summaryInterval = time.Minute
+
+// Handler receives the packet verdict records, it must not block
+type Handler interface {
+	// Wants reports if the verdict record is taken (called once per verdict on the packet path)
+	Wants(verdict event.Verdict) bool
+	Handle(record event.Record)
+}
+
+// queued is the logger event, the verdict record is built by the logger goroutines
+type queued struct {
+	sender   event.Sender
+	recorder event.Recorder
+	write    bool
+	// the handlers taking the record by index
+	handlers *[]Handler
+	wanted   uint64
+}
+
 type Logger struct {
 	logger *zerolog.Logger
-	events chan event.Sender
+	events chan queued
+
+	mu       sync.Mutex
+	handlers atomic.Pointer[[]Handler]
+
+	// suppress the packet verdict events under load
+	nop     zerolog.Logger
+	dedup   *dedup
+	sampler zerolog.Sampler
+	sampled atomic.Uint64
+	dropped atomic.Uint64
 }
 
 func NewLogger(logger *zerolog.Logger, qlen uint) *Logger {
 	return &Logger{
 		logger: logger,
-		events: make(chan event.Sender, qlen),
+		events: make(chan queued, qlen),
+		nop:    zerolog.Nop(),
 	}
 }
 
@@ -24,28 +59,194 @@ func (l *Logger) Raw() *zerolog.Logger {
 	return l.logger
 }
 
+// AddHandler subscribes the handler to the verdict records (up to 64 handlers)
+func (l *Logger) AddHandler(handler Handler) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var handlers []Handler
+	if curr := l.handlers.Load(); curr != nil {
+		handlers = append(handlers, *curr...)
+	}
+	handlers = append(handlers, handler)
+
+	l.handlers.Store(&handlers)
+}
+
+// SetDedup collapses the verdict events repeated within the interval (by source ip, action, reason and filter)
+// into the summaries with counts, "size" limits the distinct events tracked (must be called before Log)
+func (l *Logger) SetDedup(interval time.Duration, size uint) {
+	l.dedup = newDedup(interval, int(size))
+}
+
+// SetSampler writes "burst" verdict events per second, then every "rate"-th (must be called before Log)
+func (l *Logger) SetSampler(burst, rate uint) {
+	sampler := zerolog.BurstSampler{
+		Burst:  uint32(burst),
+		Period: time.Second,
+	}
+	if rate > 0 {
+		sampler.NextSampler = &zerolog.BasicSampler{N: uint32(rate)}
+	}
+
+	l.sampler = &sampler
+}
+
 func (l *Logger) Run(ctx context.Context, workers uint) {
 	for range workers {
 		go l.sendLoop(ctx)
 	}
+
+	go l.summaryLoop(ctx)
 }
 
+// Log queues the event. The packet verdict events are checked for the level, deduplicated
+// and sampled by their cheap summary first, the ones neither written nor handled are only counted.
 func (l *Logger) Log(e event.Sender) {
+	q := queued{sender: e, write: true}
+	if recorder, ok := e.(event.Recorder); ok {
+		verdict := recorder.Verdict()
+		q.recorder = recorder
+		q.write = l.write(verdict)
+		q.handlers, q.wanted = l.wanted(verdict)
+		if !q.write && q.wanted == 0 {
+			recorder.Count()
+			return
+		}
+	}
+
 	select {
-	case l.events <- e:
+	case l.events <- q:
 		// good
 	default:
-		l.logger.Warn().Msgf("logger event dropped: %T", e)
+		// NOTE: reported periodically, logging here would slow down the caller
+		l.dropped.Add(1)
+		metrics.Get().ErrorsTotal.WithLabelValues("logger event dropped").Inc()
 	}
 }
 
 func (l *Logger) sendLoop(ctx context.Context) {
 	for {
 		select {
-		case e := <-l.events:
-			e.Send(l.logger)
+		case q := <-l.events:
+			l.send(q)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (l *Logger) send(q queued) {
+	if q.recorder == nil {
+		q.sender.Send(l.logger)
+		return
+	}
+
+	if q.write {
+		q.recorder.Send(l.logger)
+	} else {
+		q.recorder.Count()
+	}
+
+	if q.wanted == 0 {
+		return
+	}
+
+	record := q.recorder.Record()
+	for i, handler := range *q.handlers {
+		if q.wanted&(1<<i) != 0 {
+			handler.Handle(record)
+		}
+	}
+}
+
+// wanted returns the handlers with the mask of the ones taking the verdict record
+func (l *Logger) wanted(verdict event.Verdict) (*[]Handler, uint64) {
+	handlers := l.handlers.Load()
+	if handlers == nil {
+		return nil, 0
+	}
+
+	var wanted uint64
+	for i, handler := range *handlers {
+		if handler.Wants(verdict) {
+			wanted |= 1 << i
+		}
+	}
+
+	return handlers, wanted
+}
+
+// write decides whether the verdict event must be written
+func (l *Logger) write(verdict event.Verdict) bool {
+	if verdict.Level < zerolog.GlobalLevel() {
+		return false
+	}
+
+	sample := func() bool {
+		if l.sampler == nil || l.sampler.Sample(verdict.Level) {
+			return true
+		}
+
+		l.sampled.Add(1)
+		return false
+	}
+
+	if l.dedup == nil {
+		return sample()
+	}
+
+	return l.dedup.observe(verdict, sample)
+}
+
+func (l *Logger) summaryLoop(ctx context.Context) {
+	interval := summaryInterval
+	if l.dedup != nil {
+		interval = l.dedup.interval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			l.summarize()
 		case <-ctx.Done():
 			return
 		}
 	}
 }
+
+// summarize writes the suppressed events counts
+func (l *Logger) summarize() {
+	if l.dedup != nil {
+		for key, entry := range l.dedup.reset() {
+			if entry.count < 1 {
+				continue
+			}
+
+			e := l.logger.
+				WithLevel(entry.level).
+				Str("action", string(key.action)).
+				Str("reason", key.reason)
+			if key.srcIP.IsValid() {
+				e = e.Str("src_ip", key.srcIP.String())
+			}
+			if len(key.filter) > 0 {
+				e = e.Str("filter", string(key.filter))
+			}
+			e.
+				Uint64("repeated", entry.count).
+				Dur("interval", l.dedup.interval).
+				Msg(entry.msg)
+		}
+	}
+
+	if count := l.sampled.Swap(0); count > 0 {
+		l.logger.Warn().Uint64("count", count).Msg("logger events sampled out")
+	}
+	if count := l.dropped.Swap(0); count > 0 {
+		l.logger.Warn().Uint64("count", count).Msg("logger events dropped")
+	}
+}
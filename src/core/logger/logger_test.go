@@ -0,0 +1,136 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/cnaize/meds/src/core/logger/event"
+)
+
+type testHandler struct {
+	mu      sync.Mutex
+	action  event.ActionType
+	records []event.Record
+}
+
+func (h *testHandler) Wants(verdict event.Verdict) bool {
+	return verdict.Action == h.action
+}
+
+func (h *testHandler) Handle(record event.Record) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.records = append(h.records, record)
+}
+
+func newTestLogger(t *testing.T, qlen uint) (*Logger, *bytes.Buffer) {
+	t.Helper()
+
+	level := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	t.Cleanup(func() { zerolog.SetGlobalLevel(level) })
+
+	var buf bytes.Buffer
+	zl := zerolog.New(&buf)
+
+	return NewLogger(&zl, qlen), &buf
+}
+
+// drain sends the queued events like the logger goroutines do
+func drain(l *Logger) {
+	for {
+		select {
+		case q := <-l.events:
+			l.send(q)
+		default:
+			return
+		}
+	}
+}
+
+func TestLogBelowLevel(t *testing.T) {
+	l, buf := newTestLogger(t, 16)
+	handler := &testHandler{action: event.ActionTypeDrop}
+	l.AddHandler(handler)
+
+	for range 100 {
+		l.Log(event.NewAccept(zerolog.DebugLevel, "packet accepted", "default", "", nil))
+	}
+	if len(l.events) != 0 {
+		t.Fatalf("queued %d events below the level, want 0", len(l.events))
+	}
+
+	// NOTE: not written, but taken by the handler
+	l.Log(event.NewDrop(zerolog.DebugLevel, "packet dropped", "FireHOL", "ip", nil))
+	if len(l.events) != 1 {
+		t.Fatalf("queued %d handled events, want 1", len(l.events))
+	}
+	drain(l)
+
+	if buf.Len() != 0 {
+		t.Errorf("written %q, want nothing", buf.String())
+	}
+	if len(handler.records) != 1 || handler.records[0].Reason != "FireHOL" {
+		t.Errorf("handled %+v, want the dropped packet", handler.records)
+	}
+}
+
+func TestLogDedup(t *testing.T) {
+	l, buf := newTestLogger(t, 16)
+	l.SetDedup(time.Hour, 1000)
+
+	for range 1000 {
+		l.Log(event.NewDrop(zerolog.InfoLevel, "packet dropped", "FireHOL", "ip", nil))
+	}
+	l.Log(event.NewDrop(zerolog.InfoLevel, "packet dropped", "Spamhaus", "ip", nil))
+	if len(l.events) != 2 {
+		t.Fatalf("queued %d events, want 2", len(l.events))
+	}
+	drain(l)
+	l.summarize()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("written %d lines, want 3:\n%s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[2], `"repeated":999`) || !strings.Contains(lines[2], `"reason":"FireHOL"`) {
+		t.Errorf("summary %s, want 999 repeated FireHOL", lines[2])
+	}
+}
+
+func TestLogSampler(t *testing.T) {
+	l, buf := newTestLogger(t, 16)
+	l.SetSampler(2, 0)
+
+	for range 10 {
+		l.Log(event.NewDrop(zerolog.InfoLevel, "packet dropped", "FireHOL", "ip", nil))
+	}
+	if len(l.events) != 2 {
+		t.Fatalf("queued %d events, want 2", len(l.events))
+	}
+	drain(l)
+
+	if got := l.sampled.Load(); got != 8 {
+		t.Errorf("sampled out %d events, want 8", got)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 2 {
+		t.Errorf("written %d lines, want 2", lines)
+	}
+}
+
+func TestLogDropped(t *testing.T) {
+	l, _ := newTestLogger(t, 1)
+
+	l.Log(event.NewMessage(zerolog.InfoLevel, "first"))
+	l.Log(event.NewMessage(zerolog.InfoLevel, "second"))
+
+	if got := l.dropped.Load(); got != 1 {
+		t.Errorf("dropped %d events, want 1", got)
+	}
+}
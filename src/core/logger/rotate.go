@@ -0,0 +1,137 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// rotated files suffix
+const rotateTimeFormat = "20060102T150405.000"
+
+// rotatingFile renames the file by size or age and keeps the last "backups" renamed files
+type rotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	size     int64
+	interval time.Duration
+	backups  int
+
+	file     *os.File
+	written  int64
+	openedAt time.Time
+}
+
+func newRotatingFile(path string, size int64, interval time.Duration, backups int) (*rotatingFile, error) {
+	f := rotatingFile{
+		path:     path,
+		size:     size,
+		interval: interval,
+		backups:  backups,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	sizeExceeded := f.size > 0 && f.written > 0 && f.written+int64(len(p)) > f.size
+	intervalPassed := f.interval > 0 && time.Since(f.openedAt) >= f.interval
+	if sizeExceeded || intervalPassed {
+		if err := f.rotate(); err != nil {
+			return 0, fmt.Errorf("rotate: %w", err)
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.written += int64(n)
+
+	return n, err
+}
+
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+
+	return err
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return fmt.Errorf("open: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("stat: %w", err)
+	}
+
+	f.file = file
+	f.written = info.Size()
+	f.openedAt = time.Now()
+
+	return nil
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("close: %w", err)
+	}
+	f.file = nil
+
+	if err := os.Rename(f.path, f.path+"."+time.Now().Format(rotateTimeFormat)); err != nil {
+		return fmt.Errorf("rename: %w", err)
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	return f.prune()
+}
+
+// prune removes the oldest rotated files
+func (f *rotatingFile) prune() error {
+	if f.backups < 1 {
+		return nil
+	}
+
+	files, err := filepath.Glob(f.path + ".[0-9]*")
+	if err != nil {
+		return fmt.Errorf("glob: %w", err)
+	}
+	if len(files) <= f.backups {
+		return nil
+	}
+
+	// NOTE: the time suffix is sortable
+	slices.Sort(files)
+	for _, file := range files[:len(files)-f.backups] {
+		if err := os.Remove(file); err != nil {
+			return fmt.Errorf("remove: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type SinkType string
+
+const (
+	SinkTypeStdout SinkType = "stdout"
+	SinkTypeStderr SinkType = "stderr"
+	SinkTypeFile   SinkType = "file"
+	SinkTypeSyslog SinkType = "syslog"
+)
+
+type SinkFormat string
+
+const (
+	SinkFormatConsole SinkFormat = "console"
+	SinkFormatJSON    SinkFormat = "json"
+	SinkFormatCEF     SinkFormat = "cef"
+	SinkFormatLEEF    SinkFormat = "leef"
+)
+
+// Sink is the log destination config
+type Sink struct {
+	Type   SinkType   `yaml:"type"`
+	Format SinkFormat `yaml:"format"`
+	// Level is the sink min level (on top of the global one), all if empty
+	Level string `yaml:"level"`
+	// file
+	Path           string        `yaml:"path"`
+	RotateSize     uint          `yaml:"rotate-size"` // MB
+	RotateInterval time.Duration `yaml:"rotate-interval"`
+	MaxBackups     uint          `yaml:"max-backups"`
+	// syslog
+	Network  string `yaml:"network"` // udp, tcp or unix
+	Addr     string `yaml:"addr"`
+	Facility string `yaml:"facility"`
+}
+
+func (s Sink) Validate() error {
+	if len(s.Level) > 0 {
+		if _, err := zerolog.ParseLevel(s.Level); err != nil {
+			return fmt.Errorf("level: %w", err)
+		}
+	}
+
+	switch s.Format {
+	case SinkFormatConsole, SinkFormatJSON, SinkFormatCEF, SinkFormatLEEF:
+	default:
+		return fmt.Errorf("unknown format: %s", s.Format)
+	}
+
+	switch s.Type {
+	case SinkTypeStdout, SinkTypeStderr:
+	case SinkTypeFile:
+		if len(s.Path) < 1 {
+			return errors.New("empty path")
+		}
+	case SinkTypeSyslog:
+		if s.Format == SinkFormatConsole {
+			return errors.New("console format over syslog")
+		}
+		switch s.Network {
+		case "udp", "tcp", "unix":
+		default:
+			return fmt.Errorf("unknown network: %s", s.Network)
+		}
+		if len(s.Addr) < 1 {
+			return errors.New("empty addr")
+		}
+		if _, ok := syslogFacilities[s.Facility]; !ok && len(s.Facility) > 0 {
+			return fmt.Errorf("unknown facility: %s", s.Facility)
+		}
+	default:
+		return fmt.Errorf("unknown type: %s", s.Type)
+	}
+
+	return nil
+}
+
+// Writer writes the log events to every sink
+type Writer struct {
+	zerolog.LevelWriter
+
+	closers []io.Closer
+}
+
+func NewWriter(sinks []Sink) (*Writer, error) {
+	var w Writer
+	writers := make([]io.Writer, 0, len(sinks))
+	for _, sink := range sinks {
+		if err := sink.Validate(); err != nil {
+			w.Close()
+			return nil, fmt.Errorf("sink %s: validate: %w", sink.Type, err)
+		}
+
+		writer, err := newSinkWriter(sink)
+		if err != nil {
+			w.Close()
+			return nil, fmt.Errorf("sink %s: create: %w", sink.Type, err)
+		}
+
+		writers = append(writers, writer)
+		if writer.closer != nil {
+			w.closers = append(w.closers, writer.closer)
+		}
+	}
+	w.LevelWriter = zerolog.MultiLevelWriter(writers...)
+
+	return &w, nil
+}
+
+func (w *Writer) Close() error {
+	var errs []error
+	for _, closer := range w.closers {
+		errs = append(errs, closer.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// sinkWriter filters and formats the events for the sink destination
+type sinkWriter struct {
+	level  zerolog.Level
+	format func(p []byte) ([]byte, error)
+	out    zerolog.LevelWriter
+	closer io.Closer
+}
+
+func newSinkWriter(sink Sink) (*sinkWriter, error) {
+	w := sinkWriter{level: zerolog.TraceLevel}
+	if len(sink.Level) > 0 {
+		w.level, _ = zerolog.ParseLevel(sink.Level)
+	}
+
+	switch sink.Type {
+	case SinkTypeStdout:
+		w.out = zerolog.LevelWriterAdapter{Writer: os.Stdout}
+	case SinkTypeStderr:
+		w.out = zerolog.LevelWriterAdapter{Writer: os.Stderr}
+	case SinkTypeFile:
+		file, err := newRotatingFile(sink.Path, int64(sink.RotateSize)<<20, sink.RotateInterval, int(sink.MaxBackups))
+		if err != nil {
+			return nil, fmt.Errorf("open file: %w", err)
+		}
+		w.out = zerolog.LevelWriterAdapter{Writer: file}
+		w.closer = file
+	case SinkTypeSyslog:
+		syslog := newSyslogWriter(sink.Network, sink.Addr, sink.Facility)
+		w.out = syslog
+		w.closer = syslog
+	}
+
+	switch sink.Format {
+	case SinkFormatConsole:
+		w.format = consoleFormat(sink.Type != SinkTypeFile)
+	case SinkFormatCEF:
+		w.format = cefFormat
+	case SinkFormatLEEF:
+		w.format = leefFormat
+	}
+
+	return &w, nil
+}
+
+func (w *sinkWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (w *sinkWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if level < w.level {
+		return len(p), nil
+	}
+
+	data := p
+	if w.format != nil {
+		var err error
+		if data, err = w.format(p); err != nil {
+			return 0, fmt.Errorf("format: %w", err)
+		}
+	}
+
+	if _, err := w.out.WriteLevel(level, data); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
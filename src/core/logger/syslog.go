@@ -0,0 +1,193 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	syslogDialTimeout  = 5 * time.Second
+	syslogWriteTimeout = 5 * time.Second
+	// events are discarded till the next dial
+	syslogRetryInterval = 10 * time.Second
+)
+
+var syslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// syslogWriter sends the events as RFC5424 messages,
+// stream connections use the octet counting framing (RFC6587)
+type syslogWriter struct {
+	mu       sync.Mutex
+	network  string
+	addr     string
+	facility int
+	hostname string
+	pid      string
+
+	conn    net.Conn
+	stream  bool
+	retryAt time.Time
+}
+
+func newSyslogWriter(network, addr, facility string) *syslogWriter {
+	hostname, err := os.Hostname()
+	if err != nil || len(hostname) < 1 {
+		hostname = "-"
+	}
+
+	code, ok := syslogFacilities[facility]
+	if !ok {
+		code = syslogFacilities["daemon"]
+	}
+
+	// NOTE: connected lazily, so the daemon starts without the syslog server
+	return &syslogWriter{
+		network:  network,
+		addr:     addr,
+		facility: code,
+		hostname: hostname,
+		pid:      strconv.Itoa(os.Getpid()),
+	}
+}
+
+func (w *syslogWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (w *syslogWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	msg := w.message(level, bytes.TrimRight(p, "\n"))
+
+	// reconnect once on failure
+	var err error
+	for range 2 {
+		if w.conn == nil {
+			// NOTE: don't stall the logger while the server is down
+			if time.Now().Before(w.retryAt) {
+				return 0, fmt.Errorf("server is down, retry at %s", w.retryAt.Format(time.TimeOnly))
+			}
+			if err = w.dial(); err != nil {
+				w.retryAt = time.Now().Add(syslogRetryInterval)
+				return 0, err
+			}
+		}
+
+		data := msg
+		if w.stream {
+			data = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+		}
+
+		w.conn.SetWriteDeadline(time.Now().Add(syslogWriteTimeout))
+		if _, err = w.conn.Write(data); err == nil {
+			return len(p), nil
+		}
+
+		w.conn.Close()
+		w.conn = nil
+	}
+
+	return 0, fmt.Errorf("send: %w", err)
+}
+
+func (w *syslogWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+
+	err := w.conn.Close()
+	w.conn = nil
+
+	return err
+}
+
+func (w *syslogWriter) dial() error {
+	switch w.network {
+	case "unix":
+		// NOTE: local syslog daemons usually listen on a datagram socket
+		conn, err := net.DialTimeout("unixgram", w.addr, syslogDialTimeout)
+		if err == nil {
+			w.conn, w.stream = conn, false
+			return nil
+		}
+
+		if conn, err = net.DialTimeout("unix", w.addr, syslogDialTimeout); err != nil {
+			return fmt.Errorf("dial: %w", err)
+		}
+		w.conn, w.stream = conn, true
+	default:
+		conn, err := net.DialTimeout(w.network, w.addr, syslogDialTimeout)
+		if err != nil {
+			return fmt.Errorf("dial: %w", err)
+		}
+		w.conn, w.stream = conn, w.network == "tcp"
+	}
+
+	return nil
+}
+
+// message formats the RFC5424 message: "<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID SD MSG"
+func (w *syslogWriter) message(level zerolog.Level, p []byte) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<%d>1 %s %s %s %s - - ",
+		w.facility*8+syslogSeverity(level),
+		time.Now().Format(time.RFC3339Nano),
+		w.hostname,
+		productName,
+		w.pid,
+	)
+	buf.Write(p)
+
+	return buf.Bytes()
+}
+
+func syslogSeverity(level zerolog.Level) int {
+	switch level {
+	case zerolog.PanicLevel:
+		return 0 // emergency
+	case zerolog.FatalLevel:
+		return 2 // critical
+	case zerolog.ErrorLevel:
+		return 3 // error
+	case zerolog.WarnLevel:
+		return 4 // warning
+	case zerolog.InfoLevel:
+		return 6 // informational
+	case zerolog.DebugLevel, zerolog.TraceLevel:
+		return 7 // debug
+	default:
+		return 5 // notice
+	}
+}
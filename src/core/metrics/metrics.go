@@ -12,6 +12,14 @@ type Metrics struct {
 	TrustConnectionsTotal *prometheus.CounterVec
 	ErrorsTotal           *prometheus.CounterVec
 	RateLimiterCacheStats *stats.Counter
+	// filter updates
+	FilterLastAttempt    *prometheus.GaugeVec
+	FilterLastSuccess    *prometheus.GaugeVec
+	FilterUpdateDuration *prometheus.GaugeVec
+	FilterUpdateFailed   *prometheus.GaugeVec
+	FilterEntries        *prometheus.GaugeVec
+	FilterBytes          *prometheus.GaugeVec
+	FilterEnabled        *prometheus.GaugeVec
 }
 
 var metrics *Metrics
@@ -63,6 +71,69 @@ func init() {
 			[]string{"error"},
 		),
 		RateLimiterCacheStats: stats.NewCounter(),
+		FilterLastAttempt: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "meds",
+				Subsystem: "core",
+				Name:      "filter_last_attempt_timestamp_seconds",
+				Help:      "Last filter update attempt time",
+			},
+			[]string{"name", "type"},
+		),
+		FilterLastSuccess: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "meds",
+				Subsystem: "core",
+				Name:      "filter_last_success_timestamp_seconds",
+				Help:      "Last successful filter update time",
+			},
+			[]string{"name", "type"},
+		),
+		FilterUpdateDuration: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "meds",
+				Subsystem: "core",
+				Name:      "filter_update_duration_seconds",
+				Help:      "Last filter update duration",
+			},
+			[]string{"name", "type"},
+		),
+		FilterUpdateFailed: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "meds",
+				Subsystem: "core",
+				Name:      "filter_update_failed",
+				Help:      "Whether the last filter update failed",
+			},
+			[]string{"name", "type"},
+		),
+		FilterEntries: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "meds",
+				Subsystem: "core",
+				Name:      "filter_entries",
+				Help:      "Number of filter list entries",
+			},
+			[]string{"name", "type"},
+		),
+		FilterBytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "meds",
+				Subsystem: "core",
+				Name:      "filter_downloaded_bytes",
+				Help:      "Bytes downloaded by the last filter update",
+			},
+			[]string{"name", "type"},
+		),
+		FilterEnabled: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "meds",
+				Subsystem: "core",
+				Name:      "filter_enabled",
+				Help:      "Whether the filter is enabled",
+			},
+			[]string{"name", "type"},
+		),
 	}
 }
 
@@ -76,4 +147,11 @@ func (m *Metrics) Register(reg *prometheus.Registry) {
 	reg.MustRegister(m.PacketsProcessedTotal)
 	reg.MustRegister(m.TrustConnectionsTotal)
 	reg.MustRegister(m.ErrorsTotal)
+	reg.MustRegister(m.FilterLastAttempt)
+	reg.MustRegister(m.FilterLastSuccess)
+	reg.MustRegister(m.FilterUpdateDuration)
+	reg.MustRegister(m.FilterUpdateFailed)
+	reg.MustRegister(m.FilterEntries)
+	reg.MustRegister(m.FilterBytes)
+	reg.MustRegister(m.FilterEnabled)
 }
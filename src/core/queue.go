@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/coreos/go-iptables/iptables"
 	"github.com/rs/zerolog"
+	"golang.org/x/sync/semaphore"
 
 	"github.com/cnaize/meds/src/core/filter"
 	"github.com/cnaize/meds/src/core/logger"
@@ -18,18 +22,59 @@ import (
 
 const ConnMark uint32 = 0x100000
 
+// min memory weight of a filter update
+const minUpdateWeight = 1 << 20
+
+var ErrFilterNotFound = errors.New("filter not found")
+
 type Queue struct {
 	qcount uint
 	wcount uint
 
-	logger  *logger.Logger
-	filters []filter.Filter
+	logger *logger.Logger
+	// all filters (pipeline order)
+	pipeline atomic.Pointer[[]filter.Filter]
+	// enabled filters (used by workers)
+	filters atomic.Pointer[[]filter.Filter]
+
+	timeout  atomic.Int64
+	interval atomic.Int64
+
+	// update limits
+	parallel *semaphore.Weighted
+	memory   *semaphore.Weighted
+	budget   int64
+	wake     chan struct{}
+
+	// last update status per filter
+	mu     sync.Mutex
+	status map[filter.Filter]*FilterStatus
+	// disabled filters by "type/name"
+	disabled map[string]bool
+	// filter list changes (optional)
+	history History
 
 	readers []*Reader
 	workers []*Worker
 }
 
-func NewQueue(qcount uint, wcount uint, qlen uint, filters []filter.Filter, logger *logger.Logger) *Queue {
+// NewQueue creates a queue, filters are updated by "parallel" goroutines
+// within the "memory" budget (bytes, estimated by the raw lists size)
+func NewQueue(qcount uint, wcount uint, qlen uint, parallel uint, memory uint64, filters []filter.Filter, logger *logger.Logger) *Queue {
+	q := &Queue{
+		qcount:   qcount,
+		wcount:   wcount,
+		logger:   logger,
+		parallel: semaphore.NewWeighted(int64(max(1, parallel))),
+		memory:   semaphore.NewWeighted(int64(max(minUpdateWeight, memory))),
+		budget:   int64(max(minUpdateWeight, memory)),
+		wake:     make(chan struct{}, 1),
+		status:   make(map[filter.Filter]*FilterStatus),
+		disabled: make(map[string]bool),
+	}
+	q.pipeline.Store(&filters)
+	q.publish()
+
 	readers := make([]*Reader, 0, qcount)
 	workers := make([]*Worker, 0, qcount*wcount)
 	// WARNING: always balancing NFQUEUE from 0
@@ -39,24 +84,29 @@ func NewQueue(qcount uint, wcount uint, qlen uint, filters []filter.Filter, logg
 
 		// workers per reader
 		for range wcount {
-			workers = append(workers, NewWorker(filters, logger))
+			workers = append(workers, NewWorker(&q.filters, logger))
 		}
 	}
 
-	return &Queue{
-		qcount:  qcount,
-		wcount:  wcount,
-		logger:  logger,
-		filters: filters,
-		readers: readers,
-		workers: workers,
-	}
+	q.readers = readers
+	q.workers = workers
+
+	return q
+}
+
+func (q *Queue) Filters() []filter.Filter {
+	return *q.pipeline.Load()
+}
+
+func (q *Queue) SetSchedule(timeout, interval time.Duration) {
+	q.timeout.Store(int64(timeout))
+	q.interval.Store(int64(interval))
 }
 
 func (q *Queue) Load(ctx context.Context) error {
 	q.logger.Raw().Info().Msg("Loading queue...")
 
-	for _, filter := range q.filters {
+	for _, filter := range q.Filters() {
 		if err := filter.Load(ctx); err != nil {
 			return fmt.Errorf("%s (%s): filter load: %w", filter.Name(), filter.Type(), err)
 		}
@@ -65,6 +115,58 @@ func (q *Queue) Load(ctx context.Context) error {
 	return nil
 }
 
+// Reload loads and updates new filters, then swaps them in atomically.
+// Already running filters are kept as is, nothing is swapped on error.
+// Readers, workers and iptables rules are left untouched.
+func (q *Queue) Reload(ctx context.Context, filters []filter.Filter) error {
+	q.logger.Raw().Info().Msg("Reloading queue...")
+
+	running := q.Filters()
+	added := make([]filter.Filter, 0, len(filters))
+	for _, filter := range filters {
+		if !slices.Contains(running, filter) {
+			added = append(added, filter)
+		}
+	}
+
+	for _, filter := range added {
+		if err := filter.Load(ctx); err != nil {
+			return fmt.Errorf("%s (%s): filter load: %w", filter.Name(), filter.Type(), err)
+		}
+	}
+
+	var mu sync.Mutex
+	var errs error
+	var wg sync.WaitGroup
+	for _, filter := range added {
+		q.begin(filter, true)
+		wg.Go(func() {
+			if err := q.update(ctx, filter); err != nil {
+				mu.Lock()
+				errs = errors.Join(errs, fmt.Errorf("%s (%s): filter update: %w", filter.Name(), filter.Type(), err))
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+
+	// NOTE: keep the running filters instead of the empty or stale ones
+	if errs != nil {
+		q.forget(running)
+		return errs
+	}
+
+	q.mu.Lock()
+	q.pipeline.Store(&filters)
+	q.publish()
+	q.mu.Unlock()
+
+	// forget removed filters
+	q.forget(filters)
+
+	return nil
+}
+
 func (q *Queue) Run(ctx context.Context) error {
 	q.logger.Raw().Info().Msg("Running queue...")
 
@@ -97,33 +199,197 @@ func (q *Queue) Run(ctx context.Context) error {
 	return nil
 }
 
-func (q *Queue) Update(ctx context.Context, timeout, interval time.Duration) {
-	for {
-		q.logger.Raw().Info().Msg("Updating queue...")
+// Update runs due filter updates till the context is done
+func (q *Queue) Update(ctx context.Context) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
 
-		// update filters
-		for _, filter := range q.filters {
-			func() {
-				// timeout is per filter
-				ctx, cancel := context.WithTimeout(ctx, timeout)
-				defer cancel()
+	for {
+		select {
+		case <-timer.C:
+		case <-q.wake:
+		case <-ctx.Done():
+			return
+		}
 
-				if err := filter.Update(ctx); err != nil {
-					msg := "filter update failed"
+		// update due filters
+		for _, filter := range q.Filters() {
+			if !q.begin(filter, false) {
+				continue
+			}
 
-					metrics.Get().ErrorsTotal.WithLabelValues(msg).Inc()
-					q.logger.Raw().
-						Error().
-						Err(err).
-						Str("name", filter.Name()).
-						Str("type", string(filter.Type())).
-						Msg(msg)
-				}
-			}()
+			// NOTE: the errors are reported by status
+			wg.Go(func() {
+				q.update(ctx, filter)
+			})
 		}
 
 		// sleep
-		time.Sleep(interval)
+		timer.Reset(min(time.Minute, time.Duration(q.interval.Load())))
+	}
+}
+
+// Trigger schedules an immediate update of the filter
+func (q *Queue) Trigger(typ filter.FilterType, name string) error {
+	filters := q.Filters()
+	i := slices.IndexFunc(filters, func(f filter.Filter) bool {
+		return f.Type() == typ && f.Name() == name
+	})
+	if i < 0 {
+		return ErrFilterNotFound
+	}
+
+	q.mu.Lock()
+	q.statusOf(filters[i]).forced = true
+	q.mu.Unlock()
+
+	q.notify()
+
+	return nil
+}
+
+// SetEnabled enables or disables the filter,
+// the state is kept for filters with the same type and name across reloads
+func (q *Queue) SetEnabled(typ filter.FilterType, name string, enabled bool) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !slices.ContainsFunc(q.Filters(), func(f filter.Filter) bool {
+		return f.Type() == typ && f.Name() == name
+	}) {
+		return ErrFilterNotFound
+	}
+
+	if enabled {
+		delete(q.disabled, filterKey(typ, name))
+	} else {
+		q.disabled[filterKey(typ, name)] = true
+	}
+	q.publish()
+
+	return nil
+}
+
+// publish stores enabled filters for workers (must be called under lock)
+func (q *Queue) publish() {
+	pipeline := q.Filters()
+	filters := make([]filter.Filter, 0, len(pipeline))
+	for _, f := range pipeline {
+		enabled := !q.disabled[filterKey(f.Type(), f.Name())]
+		if enabled {
+			filters = append(filters, f)
+		}
+
+		value := 0.0
+		if enabled {
+			value = 1
+		}
+		metrics.Get().FilterEnabled.WithLabelValues(f.Name(), string(f.Type())).Set(value)
+	}
+
+	q.filters.Store(&filters)
+}
+
+func filterKey(typ filter.FilterType, name string) string {
+	return string(typ) + "/" + name
+}
+
+// begin marks the filter as updating if it's due (or forced)
+func (q *Queue) begin(f filter.Filter, force bool) bool {
+	interval := time.Duration(q.interval.Load())
+	if scheduler, ok := f.(filter.Scheduler); ok && scheduler.Interval() > 0 {
+		interval = scheduler.Interval()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	status := q.statusOf(f)
+	if status.Updating {
+		return false
+	}
+
+	due := status.LastAttempt.IsZero() || time.Since(status.LastAttempt) >= interval
+	if !force && !status.forced && !due {
+		return false
+	}
+
+	status.Updating = true
+	status.forced = false
+
+	return true
+}
+
+func (q *Queue) update(ctx context.Context, filter filter.Filter) (err error) {
+	start := time.Now()
+	defer func() {
+		q.observe(filter, start, err)
+	}()
+
+	// wait for a free slot
+	weight := q.weight(filter)
+	if err = q.parallel.Acquire(ctx, 1); err != nil {
+		return err
+	}
+	defer q.parallel.Release(1)
+	if err = q.memory.Acquire(ctx, weight); err != nil {
+		return err
+	}
+	defer q.memory.Release(weight)
+
+	// timeout is per filter
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(q.timeout.Load()))
+	defer cancel()
+
+	before := q.entriesOf(filter)
+
+	start = time.Now()
+	if err = filter.Update(ctx); err != nil {
+		msg := "filter update failed"
+
+		metrics.Get().ErrorsTotal.WithLabelValues(msg).Inc()
+		q.logger.Raw().
+			Error().
+			Err(err).
+			Str("name", filter.Name()).
+			Str("type", string(filter.Type())).
+			Msg(msg)
+		return err
+	}
+
+	// NOTE: history is not critical for the update
+	if err := q.record(ctx, filter, before); err != nil {
+		msg := "filter history record failed"
+
+		metrics.Get().ErrorsTotal.WithLabelValues(msg).Inc()
+		q.logger.Raw().
+			Error().
+			Err(err).
+			Str("name", filter.Name()).
+			Str("type", string(filter.Type())).
+			Msg(msg)
+	}
+
+	return nil
+}
+
+// weight estimates the filter update memory by its raw lists size
+func (q *Queue) weight(f filter.Filter) int64 {
+	weight := int64(minUpdateWeight)
+	if reporter, ok := f.(filter.Reporter); ok {
+		weight = max(weight, int64(reporter.Stats().Size))
+	}
+
+	return min(weight, q.budget)
+}
+
+func (q *Queue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
 	}
 }
 
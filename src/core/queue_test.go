@@ -0,0 +1,142 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/types"
+)
+
+// testFilter is the filter with the list replaced by every update
+type testFilter struct {
+	name      string
+	update    []string
+	loadErr   error
+	updateErr error
+
+	loads   int
+	updates int
+	entries []string
+}
+
+func (f *testFilter) Name() string                    { return f.name }
+func (f *testFilter) Type() filter.FilterType         { return filter.FilterTypeIP }
+func (f *testFilter) Check(packet *types.Packet) bool { return true }
+func (f *testFilter) Entries() []string               { return slices.Clone(f.entries) }
+
+func (f *testFilter) Load(ctx context.Context) error {
+	f.loads++
+	return f.loadErr
+}
+
+func (f *testFilter) Update(ctx context.Context) error {
+	f.updates++
+	if f.updateErr != nil {
+		return f.updateErr
+	}
+
+	f.entries = slices.Clone(f.update)
+	return nil
+}
+
+func (f *testFilter) Restore(entries []string) error {
+	slices.Sort(entries)
+	f.entries = entries
+	return nil
+}
+
+func newTestQueue(t *testing.T, filters ...filter.Filter) *Queue {
+	t.Helper()
+
+	nop := zerolog.Nop()
+	q := NewQueue(1, 1, 1, 2, 0, filters, logger.NewLogger(&nop, 1))
+	q.SetSchedule(time.Minute, time.Hour)
+
+	return q
+}
+
+func TestQueueReload(t *testing.T) {
+	running := &testFilter{name: "Running"}
+	removed := &testFilter{name: "Removed"}
+	q := newTestQueue(t, running, removed)
+
+	added := &testFilter{name: "Added", update: []string{"1.2.3.4"}}
+	if err := q.Reload(context.Background(), []filter.Filter{running, added}); err != nil {
+		t.Fatalf("reload: %s", err)
+	}
+
+	if got := q.Filters(); !slices.Equal(got, []filter.Filter{running, added}) {
+		t.Errorf("filters %v, want running and added", got)
+	}
+	// NOTE: the running filters are kept as is
+	if running.loads != 0 || running.updates != 0 {
+		t.Errorf("running filter loaded %d and updated %d times", running.loads, running.updates)
+	}
+	if added.loads != 1 || added.updates != 1 || !slices.Equal(added.entries, added.update) {
+		t.Errorf("added filter loaded %d and updated %d times: %v", added.loads, added.updates, added.entries)
+	}
+	if slices.ContainsFunc(q.Status(), func(status FilterStatus) bool { return status.Name == removed.name }) {
+		t.Errorf("removed filter status kept")
+	}
+}
+
+func TestQueueReloadFailed(t *testing.T) {
+	tests := []struct {
+		name   string
+		failed *testFilter
+	}{
+		{name: "load", failed: &testFilter{name: "Failed", loadErr: errors.New("load failed")}},
+		{name: "update", failed: &testFilter{name: "Failed", updateErr: errors.New("update failed")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			running := &testFilter{name: "Running"}
+			q := newTestQueue(t, running)
+
+			added := &testFilter{name: "Added"}
+			if err := q.Reload(context.Background(), []filter.Filter{added, tt.failed}); err == nil {
+				t.Fatalf("reloaded with the failed filter")
+			}
+
+			// NOTE: nothing is swapped, the new filters are forgotten
+			if got := q.Filters(); !slices.Equal(got, []filter.Filter{running}) {
+				t.Errorf("filters %v, want the running one", got)
+			}
+			if statuses := q.Status(); len(statuses) != 1 || statuses[0].Name != running.name {
+				t.Errorf("statuses %+v, want the running one", statuses)
+			}
+		})
+	}
+}
+
+func TestQueueSetEnabled(t *testing.T) {
+	f := &testFilter{name: "FireHOL"}
+	q := newTestQueue(t, f)
+
+	if err := q.SetEnabled(filter.FilterTypeIP, "Missing", false); !errors.Is(err, ErrFilterNotFound) {
+		t.Errorf("set missing: %v, want not found", err)
+	}
+
+	if err := q.SetEnabled(filter.FilterTypeIP, f.name, false); err != nil {
+		t.Fatalf("disable: %s", err)
+	}
+	if got := *q.filters.Load(); len(got) != 0 {
+		t.Errorf("published %v, want none", got)
+	}
+
+	// NOTE: the state is kept across reloads
+	if err := q.Reload(context.Background(), []filter.Filter{&testFilter{name: f.name}}); err != nil {
+		t.Fatalf("reload: %s", err)
+	}
+	if got := *q.filters.Load(); len(got) != 0 {
+		t.Errorf("published %v after reload, want none", got)
+	}
+}
@@ -0,0 +1,188 @@
+package stats
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/core/logger/event"
+	"github.com/cnaize/meds/src/types"
+)
+
+var (
+	ErrUnknownWindow = errors.New("unknown window")
+	ErrUnknownKind   = errors.New("unknown kind")
+)
+
+type Window string
+
+const (
+	WindowHour Window = "hour"
+	WindowDay  Window = "day"
+)
+
+type Kind string
+
+const (
+	KindIP      Kind = "ip"
+	KindSubnet  Kind = "subnet"
+	KindASN     Kind = "asn"
+	KindCountry Kind = "country"
+	KindDomain  Kind = "domain"
+	KindJA3     Kind = "ja3"
+	KindPort    Kind = "port"
+)
+
+// Kinds are the counted dropped packets parts
+var Kinds = []Kind{KindIP, KindSubnet, KindASN, KindCountry, KindDomain, KindJA3, KindPort}
+
+// subnets the source ips are grouped by
+const (
+	subnetBitsV4 = 24
+	subnetBitsV6 = 48
+)
+
+var _ logger.Handler = (*Top)(nil)
+
+// Top approximately counts the most frequent dropped packets parts
+// over the sliding windows (space-saving sketch per kind and window bucket)
+type Top struct {
+	windows map[Window]*window
+}
+
+func NewTop(size int) *Top {
+	return &Top{
+		windows: map[Window]*window{
+			WindowHour: newWindow(5*time.Minute, 12, size),
+			WindowDay:  newWindow(time.Hour, 24, size),
+		},
+	}
+}
+
+// Wants takes the dropped packets only
+func (t *Top) Wants(verdict event.Verdict) bool {
+	return verdict.Action == event.ActionTypeDrop
+}
+
+func (t *Top) Handle(record event.Record) {
+	for _, w := range t.windows {
+		w.observe(record)
+	}
+}
+
+// Get returns up to "n" most frequent keys of the kind over the window
+func (t *Top) Get(window Window, kind Kind, n int) ([]types.Count, error) {
+	w, ok := t.windows[window]
+	if !ok {
+		return nil, ErrUnknownWindow
+	}
+	if !slices.Contains(Kinds, kind) {
+		return nil, ErrUnknownKind
+	}
+
+	return w.top(time.Now(), kind, n), nil
+}
+
+// window is the ring of the sketches buckets
+type window struct {
+	mu      sync.Mutex
+	span    time.Duration
+	size    int
+	buckets []bucket
+}
+
+type bucket struct {
+	// bucket number since epoch
+	id       int64
+	counters map[Kind]*types.TopCounter
+}
+
+func newWindow(span time.Duration, count, size int) *window {
+	return &window{
+		span:    span,
+		size:    size,
+		buckets: make([]bucket, count),
+	}
+}
+
+func (w *window) observe(record event.Record) {
+	id := record.Time.UnixNano() / int64(w.span)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	b := &w.buckets[id%int64(len(w.buckets))]
+	if id < b.id {
+		// too late, the bucket is reused
+		return
+	}
+	if id > b.id || b.counters == nil {
+		b.id = id
+		b.counters = make(map[Kind]*types.TopCounter, len(Kinds))
+		for _, kind := range Kinds {
+			b.counters[kind] = types.NewTopCounter(w.size)
+		}
+	}
+
+	if record.SrcIP.IsValid() {
+		srcIP := record.SrcIP.Unmap()
+		b.counters[KindIP].Observe(srcIP.String())
+
+		bits := subnetBitsV4
+		if srcIP.Is6() {
+			bits = subnetBitsV6
+		}
+		subnet, _ := srcIP.Prefix(bits)
+		b.counters[KindSubnet].Observe(subnet.String())
+	}
+	if record.ASN > 0 {
+		b.counters[KindASN].Observe(strconv.FormatUint(uint64(record.ASN), 10))
+	}
+	if len(record.Country) > 0 {
+		b.counters[KindCountry].Observe(record.Country)
+	}
+	for _, domain := range record.Domains {
+		b.counters[KindDomain].Observe(domain)
+	}
+	if len(record.JA3) > 0 {
+		b.counters[KindJA3].Observe(record.JA3)
+	}
+	if record.DstPort > 0 {
+		b.counters[KindPort].Observe(strconv.FormatUint(uint64(record.DstPort), 10))
+	}
+}
+
+// top merges the window buckets counts
+func (w *window) top(now time.Time, kind Kind, n int) []types.Count {
+	id := now.UnixNano() / int64(w.span)
+
+	var counters []*types.TopCounter
+	w.mu.Lock()
+	for _, b := range w.buckets {
+		if b.counters != nil && b.id <= id && b.id > id-int64(len(w.buckets)) {
+			counters = append(counters, b.counters[kind])
+		}
+	}
+	w.mu.Unlock()
+
+	merged := make(map[string]uint64)
+	for _, counter := range counters {
+		for _, count := range counter.Top(w.size) {
+			merged[count.Key] += count.Count
+		}
+	}
+
+	counts := make([]types.Count, 0, len(merged))
+	for key, count := range merged {
+		counts = append(counts, types.Count{Key: key, Count: count})
+	}
+	slices.SortFunc(counts, func(a, b types.Count) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Key, b.Key))
+	})
+
+	return counts[:min(max(0, n), len(counts))]
+}
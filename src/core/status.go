@@ -0,0 +1,137 @@
+package core
+
+import (
+	"slices"
+	"time"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/metrics"
+)
+
+type FilterStatus struct {
+	Name        string
+	Type        filter.FilterType
+	LastAttempt time.Time
+	LastSuccess time.Time
+	// last update duration
+	Duration time.Duration
+	Entries  int
+	// downloaded by the last update
+	Bytes int
+	// last update error
+	Error    string
+	Updating bool
+	Enabled  bool
+
+	// update requested
+	forced bool
+}
+
+// Status returns the filters status in the pipeline order
+func (q *Queue) Status() []FilterStatus {
+	filters := q.Filters()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	statuses := make([]FilterStatus, 0, len(filters))
+	for _, f := range filters {
+		status := FilterStatus{
+			Name: f.Name(),
+			Type: f.Type(),
+		}
+		if curr, ok := q.status[f]; ok {
+			status = *curr
+		}
+		status.Enabled = !q.disabled[filterKey(f.Type(), f.Name())]
+
+		if reporter, ok := f.(filter.Reporter); ok {
+			stats := reporter.Stats()
+			status.Entries = stats.Entries
+			status.Bytes = stats.Bytes
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+// observe records the filter update result
+func (q *Queue) observe(f filter.Filter, start time.Time, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	status := q.statusOf(f)
+	status.Updating = false
+	status.LastAttempt = start
+	status.Duration = time.Since(start)
+	status.Error = ""
+	if err != nil {
+		status.Error = err.Error()
+	} else {
+		status.LastSuccess = start
+	}
+
+	if reporter, ok := f.(filter.Reporter); ok {
+		stats := reporter.Stats()
+		status.Entries = stats.Entries
+		status.Bytes = stats.Bytes
+	}
+
+	// update metrics
+	labels := []string{status.Name, string(status.Type)}
+	metrics.Get().FilterLastAttempt.WithLabelValues(labels...).Set(float64(status.LastAttempt.Unix()))
+	metrics.Get().FilterUpdateDuration.WithLabelValues(labels...).Set(status.Duration.Seconds())
+	metrics.Get().FilterEntries.WithLabelValues(labels...).Set(float64(status.Entries))
+	metrics.Get().FilterBytes.WithLabelValues(labels...).Set(float64(status.Bytes))
+	if err != nil {
+		metrics.Get().FilterUpdateFailed.WithLabelValues(labels...).Set(1)
+	} else {
+		metrics.Get().FilterLastSuccess.WithLabelValues(labels...).Set(float64(status.LastSuccess.Unix()))
+		metrics.Get().FilterUpdateFailed.WithLabelValues(labels...).Set(0)
+	}
+}
+
+// statusOf returns the filter status (must be called under lock)
+func (q *Queue) statusOf(f filter.Filter) *FilterStatus {
+	status, ok := q.status[f]
+	if !ok {
+		status = &FilterStatus{
+			Name: f.Name(),
+			Type: f.Type(),
+		}
+		q.status[f] = status
+	}
+
+	return status
+}
+
+// forget drops the status of filters which are not running anymore
+func (q *Queue) forget(filters []filter.Filter) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for f, status := range q.status {
+		if slices.Contains(filters, f) {
+			continue
+		}
+		delete(q.status, f)
+
+		// the same name and type may be reused by a new filter
+		if slices.ContainsFunc(filters, func(curr filter.Filter) bool {
+			return curr.Name() == status.Name && curr.Type() == status.Type
+		}) {
+			continue
+		}
+
+		labels := []string{status.Name, string(status.Type)}
+		metrics.Get().FilterLastAttempt.DeleteLabelValues(labels...)
+		metrics.Get().FilterLastSuccess.DeleteLabelValues(labels...)
+		metrics.Get().FilterUpdateDuration.DeleteLabelValues(labels...)
+		metrics.Get().FilterUpdateFailed.DeleteLabelValues(labels...)
+		metrics.Get().FilterEntries.DeleteLabelValues(labels...)
+		metrics.Get().FilterBytes.DeleteLabelValues(labels...)
+		metrics.Get().FilterEnabled.DeleteLabelValues(labels...)
+	}
+}
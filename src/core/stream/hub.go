@@ -0,0 +1,130 @@
+package stream
+
+import (
+	"net/netip"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/core/logger/event"
+)
+
+var _ logger.Handler = (*Hub)(nil)
+
+// Hub fans out the packet verdict records to the subscribers,
+// records are dropped if a subscriber is slow
+type Hub struct {
+	mu     sync.RWMutex
+	size   int
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+func NewHub(size int) *Hub {
+	return &Hub{
+		size: max(1, size),
+		subs: make(map[*Subscription]struct{}),
+	}
+}
+
+func (h *Hub) Wants(verdict event.Verdict) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subs {
+		if sub.filter.Match(verdict) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *Hub) Handle(record event.Record) {
+	verdict := record.Verdict()
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subs {
+		if !sub.filter.Match(verdict) {
+			continue
+		}
+
+		select {
+		case sub.records <- record:
+			// good
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
+
+// Subscribe returns the buffered subscription to the matching records
+func (h *Hub) Subscribe(filter Filter) *Subscription {
+	sub := &Subscription{
+		filter:  filter,
+		records: make(chan event.Record, h.size),
+	}
+
+	h.mu.Lock()
+	// NOTE: nothing is sent to the subscription after close
+	if !h.closed {
+		h.subs[sub] = struct{}{}
+	}
+	h.mu.Unlock()
+
+	return sub
+}
+
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	delete(h.subs, sub)
+	h.mu.Unlock()
+}
+
+// Close removes all the subscriptions and rejects the new ones
+func (h *Hub) Close() {
+	h.mu.Lock()
+	clear(h.subs)
+	h.closed = true
+	h.mu.Unlock()
+}
+
+type Subscription struct {
+	filter  Filter
+	records chan event.Record
+	dropped atomic.Uint64
+}
+
+func (s *Subscription) Records() <-chan event.Record {
+	return s.records
+}
+
+// Dropped returns the records count dropped since the previous call
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Swap(0)
+}
+
+// Filter matches the records, empty fields match all
+type Filter struct {
+	Actions []event.ActionType
+	Filters []filter.FilterType
+	Prefix  netip.Prefix
+}
+
+func (f Filter) Match(verdict event.Verdict) bool {
+	if len(f.Actions) > 0 && !slices.Contains(f.Actions, verdict.Action) {
+		return false
+	}
+	if len(f.Filters) > 0 && !slices.Contains(f.Filters, verdict.Filter) {
+		return false
+	}
+	if f.Prefix.IsValid() && (!verdict.SrcIP.IsValid() || !f.Prefix.Contains(verdict.SrcIP.Unmap())) {
+		return false
+	}
+
+	return true
+}
@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/florianl/go-nfqueue/v2"
 	"github.com/rs/zerolog"
@@ -19,11 +20,11 @@ type Worker struct {
 	rch <-chan nfqueue.Attribute
 	cnt *conntrack.Conn
 
-	filters []filter.Filter
+	filters *atomic.Pointer[[]filter.Filter]
 	logger  *logger.Logger
 }
 
-func NewWorker(filters []filter.Filter, logger *logger.Logger) *Worker {
+func NewWorker(filters *atomic.Pointer[[]filter.Filter], logger *logger.Logger) *Worker {
 	return &Worker{
 		filters: filters,
 		logger:  logger,
@@ -80,7 +81,7 @@ func (w *Worker) handle(a nfqueue.Attribute) {
 	}
 
 	// pass through filters
-	for _, checker := range w.filters {
+	for _, checker := range *w.filters.Load() {
 		if checker.Check(packet) {
 			// accept whitelists
 			if checker.Name() == filter.FilterNameWhiteList {
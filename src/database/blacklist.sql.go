@@ -9,6 +9,93 @@ import (
 	"context"
 )
 
+const countBlackListASN = `-- name: CountBlackListASN :one
+SELECT COUNT(*) FROM asn_blacklist
+WHERE asn = ?1
+`
+
+func (q *Queries) CountBlackListASN(ctx context.Context, db DBTX, asn int64) (int64, error) {
+	row := db.QueryRowContext(ctx, countBlackListASN, asn)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countBlackListCountry = `-- name: CountBlackListCountry :one
+SELECT COUNT(*) FROM country_blacklist
+WHERE country = ?1
+`
+
+func (q *Queries) CountBlackListCountry(ctx context.Context, db DBTX, country string) (int64, error) {
+	row := db.QueryRowContext(ctx, countBlackListCountry, country)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countBlackListDomain = `-- name: CountBlackListDomain :one
+SELECT COUNT(*) FROM domain_blacklist
+WHERE domain = ?1
+`
+
+func (q *Queries) CountBlackListDomain(ctx context.Context, db DBTX, domain string) (int64, error) {
+	row := db.QueryRowContext(ctx, countBlackListDomain, domain)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countBlackListJA3 = `-- name: CountBlackListJA3 :one
+SELECT COUNT(*) FROM ja3_blacklist
+WHERE hash = ?1
+`
+
+func (q *Queries) CountBlackListJA3(ctx context.Context, db DBTX, hash string) (int64, error) {
+	row := db.QueryRowContext(ctx, countBlackListJA3, hash)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countBlackListSubnet = `-- name: CountBlackListSubnet :one
+SELECT COUNT(*) FROM subnet_blacklist
+WHERE subnet = ?1
+`
+
+func (q *Queries) CountBlackListSubnet(ctx context.Context, db DBTX, subnet string) (int64, error) {
+	row := db.QueryRowContext(ctx, countBlackListSubnet, subnet)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getAllBlackListASNs = `-- name: GetAllBlackListASNs :many
+SELECT asn FROM asn_blacklist
+`
+
+func (q *Queries) GetAllBlackListASNs(ctx context.Context, db DBTX) ([]int64, error) {
+	rows, err := db.QueryContext(ctx, getAllBlackListASNs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var asn int64
+		if err := rows.Scan(&asn); err != nil {
+			return nil, err
+		}
+		items = append(items, asn)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAllBlackListCountries = `-- name: GetAllBlackListCountries :many
 SELECT country FROM country_blacklist
 `
@@ -63,6 +150,33 @@ func (q *Queries) GetAllBlackListDomains(ctx context.Context, db DBTX) ([]string
 	return items, nil
 }
 
+const getAllBlackListJA3s = `-- name: GetAllBlackListJA3s :many
+SELECT hash FROM ja3_blacklist
+`
+
+func (q *Queries) GetAllBlackListJA3s(ctx context.Context, db DBTX) ([]string, error) {
+	rows, err := db.QueryContext(ctx, getAllBlackListJA3s)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		items = append(items, hash)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAllBlackListSubnets = `-- name: GetAllBlackListSubnets :many
 SELECT subnet FROM subnet_blacklist
 `
@@ -90,62 +204,682 @@ func (q *Queries) GetAllBlackListSubnets(ctx context.Context, db DBTX) ([]string
 	return items, nil
 }
 
-const removeBlackListCountry = `-- name: RemoveBlackListCountry :exec
+const getBlackListASNEntries = `-- name: GetBlackListASNEntries :many
+SELECT asn, expires_at, comment, source, created_by, created_at, last_hit_at FROM asn_blacklist
+`
+
+func (q *Queries) GetBlackListASNEntries(ctx context.Context, db DBTX) ([]*AsnBlacklist, error) {
+	rows, err := db.QueryContext(ctx, getBlackListASNEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*AsnBlacklist
+	for rows.Next() {
+		var i AsnBlacklist
+		if err := rows.Scan(
+			&i.Asn,
+			&i.ExpiresAt,
+			&i.Comment,
+			&i.Source,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.LastHitAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBlackListCountryEntries = `-- name: GetBlackListCountryEntries :many
+SELECT country, expires_at, comment, source, created_by, created_at, last_hit_at FROM country_blacklist
+`
+
+func (q *Queries) GetBlackListCountryEntries(ctx context.Context, db DBTX) ([]*CountryBlacklist, error) {
+	rows, err := db.QueryContext(ctx, getBlackListCountryEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*CountryBlacklist
+	for rows.Next() {
+		var i CountryBlacklist
+		if err := rows.Scan(
+			&i.Country,
+			&i.ExpiresAt,
+			&i.Comment,
+			&i.Source,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.LastHitAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBlackListDomainEntries = `-- name: GetBlackListDomainEntries :many
+SELECT domain, expires_at, comment, source, created_by, created_at, last_hit_at FROM domain_blacklist
+`
+
+func (q *Queries) GetBlackListDomainEntries(ctx context.Context, db DBTX) ([]*DomainBlacklist, error) {
+	rows, err := db.QueryContext(ctx, getBlackListDomainEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*DomainBlacklist
+	for rows.Next() {
+		var i DomainBlacklist
+		if err := rows.Scan(
+			&i.Domain,
+			&i.ExpiresAt,
+			&i.Comment,
+			&i.Source,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.LastHitAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBlackListJA3Entries = `-- name: GetBlackListJA3Entries :many
+SELECT hash, expires_at, comment, source, created_by, created_at, last_hit_at FROM ja3_blacklist
+`
+
+func (q *Queries) GetBlackListJA3Entries(ctx context.Context, db DBTX) ([]*Ja3Blacklist, error) {
+	rows, err := db.QueryContext(ctx, getBlackListJA3Entries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Ja3Blacklist
+	for rows.Next() {
+		var i Ja3Blacklist
+		if err := rows.Scan(
+			&i.Hash,
+			&i.ExpiresAt,
+			&i.Comment,
+			&i.Source,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.LastHitAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBlackListSubnetEntries = `-- name: GetBlackListSubnetEntries :many
+SELECT subnet, expires_at, comment, source, created_by, created_at, last_hit_at FROM subnet_blacklist
+`
+
+func (q *Queries) GetBlackListSubnetEntries(ctx context.Context, db DBTX) ([]*SubnetBlacklist, error) {
+	rows, err := db.QueryContext(ctx, getBlackListSubnetEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*SubnetBlacklist
+	for rows.Next() {
+		var i SubnetBlacklist
+		if err := rows.Scan(
+			&i.Subnet,
+			&i.ExpiresAt,
+			&i.Comment,
+			&i.Source,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.LastHitAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExpiredBlackListASNs = `-- name: GetExpiredBlackListASNs :many
+SELECT asn FROM asn_blacklist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) GetExpiredBlackListASNs(ctx context.Context, db DBTX, now int64) ([]int64, error) {
+	rows, err := db.QueryContext(ctx, getExpiredBlackListASNs, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var asn int64
+		if err := rows.Scan(&asn); err != nil {
+			return nil, err
+		}
+		items = append(items, asn)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExpiredBlackListCountries = `-- name: GetExpiredBlackListCountries :many
+SELECT country FROM country_blacklist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) GetExpiredBlackListCountries(ctx context.Context, db DBTX, now int64) ([]string, error) {
+	rows, err := db.QueryContext(ctx, getExpiredBlackListCountries, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var country string
+		if err := rows.Scan(&country); err != nil {
+			return nil, err
+		}
+		items = append(items, country)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExpiredBlackListDomains = `-- name: GetExpiredBlackListDomains :many
+SELECT domain FROM domain_blacklist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) GetExpiredBlackListDomains(ctx context.Context, db DBTX, now int64) ([]string, error) {
+	rows, err := db.QueryContext(ctx, getExpiredBlackListDomains, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var domain string
+		if err := rows.Scan(&domain); err != nil {
+			return nil, err
+		}
+		items = append(items, domain)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExpiredBlackListJA3s = `-- name: GetExpiredBlackListJA3s :many
+SELECT hash FROM ja3_blacklist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) GetExpiredBlackListJA3s(ctx context.Context, db DBTX, now int64) ([]string, error) {
+	rows, err := db.QueryContext(ctx, getExpiredBlackListJA3s, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		items = append(items, hash)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExpiredBlackListSubnets = `-- name: GetExpiredBlackListSubnets :many
+SELECT subnet FROM subnet_blacklist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) GetExpiredBlackListSubnets(ctx context.Context, db DBTX, now int64) ([]string, error) {
+	rows, err := db.QueryContext(ctx, getExpiredBlackListSubnets, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var subnet string
+		if err := rows.Scan(&subnet); err != nil {
+			return nil, err
+		}
+		items = append(items, subnet)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeBlackListASN = `-- name: RemoveBlackListASN :execrows
+DELETE FROM asn_blacklist
+WHERE asn = ?1
+`
+
+func (q *Queries) RemoveBlackListASN(ctx context.Context, db DBTX, asn int64) (int64, error) {
+	result, err := db.ExecContext(ctx, removeBlackListASN, asn)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const removeBlackListCountry = `-- name: RemoveBlackListCountry :execrows
 DELETE FROM country_blacklist
 WHERE country = ?1
 `
 
-func (q *Queries) RemoveBlackListCountry(ctx context.Context, db DBTX, country string) error {
-	_, err := db.ExecContext(ctx, removeBlackListCountry, country)
-	return err
+func (q *Queries) RemoveBlackListCountry(ctx context.Context, db DBTX, country string) (int64, error) {
+	result, err := db.ExecContext(ctx, removeBlackListCountry, country)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const removeBlackListDomain = `-- name: RemoveBlackListDomain :exec
+const removeBlackListDomain = `-- name: RemoveBlackListDomain :execrows
 DELETE FROM domain_blacklist
 WHERE domain = ?1
 `
 
-func (q *Queries) RemoveBlackListDomain(ctx context.Context, db DBTX, domain string) error {
-	_, err := db.ExecContext(ctx, removeBlackListDomain, domain)
-	return err
+func (q *Queries) RemoveBlackListDomain(ctx context.Context, db DBTX, domain string) (int64, error) {
+	result, err := db.ExecContext(ctx, removeBlackListDomain, domain)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const removeBlackListJA3 = `-- name: RemoveBlackListJA3 :execrows
+DELETE FROM ja3_blacklist
+WHERE hash = ?1
+`
+
+func (q *Queries) RemoveBlackListJA3(ctx context.Context, db DBTX, hash string) (int64, error) {
+	result, err := db.ExecContext(ctx, removeBlackListJA3, hash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const removeBlackListSubnet = `-- name: RemoveBlackListSubnet :exec
+const removeBlackListSubnet = `-- name: RemoveBlackListSubnet :execrows
 DELETE FROM subnet_blacklist
 WHERE subnet = ?1
 `
 
-func (q *Queries) RemoveBlackListSubnet(ctx context.Context, db DBTX, subnet string) error {
-	_, err := db.ExecContext(ctx, removeBlackListSubnet, subnet)
+func (q *Queries) RemoveBlackListSubnet(ctx context.Context, db DBTX, subnet string) (int64, error) {
+	result, err := db.ExecContext(ctx, removeBlackListSubnet, subnet)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const removeExpiredBlackListASNs = `-- name: RemoveExpiredBlackListASNs :exec
+DELETE FROM asn_blacklist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) RemoveExpiredBlackListASNs(ctx context.Context, db DBTX, now int64) error {
+	_, err := db.ExecContext(ctx, removeExpiredBlackListASNs, now)
+	return err
+}
+
+const removeExpiredBlackListCountries = `-- name: RemoveExpiredBlackListCountries :exec
+DELETE FROM country_blacklist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) RemoveExpiredBlackListCountries(ctx context.Context, db DBTX, now int64) error {
+	_, err := db.ExecContext(ctx, removeExpiredBlackListCountries, now)
+	return err
+}
+
+const removeExpiredBlackListDomains = `-- name: RemoveExpiredBlackListDomains :exec
+DELETE FROM domain_blacklist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) RemoveExpiredBlackListDomains(ctx context.Context, db DBTX, now int64) error {
+	_, err := db.ExecContext(ctx, removeExpiredBlackListDomains, now)
+	return err
+}
+
+const removeExpiredBlackListJA3s = `-- name: RemoveExpiredBlackListJA3s :exec
+DELETE FROM ja3_blacklist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) RemoveExpiredBlackListJA3s(ctx context.Context, db DBTX, now int64) error {
+	_, err := db.ExecContext(ctx, removeExpiredBlackListJA3s, now)
+	return err
+}
+
+const removeExpiredBlackListSubnets = `-- name: RemoveExpiredBlackListSubnets :exec
+DELETE FROM subnet_blacklist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) RemoveExpiredBlackListSubnets(ctx context.Context, db DBTX, now int64) error {
+	_, err := db.ExecContext(ctx, removeExpiredBlackListSubnets, now)
+	return err
+}
+
+const touchBlackListASN = `-- name: TouchBlackListASN :exec
+UPDATE asn_blacklist SET last_hit_at = ?1
+WHERE asn = ?2
+`
+
+type TouchBlackListASNParams struct {
+	LastHitAt int64 `json:"last_hit_at"`
+	Asn       int64 `json:"asn"`
+}
+
+func (q *Queries) TouchBlackListASN(ctx context.Context, db DBTX, arg *TouchBlackListASNParams) error {
+	_, err := db.ExecContext(ctx, touchBlackListASN, arg.LastHitAt, arg.Asn)
+	return err
+}
+
+const touchBlackListCountry = `-- name: TouchBlackListCountry :exec
+UPDATE country_blacklist SET last_hit_at = ?1
+WHERE country = ?2
+`
+
+type TouchBlackListCountryParams struct {
+	LastHitAt int64  `json:"last_hit_at"`
+	Country   string `json:"country"`
+}
+
+func (q *Queries) TouchBlackListCountry(ctx context.Context, db DBTX, arg *TouchBlackListCountryParams) error {
+	_, err := db.ExecContext(ctx, touchBlackListCountry, arg.LastHitAt, arg.Country)
+	return err
+}
+
+const touchBlackListDomain = `-- name: TouchBlackListDomain :exec
+UPDATE domain_blacklist SET last_hit_at = ?1
+WHERE domain = ?2
+`
+
+type TouchBlackListDomainParams struct {
+	LastHitAt int64  `json:"last_hit_at"`
+	Domain    string `json:"domain"`
+}
+
+func (q *Queries) TouchBlackListDomain(ctx context.Context, db DBTX, arg *TouchBlackListDomainParams) error {
+	_, err := db.ExecContext(ctx, touchBlackListDomain, arg.LastHitAt, arg.Domain)
+	return err
+}
+
+const touchBlackListJA3 = `-- name: TouchBlackListJA3 :exec
+UPDATE ja3_blacklist SET last_hit_at = ?1
+WHERE hash = ?2
+`
+
+type TouchBlackListJA3Params struct {
+	LastHitAt int64  `json:"last_hit_at"`
+	Hash      string `json:"hash"`
+}
+
+func (q *Queries) TouchBlackListJA3(ctx context.Context, db DBTX, arg *TouchBlackListJA3Params) error {
+	_, err := db.ExecContext(ctx, touchBlackListJA3, arg.LastHitAt, arg.Hash)
+	return err
+}
+
+const touchBlackListSubnet = `-- name: TouchBlackListSubnet :exec
+UPDATE subnet_blacklist SET last_hit_at = ?1
+WHERE subnet = ?2
+`
+
+type TouchBlackListSubnetParams struct {
+	LastHitAt int64  `json:"last_hit_at"`
+	Subnet    string `json:"subnet"`
+}
+
+func (q *Queries) TouchBlackListSubnet(ctx context.Context, db DBTX, arg *TouchBlackListSubnetParams) error {
+	_, err := db.ExecContext(ctx, touchBlackListSubnet, arg.LastHitAt, arg.Subnet)
+	return err
+}
+
+const upsertBlackListASN = `-- name: UpsertBlackListASN :exec
+INSERT INTO asn_blacklist (asn, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (?1, ?2, ?3, COALESCE(NULLIF(?4, ''), ?5), ?6, ?7, ?8)
+ON CONFLICT (asn) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(?4, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at)
+`
+
+type UpsertBlackListASNParams struct {
+	Asn           int64  `json:"asn"`
+	ExpiresAt     int64  `json:"expires_at"`
+	Comment       string `json:"comment"`
+	Source        string `json:"source"`
+	DefaultSource string `json:"default_source"`
+	CreatedBy     string `json:"created_by"`
+	CreatedAt     int64  `json:"created_at"`
+	LastHitAt     int64  `json:"last_hit_at"`
+}
+
+func (q *Queries) UpsertBlackListASN(ctx context.Context, db DBTX, arg *UpsertBlackListASNParams) error {
+	_, err := db.ExecContext(ctx, upsertBlackListASN,
+		arg.Asn,
+		arg.ExpiresAt,
+		arg.Comment,
+		arg.Source,
+		arg.DefaultSource,
+		arg.CreatedBy,
+		arg.CreatedAt,
+		arg.LastHitAt,
+	)
 	return err
 }
 
 const upsertBlackListCountry = `-- name: UpsertBlackListCountry :exec
-INSERT INTO country_blacklist (country)
-VALUES (?1)
+INSERT INTO country_blacklist (country, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (?1, ?2, ?3, COALESCE(NULLIF(?4, ''), ?5), ?6, ?7, ?8)
+ON CONFLICT (country) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(?4, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at)
 `
 
-func (q *Queries) UpsertBlackListCountry(ctx context.Context, db DBTX, country string) error {
-	_, err := db.ExecContext(ctx, upsertBlackListCountry, country)
+type UpsertBlackListCountryParams struct {
+	Country       string `json:"country"`
+	ExpiresAt     int64  `json:"expires_at"`
+	Comment       string `json:"comment"`
+	Source        string `json:"source"`
+	DefaultSource string `json:"default_source"`
+	CreatedBy     string `json:"created_by"`
+	CreatedAt     int64  `json:"created_at"`
+	LastHitAt     int64  `json:"last_hit_at"`
+}
+
+func (q *Queries) UpsertBlackListCountry(ctx context.Context, db DBTX, arg *UpsertBlackListCountryParams) error {
+	_, err := db.ExecContext(ctx, upsertBlackListCountry,
+		arg.Country,
+		arg.ExpiresAt,
+		arg.Comment,
+		arg.Source,
+		arg.DefaultSource,
+		arg.CreatedBy,
+		arg.CreatedAt,
+		arg.LastHitAt,
+	)
 	return err
 }
 
 const upsertBlackListDomain = `-- name: UpsertBlackListDomain :exec
-INSERT INTO domain_blacklist (domain)
-VALUES (?1)
+INSERT INTO domain_blacklist (domain, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (?1, ?2, ?3, COALESCE(NULLIF(?4, ''), ?5), ?6, ?7, ?8)
+ON CONFLICT (domain) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(?4, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at)
 `
 
-func (q *Queries) UpsertBlackListDomain(ctx context.Context, db DBTX, domain string) error {
-	_, err := db.ExecContext(ctx, upsertBlackListDomain, domain)
+type UpsertBlackListDomainParams struct {
+	Domain        string `json:"domain"`
+	ExpiresAt     int64  `json:"expires_at"`
+	Comment       string `json:"comment"`
+	Source        string `json:"source"`
+	DefaultSource string `json:"default_source"`
+	CreatedBy     string `json:"created_by"`
+	CreatedAt     int64  `json:"created_at"`
+	LastHitAt     int64  `json:"last_hit_at"`
+}
+
+func (q *Queries) UpsertBlackListDomain(ctx context.Context, db DBTX, arg *UpsertBlackListDomainParams) error {
+	_, err := db.ExecContext(ctx, upsertBlackListDomain,
+		arg.Domain,
+		arg.ExpiresAt,
+		arg.Comment,
+		arg.Source,
+		arg.DefaultSource,
+		arg.CreatedBy,
+		arg.CreatedAt,
+		arg.LastHitAt,
+	)
+	return err
+}
+
+const upsertBlackListJA3 = `-- name: UpsertBlackListJA3 :exec
+INSERT INTO ja3_blacklist (hash, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (?1, ?2, ?3, COALESCE(NULLIF(?4, ''), ?5), ?6, ?7, ?8)
+ON CONFLICT (hash) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(?4, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at)
+`
+
+type UpsertBlackListJA3Params struct {
+	Hash          string `json:"hash"`
+	ExpiresAt     int64  `json:"expires_at"`
+	Comment       string `json:"comment"`
+	Source        string `json:"source"`
+	DefaultSource string `json:"default_source"`
+	CreatedBy     string `json:"created_by"`
+	CreatedAt     int64  `json:"created_at"`
+	LastHitAt     int64  `json:"last_hit_at"`
+}
+
+func (q *Queries) UpsertBlackListJA3(ctx context.Context, db DBTX, arg *UpsertBlackListJA3Params) error {
+	_, err := db.ExecContext(ctx, upsertBlackListJA3,
+		arg.Hash,
+		arg.ExpiresAt,
+		arg.Comment,
+		arg.Source,
+		arg.DefaultSource,
+		arg.CreatedBy,
+		arg.CreatedAt,
+		arg.LastHitAt,
+	)
 	return err
 }
 
 const upsertBlackListSubnet = `-- name: UpsertBlackListSubnet :exec
-INSERT INTO subnet_blacklist (subnet)
-VALUES (?1)
+INSERT INTO subnet_blacklist (subnet, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (?1, ?2, ?3, COALESCE(NULLIF(?4, ''), ?5), ?6, ?7, ?8)
+ON CONFLICT (subnet) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(?4, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at)
 `
 
-func (q *Queries) UpsertBlackListSubnet(ctx context.Context, db DBTX, subnet string) error {
-	_, err := db.ExecContext(ctx, upsertBlackListSubnet, subnet)
+type UpsertBlackListSubnetParams struct {
+	Subnet        string `json:"subnet"`
+	ExpiresAt     int64  `json:"expires_at"`
+	Comment       string `json:"comment"`
+	Source        string `json:"source"`
+	DefaultSource string `json:"default_source"`
+	CreatedBy     string `json:"created_by"`
+	CreatedAt     int64  `json:"created_at"`
+	LastHitAt     int64  `json:"last_hit_at"`
+}
+
+func (q *Queries) UpsertBlackListSubnet(ctx context.Context, db DBTX, arg *UpsertBlackListSubnetParams) error {
+	_, err := db.ExecContext(ctx, upsertBlackListSubnet,
+		arg.Subnet,
+		arg.ExpiresAt,
+		arg.Comment,
+		arg.Source,
+		arg.DefaultSource,
+		arg.CreatedBy,
+		arg.CreatedAt,
+		arg.LastHitAt,
+	)
 	return err
 }
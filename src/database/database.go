@@ -4,17 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-
-	_ "embed"
+	"strings"
 
 	_ "modernc.org/sqlite"
 
 	"github.com/cnaize/meds/src/core/logger"
 )
 
-//go:embed migrations/*
-var migrations string
-
 type Database struct {
 	Q  *Queries
 	DB *sql.DB
@@ -31,8 +27,12 @@ func NewDatabase(path string, logger *logger.Logger) *Database {
 	}
 }
 
+// NOTE: the api, reaper, hit touches, history and events are concurrent writers,
+// so they wait for the lock instead of failing and the transactions take it upfront
+const dsnParams = "_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)&_txlock=immediate"
+
 func (d *Database) Init(ctx context.Context) error {
-	db, err := sql.Open("sqlite", d.path)
+	db, err := sql.Open("sqlite", dsn(d.path))
 	if err != nil {
 		return fmt.Errorf("open db: %w", err)
 	}
@@ -41,8 +41,8 @@ func (d *Database) Init(ctx context.Context) error {
 		return fmt.Errorf("ping: %w", err)
 	}
 
-	// TODO: implement migrations
-	if _, err := db.ExecContext(ctx, migrations); err != nil {
+	if err := d.migrate(ctx, db); err != nil {
+		db.Close()
 		return fmt.Errorf("migrate: %w", err)
 	}
 
@@ -54,3 +54,11 @@ func (d *Database) Init(ctx context.Context) error {
 func (d *Database) Close() error {
 	return d.DB.Close()
 }
+
+func dsn(path string) string {
+	if strings.Contains(path, "?") {
+		return path + "&" + dsnParams
+	}
+
+	return path + "?" + dsnParams
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/cnaize/meds/src/core/logger"
+)
+
+func newTestDatabase(t *testing.T, path string) *Database {
+	t.Helper()
+
+	nop := zerolog.Nop()
+	db := NewDatabase(path, logger.NewLogger(&nop, 1))
+	if err := db.Init(t.Context()); err != nil {
+		t.Fatalf("init: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func TestDatabaseJournalMode(t *testing.T) {
+	db := newTestDatabase(t, filepath.Join(t.TempDir(), "meds.db"))
+
+	var mode string
+	if err := db.DB.QueryRowContext(t.Context(), "PRAGMA journal_mode").Scan(&mode); err != nil {
+		t.Fatalf("journal mode: %s", err)
+	}
+	if mode != "wal" {
+		t.Errorf("journal mode %q, want wal", mode)
+	}
+}
+
+func TestDatabaseConcurrentWriters(t *testing.T) {
+	db := newTestDatabase(t, filepath.Join(t.TempDir(), "meds.db"))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := range 8 {
+		wg.Go(func() {
+			for j := range 50 {
+				// NOTE: read then write, like the api transactions
+				if err := inTx(t.Context(), db, func(tx DBTX) error {
+					var count int64
+					if err := tx.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM events").Scan(&count); err != nil {
+						return err
+					}
+
+					return db.Q.AddEvent(t.Context(), tx, &AddEventParams{CreatedAt: int64(i*100 + j), Action: "drop"})
+				}); err != nil {
+					errs <- err
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("write: %s", err)
+	}
+}
+
+func inTx(ctx context.Context, d *Database, fn func(tx DBTX) error) error {
+	tx, err := d.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
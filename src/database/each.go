@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+)
+
+// NOTE: the generated ":many" queries load all the rows,
+// the large lists are scanned row by row instead
+
+func each[T any](ctx context.Context, db DBTX, query string, scan func(rows *sql.Rows, item *T) error, fn func(item *T) error) error {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item T
+		if err := scan(rows, &item); err != nil {
+			return err
+		}
+
+		if err := fn(&item); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// EachWhiteListSubnetEntry calls fn for every whitelisted subnet
+func (q *Queries) EachWhiteListSubnetEntry(ctx context.Context, db DBTX, fn func(row *SubnetWhitelist) error) error {
+	return each(ctx, db, getWhiteListSubnetEntries, func(rows *sql.Rows, i *SubnetWhitelist) error {
+		return rows.Scan(&i.Subnet, &i.ExpiresAt, &i.Comment, &i.Source, &i.CreatedBy, &i.CreatedAt, &i.LastHitAt)
+	}, fn)
+}
+
+// EachBlackListSubnetEntry calls fn for every blacklisted subnet
+func (q *Queries) EachBlackListSubnetEntry(ctx context.Context, db DBTX, fn func(row *SubnetBlacklist) error) error {
+	return each(ctx, db, getBlackListSubnetEntries, func(rows *sql.Rows, i *SubnetBlacklist) error {
+		return rows.Scan(&i.Subnet, &i.ExpiresAt, &i.Comment, &i.Source, &i.CreatedBy, &i.CreatedAt, &i.LastHitAt)
+	}, fn)
+}
+
+// EachWhiteListDomainEntry calls fn for every whitelisted domain
+func (q *Queries) EachWhiteListDomainEntry(ctx context.Context, db DBTX, fn func(row *DomainWhitelist) error) error {
+	return each(ctx, db, getWhiteListDomainEntries, func(rows *sql.Rows, i *DomainWhitelist) error {
+		return rows.Scan(&i.Domain, &i.ExpiresAt, &i.Comment, &i.Source, &i.CreatedBy, &i.CreatedAt, &i.LastHitAt)
+	}, fn)
+}
+
+// EachBlackListDomainEntry calls fn for every blacklisted domain
+func (q *Queries) EachBlackListDomainEntry(ctx context.Context, db DBTX, fn func(row *DomainBlacklist) error) error {
+	return each(ctx, db, getBlackListDomainEntries, func(rows *sql.Rows, i *DomainBlacklist) error {
+		return rows.Scan(&i.Domain, &i.ExpiresAt, &i.Comment, &i.Source, &i.CreatedBy, &i.CreatedAt, &i.LastHitAt)
+	}, fn)
+}
+
+// EachWhiteListCountryEntry calls fn for every whitelisted country
+func (q *Queries) EachWhiteListCountryEntry(ctx context.Context, db DBTX, fn func(row *CountryWhitelist) error) error {
+	return each(ctx, db, getWhiteListCountryEntries, func(rows *sql.Rows, i *CountryWhitelist) error {
+		return rows.Scan(&i.Country, &i.ExpiresAt, &i.Comment, &i.Source, &i.CreatedBy, &i.CreatedAt, &i.LastHitAt)
+	}, fn)
+}
+
+// EachBlackListCountryEntry calls fn for every blacklisted country
+func (q *Queries) EachBlackListCountryEntry(ctx context.Context, db DBTX, fn func(row *CountryBlacklist) error) error {
+	return each(ctx, db, getBlackListCountryEntries, func(rows *sql.Rows, i *CountryBlacklist) error {
+		return rows.Scan(&i.Country, &i.ExpiresAt, &i.Comment, &i.Source, &i.CreatedBy, &i.CreatedAt, &i.LastHitAt)
+	}, fn)
+}
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrSchemaTooNew = errors.New("database schema is newer than supported")
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// migration is an up-migration named like "0001_init.sql"
+type migration struct {
+	version int64
+	name    string
+	query   string
+}
+
+func loadMigrations() ([]migration, error) {
+	files, err := fs.Glob(migrationsFS, "migrations/*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("glob: %w", err)
+	}
+
+	migrations := make([]migration, 0, len(files))
+	for _, file := range files {
+		name := strings.TrimSuffix(path.Base(file), ".sql")
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("%s: invalid version", file)
+		}
+
+		query, err := migrationsFS.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: read: %w", file, err)
+		}
+
+		migrations = append(migrations, migration{version: version, name: name, query: string(query)})
+	}
+
+	slices.SortFunc(migrations, func(a, b migration) int {
+		return int(a.version - b.version)
+	})
+	for i, m := range migrations {
+		if m.version != int64(i+1) {
+			return nil, fmt.Errorf("%s: version gap or duplicate", m.name)
+		}
+	}
+
+	return migrations, nil
+}
+
+// migrate applies the missing up-migrations
+func (d *Database) migrate(ctx context.Context, db *sql.DB) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return fmt.Errorf("load: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    applied_at INTEGER NOT NULL
+)`); err != nil {
+		return fmt.Errorf("create migrations table: %w", err)
+	}
+
+	current, err := d.version(ctx, db, migrations)
+	if err != nil {
+		return fmt.Errorf("version: %w", err)
+	}
+	if latest := int64(len(migrations)); current > latest {
+		return fmt.Errorf("%w: %d > %d", ErrSchemaTooNew, current, latest)
+	}
+
+	for _, m := range migrations[current:] {
+		if err := d.apply(ctx, db, m); err != nil {
+			return fmt.Errorf("%s: %w", m.name, err)
+		}
+
+		d.logger.Raw().Info().Int64("version", m.version).Str("name", m.name).Msg("Database migrated")
+	}
+
+	return nil
+}
+
+// version returns the current schema version,
+// the database created before the versioning has the initial schema
+func (d *Database) version(ctx context.Context, db *sql.DB, migrations []migration) (int64, error) {
+	var version sql.NullInt64
+	if err := db.QueryRowContext(ctx, `SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("select: %w", err)
+	}
+	if version.Valid {
+		return version.Int64, nil
+	}
+
+	var legacy int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'subnet_whitelist'`).Scan(&legacy); err != nil {
+		return 0, fmt.Errorf("detect baseline: %w", err)
+	}
+	if legacy < 1 {
+		return 0, nil
+	}
+
+	initial := migrations[0]
+	if _, err := db.ExecContext(ctx, `INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`, initial.version, initial.name, time.Now().Unix()); err != nil {
+		return 0, fmt.Errorf("insert baseline: %w", err)
+	}
+
+	d.logger.Raw().Info().Int64("version", initial.version).Str("name", initial.name).Msg("Database versioned")
+
+	return initial.version, nil
+}
+
+func (d *Database) apply(ctx context.Context, db *sql.DB, m migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.query); err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`, m.version, m.name, time.Now().Unix()); err != nil {
+		return fmt.Errorf("insert version: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/cnaize/meds/src/core/logger"
+)
+
+// baselineSchema is the schema created before the versioning
+const baselineSchema = `
+CREATE TABLE IF NOT EXISTS subnet_whitelist (subnet TEXT NOT NULL);
+CREATE INDEX IF NOT EXISTS idx_snwl_subnet ON subnet_whitelist (subnet);
+CREATE TABLE IF NOT EXISTS subnet_blacklist (subnet TEXT NOT NULL);
+CREATE INDEX IF NOT EXISTS idx_snbl_subnet ON subnet_blacklist (subnet);
+CREATE TABLE IF NOT EXISTS domain_whitelist (domain TEXT NOT NULL);
+CREATE INDEX IF NOT EXISTS idx_dmwl_domain ON domain_whitelist (domain);
+CREATE TABLE IF NOT EXISTS domain_blacklist (domain TEXT NOT NULL);
+CREATE INDEX IF NOT EXISTS idx_dmbl_domain ON domain_blacklist (domain);
+CREATE TABLE IF NOT EXISTS country_blacklist (country TEXT NOT NULL);
+CREATE INDEX IF NOT EXISTS idx_crbl_country ON country_blacklist (country);
+`
+
+func applied(t *testing.T, db *sql.DB) []string {
+	t.Helper()
+
+	rows, err := db.QueryContext(t.Context(), `SELECT name FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		t.Fatalf("select migrations: %s", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatalf("scan: %s", err)
+		}
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func checkMigrated(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("load migrations: %s", err)
+	}
+
+	names := applied(t, db)
+	if len(names) != len(migrations) {
+		t.Fatalf("applied %v, want %d migrations", names, len(migrations))
+	}
+	for i, m := range migrations {
+		if names[i] != m.name {
+			t.Errorf("applied %s, want %s", names[i], m.name)
+		}
+	}
+}
+
+func TestMigrateEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meds.db")
+	db := newTestDatabase(t, path)
+	checkMigrated(t, db.DB)
+
+	if err := db.Q.AddEvent(t.Context(), db.DB, &AddEventParams{CreatedAt: 1, Action: "drop"}); err != nil {
+		t.Errorf("add event: %s", err)
+	}
+
+	// NOTE: nothing is applied twice
+	db.Close()
+	checkMigrated(t, newTestDatabase(t, path).DB)
+}
+
+func TestMigrateBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meds.db")
+
+	raw, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("open: %s", err)
+	}
+	if _, err := raw.ExecContext(t.Context(), baselineSchema); err != nil {
+		t.Fatalf("create baseline: %s", err)
+	}
+	if _, err := raw.ExecContext(t.Context(), `INSERT INTO subnet_whitelist (subnet) VALUES ('10.0.0.0/8'), ('10.0.0.0/8'), ('1.2.3.4/32');
+INSERT INTO domain_blacklist (domain) VALUES ('bad.com');`); err != nil {
+		t.Fatalf("insert: %s", err)
+	}
+	raw.Close()
+
+	db := newTestDatabase(t, path)
+	checkMigrated(t, db.DB)
+
+	// the entries are kept with the default metadata, the duplicates are collapsed
+	var count int
+	var source string
+	if err := db.DB.QueryRowContext(t.Context(), `SELECT COUNT(*), MIN(source) FROM subnet_whitelist`).Scan(&count, &source); err != nil {
+		t.Fatalf("select: %s", err)
+	}
+	if count != 2 || source != "api" {
+		t.Errorf("got %d subnets from %q, want 2 from api", count, source)
+	}
+	if _, err := db.DB.ExecContext(t.Context(), `INSERT INTO subnet_whitelist (subnet) VALUES ('1.2.3.4/32')`); err == nil {
+		t.Errorf("duplicate subnet inserted")
+	}
+	if err := db.DB.QueryRowContext(t.Context(), `SELECT COUNT(*) FROM domain_blacklist WHERE domain = 'bad.com'`).Scan(&count); err != nil || count != 1 {
+		t.Errorf("got %d domains (%v), want 1", count, err)
+	}
+}
+
+func TestMigrateTooNew(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meds.db")
+	db := newTestDatabase(t, path)
+	if _, err := db.DB.ExecContext(t.Context(), `INSERT INTO schema_migrations (version, name, applied_at) VALUES (1000, 'future', 0)`); err != nil {
+		t.Fatalf("insert: %s", err)
+	}
+	db.Close()
+
+	nop := zerolog.Nop()
+	if err := NewDatabase(path, logger.NewLogger(&nop, 1)).Init(t.Context()); !errors.Is(err, ErrSchemaTooNew) {
+		t.Errorf("init: %v, want schema too new", err)
+	}
+}
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS feeds (
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    sources TEXT NOT NULL,
+    update_interval INTEGER NOT NULL DEFAULT 0,
+    limits TEXT NOT NULL DEFAULT '{}',
+    PRIMARY KEY (type, name)
+);
+
+CREATE TABLE IF NOT EXISTS filter_states (
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    PRIMARY KEY (type, name)
+);
+
+CREATE TABLE IF NOT EXISTS filter_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    added TEXT NOT NULL,
+    removed TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_flhs_type_name ON filter_history (type, name, id);
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS country_whitelist (
+    country TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_crwl_country ON country_whitelist (country);
+
+CREATE TABLE IF NOT EXISTS asn_whitelist (
+    asn INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_aswl_asn ON asn_whitelist (asn);
+
+CREATE TABLE IF NOT EXISTS asn_blacklist (
+    asn INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_asbl_asn ON asn_blacklist (asn);
+
+CREATE TABLE IF NOT EXISTS ja3_whitelist (
+    hash TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_jawl_hash ON ja3_whitelist (hash);
+
+CREATE TABLE IF NOT EXISTS ja3_blacklist (
+    hash TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_jabl_hash ON ja3_blacklist (hash);
@@ -0,0 +1,69 @@
+ALTER TABLE subnet_whitelist ADD COLUMN expires_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE subnet_whitelist ADD COLUMN comment TEXT NOT NULL DEFAULT '';
+ALTER TABLE subnet_whitelist ADD COLUMN source TEXT NOT NULL DEFAULT 'api';
+ALTER TABLE subnet_whitelist ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE subnet_whitelist ADD COLUMN created_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE subnet_whitelist ADD COLUMN last_hit_at INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE subnet_blacklist ADD COLUMN expires_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE subnet_blacklist ADD COLUMN comment TEXT NOT NULL DEFAULT '';
+ALTER TABLE subnet_blacklist ADD COLUMN source TEXT NOT NULL DEFAULT 'api';
+ALTER TABLE subnet_blacklist ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE subnet_blacklist ADD COLUMN created_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE subnet_blacklist ADD COLUMN last_hit_at INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE domain_whitelist ADD COLUMN expires_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE domain_whitelist ADD COLUMN comment TEXT NOT NULL DEFAULT '';
+ALTER TABLE domain_whitelist ADD COLUMN source TEXT NOT NULL DEFAULT 'api';
+ALTER TABLE domain_whitelist ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE domain_whitelist ADD COLUMN created_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE domain_whitelist ADD COLUMN last_hit_at INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE domain_blacklist ADD COLUMN expires_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE domain_blacklist ADD COLUMN comment TEXT NOT NULL DEFAULT '';
+ALTER TABLE domain_blacklist ADD COLUMN source TEXT NOT NULL DEFAULT 'api';
+ALTER TABLE domain_blacklist ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE domain_blacklist ADD COLUMN created_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE domain_blacklist ADD COLUMN last_hit_at INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE country_whitelist ADD COLUMN expires_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE country_whitelist ADD COLUMN comment TEXT NOT NULL DEFAULT '';
+ALTER TABLE country_whitelist ADD COLUMN source TEXT NOT NULL DEFAULT 'api';
+ALTER TABLE country_whitelist ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE country_whitelist ADD COLUMN created_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE country_whitelist ADD COLUMN last_hit_at INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE country_blacklist ADD COLUMN expires_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE country_blacklist ADD COLUMN comment TEXT NOT NULL DEFAULT '';
+ALTER TABLE country_blacklist ADD COLUMN source TEXT NOT NULL DEFAULT 'api';
+ALTER TABLE country_blacklist ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE country_blacklist ADD COLUMN created_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE country_blacklist ADD COLUMN last_hit_at INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE asn_whitelist ADD COLUMN expires_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE asn_whitelist ADD COLUMN comment TEXT NOT NULL DEFAULT '';
+ALTER TABLE asn_whitelist ADD COLUMN source TEXT NOT NULL DEFAULT 'api';
+ALTER TABLE asn_whitelist ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE asn_whitelist ADD COLUMN created_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE asn_whitelist ADD COLUMN last_hit_at INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE asn_blacklist ADD COLUMN expires_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE asn_blacklist ADD COLUMN comment TEXT NOT NULL DEFAULT '';
+ALTER TABLE asn_blacklist ADD COLUMN source TEXT NOT NULL DEFAULT 'api';
+ALTER TABLE asn_blacklist ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE asn_blacklist ADD COLUMN created_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE asn_blacklist ADD COLUMN last_hit_at INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE ja3_whitelist ADD COLUMN expires_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE ja3_whitelist ADD COLUMN comment TEXT NOT NULL DEFAULT '';
+ALTER TABLE ja3_whitelist ADD COLUMN source TEXT NOT NULL DEFAULT 'api';
+ALTER TABLE ja3_whitelist ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE ja3_whitelist ADD COLUMN created_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE ja3_whitelist ADD COLUMN last_hit_at INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE ja3_blacklist ADD COLUMN expires_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE ja3_blacklist ADD COLUMN comment TEXT NOT NULL DEFAULT '';
+ALTER TABLE ja3_blacklist ADD COLUMN source TEXT NOT NULL DEFAULT 'api';
+ALTER TABLE ja3_blacklist ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE ja3_blacklist ADD COLUMN created_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE ja3_blacklist ADD COLUMN last_hit_at INTEGER NOT NULL DEFAULT 0;
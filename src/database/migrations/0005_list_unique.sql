@@ -0,0 +1,39 @@
+DELETE FROM subnet_whitelist WHERE rowid NOT IN (SELECT MAX(rowid) FROM subnet_whitelist GROUP BY subnet);
+DROP INDEX IF EXISTS idx_snwl_subnet;
+CREATE UNIQUE INDEX idx_snwl_subnet ON subnet_whitelist (subnet);
+
+DELETE FROM subnet_blacklist WHERE rowid NOT IN (SELECT MAX(rowid) FROM subnet_blacklist GROUP BY subnet);
+DROP INDEX IF EXISTS idx_snbl_subnet;
+CREATE UNIQUE INDEX idx_snbl_subnet ON subnet_blacklist (subnet);
+
+DELETE FROM domain_whitelist WHERE rowid NOT IN (SELECT MAX(rowid) FROM domain_whitelist GROUP BY domain);
+DROP INDEX IF EXISTS idx_dmwl_domain;
+CREATE UNIQUE INDEX idx_dmwl_domain ON domain_whitelist (domain);
+
+DELETE FROM domain_blacklist WHERE rowid NOT IN (SELECT MAX(rowid) FROM domain_blacklist GROUP BY domain);
+DROP INDEX IF EXISTS idx_dmbl_domain;
+CREATE UNIQUE INDEX idx_dmbl_domain ON domain_blacklist (domain);
+
+DELETE FROM country_whitelist WHERE rowid NOT IN (SELECT MAX(rowid) FROM country_whitelist GROUP BY country);
+DROP INDEX IF EXISTS idx_crwl_country;
+CREATE UNIQUE INDEX idx_crwl_country ON country_whitelist (country);
+
+DELETE FROM country_blacklist WHERE rowid NOT IN (SELECT MAX(rowid) FROM country_blacklist GROUP BY country);
+DROP INDEX IF EXISTS idx_crbl_country;
+CREATE UNIQUE INDEX idx_crbl_country ON country_blacklist (country);
+
+DELETE FROM asn_whitelist WHERE rowid NOT IN (SELECT MAX(rowid) FROM asn_whitelist GROUP BY asn);
+DROP INDEX IF EXISTS idx_aswl_asn;
+CREATE UNIQUE INDEX idx_aswl_asn ON asn_whitelist (asn);
+
+DELETE FROM asn_blacklist WHERE rowid NOT IN (SELECT MAX(rowid) FROM asn_blacklist GROUP BY asn);
+DROP INDEX IF EXISTS idx_asbl_asn;
+CREATE UNIQUE INDEX idx_asbl_asn ON asn_blacklist (asn);
+
+DELETE FROM ja3_whitelist WHERE rowid NOT IN (SELECT MAX(rowid) FROM ja3_whitelist GROUP BY hash);
+DROP INDEX IF EXISTS idx_jawl_hash;
+CREATE UNIQUE INDEX idx_jawl_hash ON ja3_whitelist (hash);
+
+DELETE FROM ja3_blacklist WHERE rowid NOT IN (SELECT MAX(rowid) FROM ja3_blacklist GROUP BY hash);
+DROP INDEX IF EXISTS idx_jabl_hash;
+CREATE UNIQUE INDEX idx_jabl_hash ON ja3_blacklist (hash);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at INTEGER NOT NULL,
+    action TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    filter TEXT NOT NULL,
+    target TEXT NOT NULL,
+    src_ip TEXT NOT NULL,
+    dst_port INTEGER NOT NULL,
+    country TEXT NOT NULL,
+    asn INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_evnt_created_at ON events (created_at);
+CREATE INDEX IF NOT EXISTS idx_evnt_src_ip ON events (src_ip, id);
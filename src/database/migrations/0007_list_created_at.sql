@@ -0,0 +1,10 @@
+CREATE INDEX IF NOT EXISTS idx_snwl_created_at ON subnet_whitelist (created_at, subnet);
+CREATE INDEX IF NOT EXISTS idx_snbl_created_at ON subnet_blacklist (created_at, subnet);
+CREATE INDEX IF NOT EXISTS idx_dmwl_created_at ON domain_whitelist (created_at, domain);
+CREATE INDEX IF NOT EXISTS idx_dmbl_created_at ON domain_blacklist (created_at, domain);
+CREATE INDEX IF NOT EXISTS idx_crwl_created_at ON country_whitelist (created_at, country);
+CREATE INDEX IF NOT EXISTS idx_crbl_created_at ON country_blacklist (created_at, country);
+CREATE INDEX IF NOT EXISTS idx_aswl_created_at ON asn_whitelist (created_at, asn);
+CREATE INDEX IF NOT EXISTS idx_asbl_created_at ON asn_blacklist (created_at, asn);
+CREATE INDEX IF NOT EXISTS idx_jawl_created_at ON ja3_whitelist (created_at, hash);
+CREATE INDEX IF NOT EXISTS idx_jabl_created_at ON ja3_blacklist (created_at, hash);
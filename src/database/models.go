@@ -3,3 +3,139 @@
 //   sqlc v1.30.0
 
 package database
+
+type AsnBlacklist struct {
+	Asn       int64  `json:"asn"`
+	ExpiresAt int64  `json:"expires_at"`
+	Comment   string `json:"comment"`
+	Source    string `json:"source"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt int64  `json:"created_at"`
+	LastHitAt int64  `json:"last_hit_at"`
+}
+
+type AsnWhitelist struct {
+	Asn       int64  `json:"asn"`
+	ExpiresAt int64  `json:"expires_at"`
+	Comment   string `json:"comment"`
+	Source    string `json:"source"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt int64  `json:"created_at"`
+	LastHitAt int64  `json:"last_hit_at"`
+}
+
+type CountryBlacklist struct {
+	Country   string `json:"country"`
+	ExpiresAt int64  `json:"expires_at"`
+	Comment   string `json:"comment"`
+	Source    string `json:"source"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt int64  `json:"created_at"`
+	LastHitAt int64  `json:"last_hit_at"`
+}
+
+type CountryWhitelist struct {
+	Country   string `json:"country"`
+	ExpiresAt int64  `json:"expires_at"`
+	Comment   string `json:"comment"`
+	Source    string `json:"source"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt int64  `json:"created_at"`
+	LastHitAt int64  `json:"last_hit_at"`
+}
+
+type DomainBlacklist struct {
+	Domain    string `json:"domain"`
+	ExpiresAt int64  `json:"expires_at"`
+	Comment   string `json:"comment"`
+	Source    string `json:"source"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt int64  `json:"created_at"`
+	LastHitAt int64  `json:"last_hit_at"`
+}
+
+type DomainWhitelist struct {
+	Domain    string `json:"domain"`
+	ExpiresAt int64  `json:"expires_at"`
+	Comment   string `json:"comment"`
+	Source    string `json:"source"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt int64  `json:"created_at"`
+	LastHitAt int64  `json:"last_hit_at"`
+}
+
+type Event struct {
+	ID        int64  `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+	Action    string `json:"action"`
+	Reason    string `json:"reason"`
+	Filter    string `json:"filter"`
+	Target    string `json:"target"`
+	SrcIp     string `json:"src_ip"`
+	DstPort   int64  `json:"dst_port"`
+	Country   string `json:"country"`
+	Asn       int64  `json:"asn"`
+}
+
+type Feed struct {
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	Sources        string `json:"sources"`
+	UpdateInterval int64  `json:"update_interval"`
+	Limits         string `json:"limits"`
+}
+
+type FilterHistory struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	CreatedAt int64  `json:"created_at"`
+	Added     string `json:"added"`
+	Removed   string `json:"removed"`
+}
+
+type FilterState struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+type Ja3Blacklist struct {
+	Hash      string `json:"hash"`
+	ExpiresAt int64  `json:"expires_at"`
+	Comment   string `json:"comment"`
+	Source    string `json:"source"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt int64  `json:"created_at"`
+	LastHitAt int64  `json:"last_hit_at"`
+}
+
+type Ja3Whitelist struct {
+	Hash      string `json:"hash"`
+	ExpiresAt int64  `json:"expires_at"`
+	Comment   string `json:"comment"`
+	Source    string `json:"source"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt int64  `json:"created_at"`
+	LastHitAt int64  `json:"last_hit_at"`
+}
+
+type SubnetBlacklist struct {
+	Subnet    string `json:"subnet"`
+	ExpiresAt int64  `json:"expires_at"`
+	Comment   string `json:"comment"`
+	Source    string `json:"source"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt int64  `json:"created_at"`
+	LastHitAt int64  `json:"last_hit_at"`
+}
+
+type SubnetWhitelist struct {
+	Subnet    string `json:"subnet"`
+	ExpiresAt int64  `json:"expires_at"`
+	Comment   string `json:"comment"`
+	Source    string `json:"source"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt int64  `json:"created_at"`
+	LastHitAt int64  `json:"last_hit_at"`
+}
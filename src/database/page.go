@@ -0,0 +1,184 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+)
+
+// NOTE: the list pages are filtered and sorted by the request,
+// so the queries are built here instead of the generated ones
+
+// ListPageParams is the keyset page query of a white/black list
+type ListPageParams struct {
+	// ByCreatedAt sorts by the creation time first, by the value otherwise
+	ByCreatedAt bool
+	Desc        bool
+	// After is the last returned entry, the first page is returned if not set
+	After *ListCursor
+	// Values, Search and Prefix filter the entries by value (if set)
+	Values []string
+	Search string
+	Prefix string
+	// Limit is the page size, all entries are returned if not set
+	Limit int64
+}
+
+// ListCursor is the list entry position
+type ListCursor struct {
+	CreatedAt int64
+	Value     string
+}
+
+// listTable is the white/black list table
+type listTable struct {
+	name   string
+	column string
+	// integer values are compared as numbers
+	integer bool
+}
+
+func page[T any](ctx context.Context, db DBTX, table listTable, arg *ListPageParams, scan func(rows *sql.Rows, item *T) error) ([]*T, error) {
+	var where []string
+	var args []any
+	if len(arg.Values) > 0 {
+		values, err := json.Marshal(arg.Values)
+		if err != nil {
+			return nil, err
+		}
+
+		where = append(where, table.column+" IN (SELECT value FROM json_each(?))")
+		args = append(args, string(values))
+	}
+	if len(arg.Search) > 0 {
+		where = append(where, "instr("+table.column+", ?) > 0")
+		args = append(args, arg.Search)
+	}
+	if len(arg.Prefix) > 0 {
+		where = append(where, "substr("+table.column+", 1, length(?)) = ?")
+		args = append(args, arg.Prefix, arg.Prefix)
+	}
+
+	op, order := ">", "ASC"
+	if arg.Desc {
+		op, order = "<", "DESC"
+	}
+	value := "?"
+	if table.integer {
+		value = "CAST(? AS INTEGER)"
+	}
+
+	keys := table.column + " " + order
+	if arg.ByCreatedAt {
+		keys = "created_at " + order + ", " + keys
+	}
+	if arg.After != nil {
+		if arg.ByCreatedAt {
+			where = append(where, "(created_at, "+table.column+") "+op+" (?, "+value+")")
+			args = append(args, arg.After.CreatedAt, arg.After.Value)
+		} else {
+			where = append(where, table.column+" "+op+" "+value)
+			args = append(args, arg.After.Value)
+		}
+	}
+
+	query := "SELECT " + table.column + ", expires_at, comment, source, created_by, created_at, last_hit_at FROM " + table.name
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	// NOTE: negative limit means no limit
+	query += " ORDER BY " + keys + " LIMIT ?"
+	args = append(args, cmp.Or(arg.Limit, -1))
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*T
+	for rows.Next() {
+		var item T
+		if err := scan(rows, &item); err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// GetWhiteListSubnetPage returns the page of whitelisted subnets
+func (q *Queries) GetWhiteListSubnetPage(ctx context.Context, db DBTX, arg *ListPageParams) ([]*SubnetWhitelist, error) {
+	return page(ctx, db, listTable{name: "subnet_whitelist", column: "subnet"}, arg, func(rows *sql.Rows, i *SubnetWhitelist) error {
+		return rows.Scan(&i.Subnet, &i.ExpiresAt, &i.Comment, &i.Source, &i.CreatedBy, &i.CreatedAt, &i.LastHitAt)
+	})
+}
+
+// GetBlackListSubnetPage returns the page of blacklisted subnets
+func (q *Queries) GetBlackListSubnetPage(ctx context.Context, db DBTX, arg *ListPageParams) ([]*SubnetBlacklist, error) {
+	return page(ctx, db, listTable{name: "subnet_blacklist", column: "subnet"}, arg, func(rows *sql.Rows, i *SubnetBlacklist) error {
+		return rows.Scan(&i.Subnet, &i.ExpiresAt, &i.Comment, &i.Source, &i.CreatedBy, &i.CreatedAt, &i.LastHitAt)
+	})
+}
+
+// GetWhiteListDomainPage returns the page of whitelisted domains
+func (q *Queries) GetWhiteListDomainPage(ctx context.Context, db DBTX, arg *ListPageParams) ([]*DomainWhitelist, error) {
+	return page(ctx, db, listTable{name: "domain_whitelist", column: "domain"}, arg, func(rows *sql.Rows, i *DomainWhitelist) error {
+		return rows.Scan(&i.Domain, &i.ExpiresAt, &i.Comment, &i.Source, &i.CreatedBy, &i.CreatedAt, &i.LastHitAt)
+	})
+}
+
+// GetBlackListDomainPage returns the page of blacklisted domains
+func (q *Queries) GetBlackListDomainPage(ctx context.Context, db DBTX, arg *ListPageParams) ([]*DomainBlacklist, error) {
+	return page(ctx, db, listTable{name: "domain_blacklist", column: "domain"}, arg, func(rows *sql.Rows, i *DomainBlacklist) error {
+		return rows.Scan(&i.Domain, &i.ExpiresAt, &i.Comment, &i.Source, &i.CreatedBy, &i.CreatedAt, &i.LastHitAt)
+	})
+}
+
+// GetWhiteListCountryPage returns the page of whitelisted countries
+func (q *Queries) GetWhiteListCountryPage(ctx context.Context, db DBTX, arg *ListPageParams) ([]*CountryWhitelist, error) {
+	return page(ctx, db, listTable{name: "country_whitelist", column: "country"}, arg, func(rows *sql.Rows, i *CountryWhitelist) error {
+		return rows.Scan(&i.Country, &i.ExpiresAt, &i.Comment, &i.Source, &i.CreatedBy, &i.CreatedAt, &i.LastHitAt)
+	})
+}
+
+// GetBlackListCountryPage returns the page of blacklisted countries
+func (q *Queries) GetBlackListCountryPage(ctx context.Context, db DBTX, arg *ListPageParams) ([]*CountryBlacklist, error) {
+	return page(ctx, db, listTable{name: "country_blacklist", column: "country"}, arg, func(rows *sql.Rows, i *CountryBlacklist) error {
+		return rows.Scan(&i.Country, &i.ExpiresAt, &i.Comment, &i.Source, &i.CreatedBy, &i.CreatedAt, &i.LastHitAt)
+	})
+}
+
+// GetWhiteListASNPage returns the page of whitelisted asns
+func (q *Queries) GetWhiteListASNPage(ctx context.Context, db DBTX, arg *ListPageParams) ([]*AsnWhitelist, error) {
+	return page(ctx, db, listTable{name: "asn_whitelist", column: "asn", integer: true}, arg, func(rows *sql.Rows, i *AsnWhitelist) error {
+		return rows.Scan(&i.Asn, &i.ExpiresAt, &i.Comment, &i.Source, &i.CreatedBy, &i.CreatedAt, &i.LastHitAt)
+	})
+}
+
+// GetBlackListASNPage returns the page of blacklisted asns
+func (q *Queries) GetBlackListASNPage(ctx context.Context, db DBTX, arg *ListPageParams) ([]*AsnBlacklist, error) {
+	return page(ctx, db, listTable{name: "asn_blacklist", column: "asn", integer: true}, arg, func(rows *sql.Rows, i *AsnBlacklist) error {
+		return rows.Scan(&i.Asn, &i.ExpiresAt, &i.Comment, &i.Source, &i.CreatedBy, &i.CreatedAt, &i.LastHitAt)
+	})
+}
+
+// GetWhiteListJA3Page returns the page of whitelisted ja3s
+func (q *Queries) GetWhiteListJA3Page(ctx context.Context, db DBTX, arg *ListPageParams) ([]*Ja3Whitelist, error) {
+	return page(ctx, db, listTable{name: "ja3_whitelist", column: "hash"}, arg, func(rows *sql.Rows, i *Ja3Whitelist) error {
+		return rows.Scan(&i.Hash, &i.ExpiresAt, &i.Comment, &i.Source, &i.CreatedBy, &i.CreatedAt, &i.LastHitAt)
+	})
+}
+
+// GetBlackListJA3Page returns the page of blacklisted ja3s
+func (q *Queries) GetBlackListJA3Page(ctx context.Context, db DBTX, arg *ListPageParams) ([]*Ja3Blacklist, error) {
+	return page(ctx, db, listTable{name: "ja3_blacklist", column: "hash"}, arg, func(rows *sql.Rows, i *Ja3Blacklist) error {
+		return rows.Scan(&i.Hash, &i.ExpiresAt, &i.Comment, &i.Source, &i.CreatedBy, &i.CreatedAt, &i.LastHitAt)
+	})
+}
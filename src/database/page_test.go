@@ -0,0 +1,92 @@
+package database
+
+import (
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestListPage(t *testing.T) {
+	ctx := t.Context()
+	db := newTestDatabase(t, filepath.Join(t.TempDir(), "meds.db"))
+
+	// created_at ties are broken by the value
+	domains := map[string]int64{"a.com": 30, "b.org": 10, "c.com": 20, "sub.a.com": 10, "x.net": 40}
+	for domain, createdAt := range domains {
+		if err := db.Q.UpsertBlackListDomain(ctx, db.DB, &UpsertBlackListDomainParams{Domain: domain, CreatedAt: createdAt, DefaultSource: "api"}); err != nil {
+			t.Fatalf("upsert %s: %s", domain, err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		arg  ListPageParams
+		want []string
+	}{
+		{name: "all", want: []string{"a.com", "b.org", "c.com", "sub.a.com", "x.net"}},
+		{name: "desc", arg: ListPageParams{Desc: true}, want: []string{"x.net", "sub.a.com", "c.com", "b.org", "a.com"}},
+		{name: "limit", arg: ListPageParams{Limit: 2}, want: []string{"a.com", "b.org"}},
+		{name: "after", arg: ListPageParams{After: &ListCursor{Value: "b.org"}, Limit: 2}, want: []string{"c.com", "sub.a.com"}},
+		{name: "after desc", arg: ListPageParams{Desc: true, After: &ListCursor{Value: "c.com"}}, want: []string{"b.org", "a.com"}},
+		{name: "created", arg: ListPageParams{ByCreatedAt: true}, want: []string{"b.org", "sub.a.com", "c.com", "a.com", "x.net"}},
+		{
+			name: "created after tie",
+			arg:  ListPageParams{ByCreatedAt: true, After: &ListCursor{CreatedAt: 10, Value: "b.org"}, Limit: 2},
+			want: []string{"sub.a.com", "c.com"},
+		},
+		{
+			name: "created desc after",
+			arg:  ListPageParams{ByCreatedAt: true, Desc: true, After: &ListCursor{CreatedAt: 20, Value: "c.com"}},
+			want: []string{"sub.a.com", "b.org"},
+		},
+		{name: "search", arg: ListPageParams{Search: ".com"}, want: []string{"a.com", "c.com", "sub.a.com"}},
+		// NOTE: the search is literal, not a LIKE pattern
+		{name: "search literal", arg: ListPageParams{Search: "_"}},
+		{name: "prefix", arg: ListPageParams{Prefix: "sub."}, want: []string{"sub.a.com"}},
+		{name: "values", arg: ListPageParams{Values: []string{"x.net", "a.com", "missing.com"}}, want: []string{"a.com", "x.net"}},
+		{name: "combined", arg: ListPageParams{Search: "com", After: &ListCursor{Value: "a.com"}, Limit: 1}, want: []string{"c.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := db.Q.GetBlackListDomainPage(ctx, db.DB, &tt.arg)
+			if err != nil {
+				t.Fatalf("page: %s", err)
+			}
+
+			var got []string
+			for _, item := range items {
+				got = append(got, item.Domain)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("page %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListPageInteger(t *testing.T) {
+	ctx := t.Context()
+	db := newTestDatabase(t, filepath.Join(t.TempDir(), "meds.db"))
+
+	for _, asn := range []int64{9, 13335, 15169, 100} {
+		if err := db.Q.UpsertWhiteListASN(ctx, db.DB, &UpsertWhiteListASNParams{Asn: asn, DefaultSource: "api"}); err != nil {
+			t.Fatalf("upsert %d: %s", asn, err)
+		}
+	}
+
+	// NOTE: the numbers are compared as numbers, not strings
+	items, err := db.Q.GetWhiteListASNPage(ctx, db.DB, &ListPageParams{After: &ListCursor{Value: "100"}})
+	if err != nil {
+		t.Fatalf("page: %s", err)
+	}
+
+	var got []string
+	for _, item := range items {
+		got = append(got, strconv.FormatInt(item.Asn, 10))
+	}
+	if want := []string{"13335", "15169"}; !slices.Equal(got, want) {
+		t.Errorf("page %v, want %v", got, want)
+	}
+}
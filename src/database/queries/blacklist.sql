@@ -1,32 +1,174 @@
 -- name: GetAllBlackListSubnets :many
+SELECT subnet FROM subnet_blacklist;
+
+-- name: GetBlackListSubnetEntries :many
 SELECT * FROM subnet_blacklist;
 
+-- name: GetExpiredBlackListSubnets :many
+SELECT subnet FROM subnet_blacklist
+WHERE expires_at > 0 AND expires_at <= @now;
+
+-- name: CountBlackListSubnet :one
+SELECT COUNT(*) FROM subnet_blacklist
+WHERE subnet = @subnet;
+
 -- name: UpsertBlackListSubnet :exec
-INSERT INTO subnet_blacklist (subnet)
-VALUES (@subnet);
+INSERT INTO subnet_blacklist (subnet, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (@subnet, @expires_at, @comment, COALESCE(NULLIF(@source, ''), @default_source), @created_by, @created_at, @last_hit_at)
+ON CONFLICT (subnet) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(@source, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at);
+
+-- name: TouchBlackListSubnet :exec
+UPDATE subnet_blacklist SET last_hit_at = @last_hit_at
+WHERE subnet = @subnet;
 
--- name: RemoveBlackListSubnet :exec
+-- name: RemoveBlackListSubnet :execrows
 DELETE FROM subnet_blacklist
 WHERE subnet = @subnet;
 
+-- name: RemoveExpiredBlackListSubnets :exec
+DELETE FROM subnet_blacklist
+WHERE expires_at > 0 AND expires_at <= @now;
+
 -- name: GetAllBlackListDomains :many
+SELECT domain FROM domain_blacklist;
+
+-- name: GetBlackListDomainEntries :many
 SELECT * FROM domain_blacklist;
 
+-- name: GetExpiredBlackListDomains :many
+SELECT domain FROM domain_blacklist
+WHERE expires_at > 0 AND expires_at <= @now;
+
+-- name: CountBlackListDomain :one
+SELECT COUNT(*) FROM domain_blacklist
+WHERE domain = @domain;
+
 -- name: UpsertBlackListDomain :exec
-INSERT INTO domain_blacklist (domain)
-VALUES (@domain);
+INSERT INTO domain_blacklist (domain, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (@domain, @expires_at, @comment, COALESCE(NULLIF(@source, ''), @default_source), @created_by, @created_at, @last_hit_at)
+ON CONFLICT (domain) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(@source, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at);
 
--- name: RemoveBlackListDomain :exec
+-- name: TouchBlackListDomain :exec
+UPDATE domain_blacklist SET last_hit_at = @last_hit_at
+WHERE domain = @domain;
+
+-- name: RemoveBlackListDomain :execrows
 DELETE FROM domain_blacklist
 WHERE domain = @domain;
 
+-- name: RemoveExpiredBlackListDomains :exec
+DELETE FROM domain_blacklist
+WHERE expires_at > 0 AND expires_at <= @now;
+
 -- name: GetAllBlackListCountries :many
+SELECT country FROM country_blacklist;
+
+-- name: GetBlackListCountryEntries :many
 SELECT * FROM country_blacklist;
 
+-- name: GetExpiredBlackListCountries :many
+SELECT country FROM country_blacklist
+WHERE expires_at > 0 AND expires_at <= @now;
+
+-- name: CountBlackListCountry :one
+SELECT COUNT(*) FROM country_blacklist
+WHERE country = @country;
+
 -- name: UpsertBlackListCountry :exec
-INSERT INTO country_blacklist (country)
-VALUES (@country);
+INSERT INTO country_blacklist (country, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (@country, @expires_at, @comment, COALESCE(NULLIF(@source, ''), @default_source), @created_by, @created_at, @last_hit_at)
+ON CONFLICT (country) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(@source, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at);
 
--- name: RemoveBlackListCountry :exec
+-- name: TouchBlackListCountry :exec
+UPDATE country_blacklist SET last_hit_at = @last_hit_at
+WHERE country = @country;
+
+-- name: RemoveBlackListCountry :execrows
 DELETE FROM country_blacklist
 WHERE country = @country;
+
+-- name: RemoveExpiredBlackListCountries :exec
+DELETE FROM country_blacklist
+WHERE expires_at > 0 AND expires_at <= @now;
+
+-- name: GetAllBlackListASNs :many
+SELECT asn FROM asn_blacklist;
+
+-- name: GetBlackListASNEntries :many
+SELECT * FROM asn_blacklist;
+
+-- name: GetExpiredBlackListASNs :many
+SELECT asn FROM asn_blacklist
+WHERE expires_at > 0 AND expires_at <= @now;
+
+-- name: CountBlackListASN :one
+SELECT COUNT(*) FROM asn_blacklist
+WHERE asn = @asn;
+
+-- name: UpsertBlackListASN :exec
+INSERT INTO asn_blacklist (asn, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (@asn, @expires_at, @comment, COALESCE(NULLIF(@source, ''), @default_source), @created_by, @created_at, @last_hit_at)
+ON CONFLICT (asn) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(@source, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at);
+
+-- name: TouchBlackListASN :exec
+UPDATE asn_blacklist SET last_hit_at = @last_hit_at
+WHERE asn = @asn;
+
+-- name: RemoveBlackListASN :execrows
+DELETE FROM asn_blacklist
+WHERE asn = @asn;
+
+-- name: RemoveExpiredBlackListASNs :exec
+DELETE FROM asn_blacklist
+WHERE expires_at > 0 AND expires_at <= @now;
+
+-- name: GetAllBlackListJA3s :many
+SELECT hash FROM ja3_blacklist;
+
+-- name: GetBlackListJA3Entries :many
+SELECT * FROM ja3_blacklist;
+
+-- name: GetExpiredBlackListJA3s :many
+SELECT hash FROM ja3_blacklist
+WHERE expires_at > 0 AND expires_at <= @now;
+
+-- name: CountBlackListJA3 :one
+SELECT COUNT(*) FROM ja3_blacklist
+WHERE hash = @hash;
+
+-- name: UpsertBlackListJA3 :exec
+INSERT INTO ja3_blacklist (hash, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (@hash, @expires_at, @comment, COALESCE(NULLIF(@source, ''), @default_source), @created_by, @created_at, @last_hit_at)
+ON CONFLICT (hash) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(@source, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at);
+
+-- name: TouchBlackListJA3 :exec
+UPDATE ja3_blacklist SET last_hit_at = @last_hit_at
+WHERE hash = @hash;
+
+-- name: RemoveBlackListJA3 :execrows
+DELETE FROM ja3_blacklist
+WHERE hash = @hash;
+
+-- name: RemoveExpiredBlackListJA3s :exec
+DELETE FROM ja3_blacklist
+WHERE expires_at > 0 AND expires_at <= @now;
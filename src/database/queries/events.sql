@@ -0,0 +1,27 @@
+-- name: AddEvent :exec
+INSERT INTO events (created_at, action, reason, filter, target, src_ip, dst_port, country, asn)
+VALUES (@created_at, @action, @reason, @filter, @target, @src_ip, @dst_port, @country, @asn);
+
+-- name: GetEvents :many
+SELECT * FROM events
+WHERE id < @before AND created_at >= @since AND created_at < @until
+    AND (CAST(@action AS TEXT) = '' OR action = @action)
+    AND (CAST(@filter AS TEXT) = '' OR filter = @filter)
+    AND (CAST(@reason AS TEXT) = '' OR reason = @reason)
+    AND (CAST(@src_ip AS TEXT) = '' OR src_ip = @src_ip)
+    AND (CAST(@country AS TEXT) = '' OR country = @country)
+    AND (CAST(@dst_port AS INTEGER) = 0 OR dst_port = @dst_port)
+ORDER BY id DESC
+LIMIT @limit;
+
+-- name: RemoveOldEvents :exec
+DELETE FROM events
+WHERE created_at < @before;
+
+-- name: TrimEvents :exec
+DELETE FROM events
+WHERE id <= (
+    SELECT id FROM events
+    ORDER BY id DESC
+    LIMIT 1 OFFSET @keep
+);
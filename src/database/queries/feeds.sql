@@ -0,0 +1,11 @@
+-- name: GetAllFeeds :many
+SELECT * FROM feeds;
+
+-- name: UpsertFeed :exec
+INSERT INTO feeds (name, type, sources, update_interval, limits)
+VALUES (@name, @type, @sources, @update_interval, @limits)
+ON CONFLICT (type, name) DO UPDATE SET sources = excluded.sources, update_interval = excluded.update_interval, limits = excluded.limits;
+
+-- name: RemoveFeed :exec
+DELETE FROM feeds
+WHERE type = @type AND name = @name;
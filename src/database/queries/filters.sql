@@ -0,0 +1,35 @@
+-- name: GetAllFilterStates :many
+SELECT * FROM filter_states;
+
+-- name: UpsertFilterState :exec
+INSERT INTO filter_states (name, type, enabled)
+VALUES (@name, @type, @enabled)
+ON CONFLICT (type, name) DO UPDATE SET enabled = excluded.enabled;
+
+-- name: AddFilterHistory :exec
+INSERT INTO filter_history (name, type, created_at, added, removed)
+VALUES (@name, @type, @created_at, @added, @removed);
+
+-- name: GetFilterHistory :many
+SELECT * FROM filter_history
+WHERE type = @type AND name = @name
+ORDER BY id DESC;
+
+-- name: GetLastFilterHistory :one
+SELECT * FROM filter_history
+WHERE type = @type AND name = @name
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: RemoveFilterHistory :exec
+DELETE FROM filter_history
+WHERE id = @id;
+
+-- name: TrimFilterHistory :exec
+DELETE FROM filter_history
+WHERE type = @type AND name = @name AND id NOT IN (
+    SELECT id FROM filter_history
+    WHERE type = @type AND name = @name
+    ORDER BY id DESC
+    LIMIT @keep
+);
@@ -1,21 +1,174 @@
 -- name: GetAllWhiteListSubnets :many
+SELECT subnet FROM subnet_whitelist;
+
+-- name: GetWhiteListSubnetEntries :many
 SELECT * FROM subnet_whitelist;
 
+-- name: GetExpiredWhiteListSubnets :many
+SELECT subnet FROM subnet_whitelist
+WHERE expires_at > 0 AND expires_at <= @now;
+
+-- name: CountWhiteListSubnet :one
+SELECT COUNT(*) FROM subnet_whitelist
+WHERE subnet = @subnet;
+
 -- name: UpsertWhiteListSubnet :exec
-INSERT INTO subnet_whitelist (subnet)
-VALUES (@subnet);
+INSERT INTO subnet_whitelist (subnet, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (@subnet, @expires_at, @comment, COALESCE(NULLIF(@source, ''), @default_source), @created_by, @created_at, @last_hit_at)
+ON CONFLICT (subnet) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(@source, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at);
+
+-- name: TouchWhiteListSubnet :exec
+UPDATE subnet_whitelist SET last_hit_at = @last_hit_at
+WHERE subnet = @subnet;
 
--- name: RemoveWhiteListSubnet :exec
+-- name: RemoveWhiteListSubnet :execrows
 DELETE FROM subnet_whitelist
 WHERE subnet = @subnet;
 
+-- name: RemoveExpiredWhiteListSubnets :exec
+DELETE FROM subnet_whitelist
+WHERE expires_at > 0 AND expires_at <= @now;
+
 -- name: GetAllWhiteListDomains :many
+SELECT domain FROM domain_whitelist;
+
+-- name: GetWhiteListDomainEntries :many
 SELECT * FROM domain_whitelist;
 
+-- name: GetExpiredWhiteListDomains :many
+SELECT domain FROM domain_whitelist
+WHERE expires_at > 0 AND expires_at <= @now;
+
+-- name: CountWhiteListDomain :one
+SELECT COUNT(*) FROM domain_whitelist
+WHERE domain = @domain;
+
 -- name: UpsertWhiteListDomain :exec
-INSERT INTO domain_whitelist (domain)
-VALUES (@domain);
+INSERT INTO domain_whitelist (domain, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (@domain, @expires_at, @comment, COALESCE(NULLIF(@source, ''), @default_source), @created_by, @created_at, @last_hit_at)
+ON CONFLICT (domain) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(@source, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at);
 
--- name: RemoveWhiteListDomain :exec
+-- name: TouchWhiteListDomain :exec
+UPDATE domain_whitelist SET last_hit_at = @last_hit_at
+WHERE domain = @domain;
+
+-- name: RemoveWhiteListDomain :execrows
 DELETE FROM domain_whitelist
 WHERE domain = @domain;
+
+-- name: RemoveExpiredWhiteListDomains :exec
+DELETE FROM domain_whitelist
+WHERE expires_at > 0 AND expires_at <= @now;
+
+-- name: GetAllWhiteListCountries :many
+SELECT country FROM country_whitelist;
+
+-- name: GetWhiteListCountryEntries :many
+SELECT * FROM country_whitelist;
+
+-- name: GetExpiredWhiteListCountries :many
+SELECT country FROM country_whitelist
+WHERE expires_at > 0 AND expires_at <= @now;
+
+-- name: CountWhiteListCountry :one
+SELECT COUNT(*) FROM country_whitelist
+WHERE country = @country;
+
+-- name: UpsertWhiteListCountry :exec
+INSERT INTO country_whitelist (country, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (@country, @expires_at, @comment, COALESCE(NULLIF(@source, ''), @default_source), @created_by, @created_at, @last_hit_at)
+ON CONFLICT (country) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(@source, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at);
+
+-- name: TouchWhiteListCountry :exec
+UPDATE country_whitelist SET last_hit_at = @last_hit_at
+WHERE country = @country;
+
+-- name: RemoveWhiteListCountry :execrows
+DELETE FROM country_whitelist
+WHERE country = @country;
+
+-- name: RemoveExpiredWhiteListCountries :exec
+DELETE FROM country_whitelist
+WHERE expires_at > 0 AND expires_at <= @now;
+
+-- name: GetAllWhiteListASNs :many
+SELECT asn FROM asn_whitelist;
+
+-- name: GetWhiteListASNEntries :many
+SELECT * FROM asn_whitelist;
+
+-- name: GetExpiredWhiteListASNs :many
+SELECT asn FROM asn_whitelist
+WHERE expires_at > 0 AND expires_at <= @now;
+
+-- name: CountWhiteListASN :one
+SELECT COUNT(*) FROM asn_whitelist
+WHERE asn = @asn;
+
+-- name: UpsertWhiteListASN :exec
+INSERT INTO asn_whitelist (asn, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (@asn, @expires_at, @comment, COALESCE(NULLIF(@source, ''), @default_source), @created_by, @created_at, @last_hit_at)
+ON CONFLICT (asn) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(@source, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at);
+
+-- name: TouchWhiteListASN :exec
+UPDATE asn_whitelist SET last_hit_at = @last_hit_at
+WHERE asn = @asn;
+
+-- name: RemoveWhiteListASN :execrows
+DELETE FROM asn_whitelist
+WHERE asn = @asn;
+
+-- name: RemoveExpiredWhiteListASNs :exec
+DELETE FROM asn_whitelist
+WHERE expires_at > 0 AND expires_at <= @now;
+
+-- name: GetAllWhiteListJA3s :many
+SELECT hash FROM ja3_whitelist;
+
+-- name: GetWhiteListJA3Entries :many
+SELECT * FROM ja3_whitelist;
+
+-- name: GetExpiredWhiteListJA3s :many
+SELECT hash FROM ja3_whitelist
+WHERE expires_at > 0 AND expires_at <= @now;
+
+-- name: CountWhiteListJA3 :one
+SELECT COUNT(*) FROM ja3_whitelist
+WHERE hash = @hash;
+
+-- name: UpsertWhiteListJA3 :exec
+INSERT INTO ja3_whitelist (hash, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (@hash, @expires_at, @comment, COALESCE(NULLIF(@source, ''), @default_source), @created_by, @created_at, @last_hit_at)
+ON CONFLICT (hash) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(@source, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at);
+
+-- name: TouchWhiteListJA3 :exec
+UPDATE ja3_whitelist SET last_hit_at = @last_hit_at
+WHERE hash = @hash;
+
+-- name: RemoveWhiteListJA3 :execrows
+DELETE FROM ja3_whitelist
+WHERE hash = @hash;
+
+-- name: RemoveExpiredWhiteListJA3s :exec
+DELETE FROM ja3_whitelist
+WHERE expires_at > 0 AND expires_at <= @now;
@@ -9,6 +9,120 @@ import (
 	"context"
 )
 
+const countWhiteListASN = `-- name: CountWhiteListASN :one
+SELECT COUNT(*) FROM asn_whitelist
+WHERE asn = ?1
+`
+
+func (q *Queries) CountWhiteListASN(ctx context.Context, db DBTX, asn int64) (int64, error) {
+	row := db.QueryRowContext(ctx, countWhiteListASN, asn)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countWhiteListCountry = `-- name: CountWhiteListCountry :one
+SELECT COUNT(*) FROM country_whitelist
+WHERE country = ?1
+`
+
+func (q *Queries) CountWhiteListCountry(ctx context.Context, db DBTX, country string) (int64, error) {
+	row := db.QueryRowContext(ctx, countWhiteListCountry, country)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countWhiteListDomain = `-- name: CountWhiteListDomain :one
+SELECT COUNT(*) FROM domain_whitelist
+WHERE domain = ?1
+`
+
+func (q *Queries) CountWhiteListDomain(ctx context.Context, db DBTX, domain string) (int64, error) {
+	row := db.QueryRowContext(ctx, countWhiteListDomain, domain)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countWhiteListJA3 = `-- name: CountWhiteListJA3 :one
+SELECT COUNT(*) FROM ja3_whitelist
+WHERE hash = ?1
+`
+
+func (q *Queries) CountWhiteListJA3(ctx context.Context, db DBTX, hash string) (int64, error) {
+	row := db.QueryRowContext(ctx, countWhiteListJA3, hash)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countWhiteListSubnet = `-- name: CountWhiteListSubnet :one
+SELECT COUNT(*) FROM subnet_whitelist
+WHERE subnet = ?1
+`
+
+func (q *Queries) CountWhiteListSubnet(ctx context.Context, db DBTX, subnet string) (int64, error) {
+	row := db.QueryRowContext(ctx, countWhiteListSubnet, subnet)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getAllWhiteListASNs = `-- name: GetAllWhiteListASNs :many
+SELECT asn FROM asn_whitelist
+`
+
+func (q *Queries) GetAllWhiteListASNs(ctx context.Context, db DBTX) ([]int64, error) {
+	rows, err := db.QueryContext(ctx, getAllWhiteListASNs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var asn int64
+		if err := rows.Scan(&asn); err != nil {
+			return nil, err
+		}
+		items = append(items, asn)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllWhiteListCountries = `-- name: GetAllWhiteListCountries :many
+SELECT country FROM country_whitelist
+`
+
+func (q *Queries) GetAllWhiteListCountries(ctx context.Context, db DBTX) ([]string, error) {
+	rows, err := db.QueryContext(ctx, getAllWhiteListCountries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var country string
+		if err := rows.Scan(&country); err != nil {
+			return nil, err
+		}
+		items = append(items, country)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAllWhiteListDomains = `-- name: GetAllWhiteListDomains :many
 SELECT domain FROM domain_whitelist
 `
@@ -36,6 +150,33 @@ func (q *Queries) GetAllWhiteListDomains(ctx context.Context, db DBTX) ([]string
 	return items, nil
 }
 
+const getAllWhiteListJA3s = `-- name: GetAllWhiteListJA3s :many
+SELECT hash FROM ja3_whitelist
+`
+
+func (q *Queries) GetAllWhiteListJA3s(ctx context.Context, db DBTX) ([]string, error) {
+	rows, err := db.QueryContext(ctx, getAllWhiteListJA3s)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		items = append(items, hash)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAllWhiteListSubnets = `-- name: GetAllWhiteListSubnets :many
 SELECT subnet FROM subnet_whitelist
 `
@@ -63,42 +204,682 @@ func (q *Queries) GetAllWhiteListSubnets(ctx context.Context, db DBTX) ([]string
 	return items, nil
 }
 
-const removeWhiteListDomain = `-- name: RemoveWhiteListDomain :exec
+const getExpiredWhiteListASNs = `-- name: GetExpiredWhiteListASNs :many
+SELECT asn FROM asn_whitelist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) GetExpiredWhiteListASNs(ctx context.Context, db DBTX, now int64) ([]int64, error) {
+	rows, err := db.QueryContext(ctx, getExpiredWhiteListASNs, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var asn int64
+		if err := rows.Scan(&asn); err != nil {
+			return nil, err
+		}
+		items = append(items, asn)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExpiredWhiteListCountries = `-- name: GetExpiredWhiteListCountries :many
+SELECT country FROM country_whitelist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) GetExpiredWhiteListCountries(ctx context.Context, db DBTX, now int64) ([]string, error) {
+	rows, err := db.QueryContext(ctx, getExpiredWhiteListCountries, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var country string
+		if err := rows.Scan(&country); err != nil {
+			return nil, err
+		}
+		items = append(items, country)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExpiredWhiteListDomains = `-- name: GetExpiredWhiteListDomains :many
+SELECT domain FROM domain_whitelist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) GetExpiredWhiteListDomains(ctx context.Context, db DBTX, now int64) ([]string, error) {
+	rows, err := db.QueryContext(ctx, getExpiredWhiteListDomains, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var domain string
+		if err := rows.Scan(&domain); err != nil {
+			return nil, err
+		}
+		items = append(items, domain)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExpiredWhiteListJA3s = `-- name: GetExpiredWhiteListJA3s :many
+SELECT hash FROM ja3_whitelist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) GetExpiredWhiteListJA3s(ctx context.Context, db DBTX, now int64) ([]string, error) {
+	rows, err := db.QueryContext(ctx, getExpiredWhiteListJA3s, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		items = append(items, hash)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExpiredWhiteListSubnets = `-- name: GetExpiredWhiteListSubnets :many
+SELECT subnet FROM subnet_whitelist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) GetExpiredWhiteListSubnets(ctx context.Context, db DBTX, now int64) ([]string, error) {
+	rows, err := db.QueryContext(ctx, getExpiredWhiteListSubnets, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var subnet string
+		if err := rows.Scan(&subnet); err != nil {
+			return nil, err
+		}
+		items = append(items, subnet)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWhiteListASNEntries = `-- name: GetWhiteListASNEntries :many
+SELECT asn, expires_at, comment, source, created_by, created_at, last_hit_at FROM asn_whitelist
+`
+
+func (q *Queries) GetWhiteListASNEntries(ctx context.Context, db DBTX) ([]*AsnWhitelist, error) {
+	rows, err := db.QueryContext(ctx, getWhiteListASNEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*AsnWhitelist
+	for rows.Next() {
+		var i AsnWhitelist
+		if err := rows.Scan(
+			&i.Asn,
+			&i.ExpiresAt,
+			&i.Comment,
+			&i.Source,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.LastHitAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWhiteListCountryEntries = `-- name: GetWhiteListCountryEntries :many
+SELECT country, expires_at, comment, source, created_by, created_at, last_hit_at FROM country_whitelist
+`
+
+func (q *Queries) GetWhiteListCountryEntries(ctx context.Context, db DBTX) ([]*CountryWhitelist, error) {
+	rows, err := db.QueryContext(ctx, getWhiteListCountryEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*CountryWhitelist
+	for rows.Next() {
+		var i CountryWhitelist
+		if err := rows.Scan(
+			&i.Country,
+			&i.ExpiresAt,
+			&i.Comment,
+			&i.Source,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.LastHitAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWhiteListDomainEntries = `-- name: GetWhiteListDomainEntries :many
+SELECT domain, expires_at, comment, source, created_by, created_at, last_hit_at FROM domain_whitelist
+`
+
+func (q *Queries) GetWhiteListDomainEntries(ctx context.Context, db DBTX) ([]*DomainWhitelist, error) {
+	rows, err := db.QueryContext(ctx, getWhiteListDomainEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*DomainWhitelist
+	for rows.Next() {
+		var i DomainWhitelist
+		if err := rows.Scan(
+			&i.Domain,
+			&i.ExpiresAt,
+			&i.Comment,
+			&i.Source,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.LastHitAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWhiteListJA3Entries = `-- name: GetWhiteListJA3Entries :many
+SELECT hash, expires_at, comment, source, created_by, created_at, last_hit_at FROM ja3_whitelist
+`
+
+func (q *Queries) GetWhiteListJA3Entries(ctx context.Context, db DBTX) ([]*Ja3Whitelist, error) {
+	rows, err := db.QueryContext(ctx, getWhiteListJA3Entries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Ja3Whitelist
+	for rows.Next() {
+		var i Ja3Whitelist
+		if err := rows.Scan(
+			&i.Hash,
+			&i.ExpiresAt,
+			&i.Comment,
+			&i.Source,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.LastHitAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWhiteListSubnetEntries = `-- name: GetWhiteListSubnetEntries :many
+SELECT subnet, expires_at, comment, source, created_by, created_at, last_hit_at FROM subnet_whitelist
+`
+
+func (q *Queries) GetWhiteListSubnetEntries(ctx context.Context, db DBTX) ([]*SubnetWhitelist, error) {
+	rows, err := db.QueryContext(ctx, getWhiteListSubnetEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*SubnetWhitelist
+	for rows.Next() {
+		var i SubnetWhitelist
+		if err := rows.Scan(
+			&i.Subnet,
+			&i.ExpiresAt,
+			&i.Comment,
+			&i.Source,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.LastHitAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeExpiredWhiteListASNs = `-- name: RemoveExpiredWhiteListASNs :exec
+DELETE FROM asn_whitelist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) RemoveExpiredWhiteListASNs(ctx context.Context, db DBTX, now int64) error {
+	_, err := db.ExecContext(ctx, removeExpiredWhiteListASNs, now)
+	return err
+}
+
+const removeExpiredWhiteListCountries = `-- name: RemoveExpiredWhiteListCountries :exec
+DELETE FROM country_whitelist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) RemoveExpiredWhiteListCountries(ctx context.Context, db DBTX, now int64) error {
+	_, err := db.ExecContext(ctx, removeExpiredWhiteListCountries, now)
+	return err
+}
+
+const removeExpiredWhiteListDomains = `-- name: RemoveExpiredWhiteListDomains :exec
 DELETE FROM domain_whitelist
-WHERE domain = ?1
+WHERE expires_at > 0 AND expires_at <= ?1
 `
 
-func (q *Queries) RemoveWhiteListDomain(ctx context.Context, db DBTX, domain string) error {
-	_, err := db.ExecContext(ctx, removeWhiteListDomain, domain)
+func (q *Queries) RemoveExpiredWhiteListDomains(ctx context.Context, db DBTX, now int64) error {
+	_, err := db.ExecContext(ctx, removeExpiredWhiteListDomains, now)
 	return err
 }
 
-const removeWhiteListSubnet = `-- name: RemoveWhiteListSubnet :exec
+const removeExpiredWhiteListJA3s = `-- name: RemoveExpiredWhiteListJA3s :exec
+DELETE FROM ja3_whitelist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) RemoveExpiredWhiteListJA3s(ctx context.Context, db DBTX, now int64) error {
+	_, err := db.ExecContext(ctx, removeExpiredWhiteListJA3s, now)
+	return err
+}
+
+const removeExpiredWhiteListSubnets = `-- name: RemoveExpiredWhiteListSubnets :exec
+DELETE FROM subnet_whitelist
+WHERE expires_at > 0 AND expires_at <= ?1
+`
+
+func (q *Queries) RemoveExpiredWhiteListSubnets(ctx context.Context, db DBTX, now int64) error {
+	_, err := db.ExecContext(ctx, removeExpiredWhiteListSubnets, now)
+	return err
+}
+
+const removeWhiteListASN = `-- name: RemoveWhiteListASN :execrows
+DELETE FROM asn_whitelist
+WHERE asn = ?1
+`
+
+func (q *Queries) RemoveWhiteListASN(ctx context.Context, db DBTX, asn int64) (int64, error) {
+	result, err := db.ExecContext(ctx, removeWhiteListASN, asn)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const removeWhiteListCountry = `-- name: RemoveWhiteListCountry :execrows
+DELETE FROM country_whitelist
+WHERE country = ?1
+`
+
+func (q *Queries) RemoveWhiteListCountry(ctx context.Context, db DBTX, country string) (int64, error) {
+	result, err := db.ExecContext(ctx, removeWhiteListCountry, country)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const removeWhiteListDomain = `-- name: RemoveWhiteListDomain :execrows
+DELETE FROM domain_whitelist
+WHERE domain = ?1
+`
+
+func (q *Queries) RemoveWhiteListDomain(ctx context.Context, db DBTX, domain string) (int64, error) {
+	result, err := db.ExecContext(ctx, removeWhiteListDomain, domain)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const removeWhiteListJA3 = `-- name: RemoveWhiteListJA3 :execrows
+DELETE FROM ja3_whitelist
+WHERE hash = ?1
+`
+
+func (q *Queries) RemoveWhiteListJA3(ctx context.Context, db DBTX, hash string) (int64, error) {
+	result, err := db.ExecContext(ctx, removeWhiteListJA3, hash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const removeWhiteListSubnet = `-- name: RemoveWhiteListSubnet :execrows
 DELETE FROM subnet_whitelist
 WHERE subnet = ?1
 `
 
-func (q *Queries) RemoveWhiteListSubnet(ctx context.Context, db DBTX, subnet string) error {
-	_, err := db.ExecContext(ctx, removeWhiteListSubnet, subnet)
+func (q *Queries) RemoveWhiteListSubnet(ctx context.Context, db DBTX, subnet string) (int64, error) {
+	result, err := db.ExecContext(ctx, removeWhiteListSubnet, subnet)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const touchWhiteListASN = `-- name: TouchWhiteListASN :exec
+UPDATE asn_whitelist SET last_hit_at = ?1
+WHERE asn = ?2
+`
+
+type TouchWhiteListASNParams struct {
+	LastHitAt int64 `json:"last_hit_at"`
+	Asn       int64 `json:"asn"`
+}
+
+func (q *Queries) TouchWhiteListASN(ctx context.Context, db DBTX, arg *TouchWhiteListASNParams) error {
+	_, err := db.ExecContext(ctx, touchWhiteListASN, arg.LastHitAt, arg.Asn)
+	return err
+}
+
+const touchWhiteListCountry = `-- name: TouchWhiteListCountry :exec
+UPDATE country_whitelist SET last_hit_at = ?1
+WHERE country = ?2
+`
+
+type TouchWhiteListCountryParams struct {
+	LastHitAt int64  `json:"last_hit_at"`
+	Country   string `json:"country"`
+}
+
+func (q *Queries) TouchWhiteListCountry(ctx context.Context, db DBTX, arg *TouchWhiteListCountryParams) error {
+	_, err := db.ExecContext(ctx, touchWhiteListCountry, arg.LastHitAt, arg.Country)
+	return err
+}
+
+const touchWhiteListDomain = `-- name: TouchWhiteListDomain :exec
+UPDATE domain_whitelist SET last_hit_at = ?1
+WHERE domain = ?2
+`
+
+type TouchWhiteListDomainParams struct {
+	LastHitAt int64  `json:"last_hit_at"`
+	Domain    string `json:"domain"`
+}
+
+func (q *Queries) TouchWhiteListDomain(ctx context.Context, db DBTX, arg *TouchWhiteListDomainParams) error {
+	_, err := db.ExecContext(ctx, touchWhiteListDomain, arg.LastHitAt, arg.Domain)
+	return err
+}
+
+const touchWhiteListJA3 = `-- name: TouchWhiteListJA3 :exec
+UPDATE ja3_whitelist SET last_hit_at = ?1
+WHERE hash = ?2
+`
+
+type TouchWhiteListJA3Params struct {
+	LastHitAt int64  `json:"last_hit_at"`
+	Hash      string `json:"hash"`
+}
+
+func (q *Queries) TouchWhiteListJA3(ctx context.Context, db DBTX, arg *TouchWhiteListJA3Params) error {
+	_, err := db.ExecContext(ctx, touchWhiteListJA3, arg.LastHitAt, arg.Hash)
+	return err
+}
+
+const touchWhiteListSubnet = `-- name: TouchWhiteListSubnet :exec
+UPDATE subnet_whitelist SET last_hit_at = ?1
+WHERE subnet = ?2
+`
+
+type TouchWhiteListSubnetParams struct {
+	LastHitAt int64  `json:"last_hit_at"`
+	Subnet    string `json:"subnet"`
+}
+
+func (q *Queries) TouchWhiteListSubnet(ctx context.Context, db DBTX, arg *TouchWhiteListSubnetParams) error {
+	_, err := db.ExecContext(ctx, touchWhiteListSubnet, arg.LastHitAt, arg.Subnet)
+	return err
+}
+
+const upsertWhiteListASN = `-- name: UpsertWhiteListASN :exec
+INSERT INTO asn_whitelist (asn, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (?1, ?2, ?3, COALESCE(NULLIF(?4, ''), ?5), ?6, ?7, ?8)
+ON CONFLICT (asn) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(?4, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at)
+`
+
+type UpsertWhiteListASNParams struct {
+	Asn           int64  `json:"asn"`
+	ExpiresAt     int64  `json:"expires_at"`
+	Comment       string `json:"comment"`
+	Source        string `json:"source"`
+	DefaultSource string `json:"default_source"`
+	CreatedBy     string `json:"created_by"`
+	CreatedAt     int64  `json:"created_at"`
+	LastHitAt     int64  `json:"last_hit_at"`
+}
+
+func (q *Queries) UpsertWhiteListASN(ctx context.Context, db DBTX, arg *UpsertWhiteListASNParams) error {
+	_, err := db.ExecContext(ctx, upsertWhiteListASN,
+		arg.Asn,
+		arg.ExpiresAt,
+		arg.Comment,
+		arg.Source,
+		arg.DefaultSource,
+		arg.CreatedBy,
+		arg.CreatedAt,
+		arg.LastHitAt,
+	)
+	return err
+}
+
+const upsertWhiteListCountry = `-- name: UpsertWhiteListCountry :exec
+INSERT INTO country_whitelist (country, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (?1, ?2, ?3, COALESCE(NULLIF(?4, ''), ?5), ?6, ?7, ?8)
+ON CONFLICT (country) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(?4, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at)
+`
+
+type UpsertWhiteListCountryParams struct {
+	Country       string `json:"country"`
+	ExpiresAt     int64  `json:"expires_at"`
+	Comment       string `json:"comment"`
+	Source        string `json:"source"`
+	DefaultSource string `json:"default_source"`
+	CreatedBy     string `json:"created_by"`
+	CreatedAt     int64  `json:"created_at"`
+	LastHitAt     int64  `json:"last_hit_at"`
+}
+
+func (q *Queries) UpsertWhiteListCountry(ctx context.Context, db DBTX, arg *UpsertWhiteListCountryParams) error {
+	_, err := db.ExecContext(ctx, upsertWhiteListCountry,
+		arg.Country,
+		arg.ExpiresAt,
+		arg.Comment,
+		arg.Source,
+		arg.DefaultSource,
+		arg.CreatedBy,
+		arg.CreatedAt,
+		arg.LastHitAt,
+	)
 	return err
 }
 
 const upsertWhiteListDomain = `-- name: UpsertWhiteListDomain :exec
-INSERT INTO domain_whitelist (domain)
-VALUES (?1)
+INSERT INTO domain_whitelist (domain, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (?1, ?2, ?3, COALESCE(NULLIF(?4, ''), ?5), ?6, ?7, ?8)
+ON CONFLICT (domain) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(?4, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at)
+`
+
+type UpsertWhiteListDomainParams struct {
+	Domain        string `json:"domain"`
+	ExpiresAt     int64  `json:"expires_at"`
+	Comment       string `json:"comment"`
+	Source        string `json:"source"`
+	DefaultSource string `json:"default_source"`
+	CreatedBy     string `json:"created_by"`
+	CreatedAt     int64  `json:"created_at"`
+	LastHitAt     int64  `json:"last_hit_at"`
+}
+
+func (q *Queries) UpsertWhiteListDomain(ctx context.Context, db DBTX, arg *UpsertWhiteListDomainParams) error {
+	_, err := db.ExecContext(ctx, upsertWhiteListDomain,
+		arg.Domain,
+		arg.ExpiresAt,
+		arg.Comment,
+		arg.Source,
+		arg.DefaultSource,
+		arg.CreatedBy,
+		arg.CreatedAt,
+		arg.LastHitAt,
+	)
+	return err
+}
+
+const upsertWhiteListJA3 = `-- name: UpsertWhiteListJA3 :exec
+INSERT INTO ja3_whitelist (hash, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (?1, ?2, ?3, COALESCE(NULLIF(?4, ''), ?5), ?6, ?7, ?8)
+ON CONFLICT (hash) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(?4, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at)
 `
 
-func (q *Queries) UpsertWhiteListDomain(ctx context.Context, db DBTX, domain string) error {
-	_, err := db.ExecContext(ctx, upsertWhiteListDomain, domain)
+type UpsertWhiteListJA3Params struct {
+	Hash          string `json:"hash"`
+	ExpiresAt     int64  `json:"expires_at"`
+	Comment       string `json:"comment"`
+	Source        string `json:"source"`
+	DefaultSource string `json:"default_source"`
+	CreatedBy     string `json:"created_by"`
+	CreatedAt     int64  `json:"created_at"`
+	LastHitAt     int64  `json:"last_hit_at"`
+}
+
+func (q *Queries) UpsertWhiteListJA3(ctx context.Context, db DBTX, arg *UpsertWhiteListJA3Params) error {
+	_, err := db.ExecContext(ctx, upsertWhiteListJA3,
+		arg.Hash,
+		arg.ExpiresAt,
+		arg.Comment,
+		arg.Source,
+		arg.DefaultSource,
+		arg.CreatedBy,
+		arg.CreatedAt,
+		arg.LastHitAt,
+	)
 	return err
 }
 
 const upsertWhiteListSubnet = `-- name: UpsertWhiteListSubnet :exec
-INSERT INTO subnet_whitelist (subnet)
-VALUES (?1)
+INSERT INTO subnet_whitelist (subnet, expires_at, comment, source, created_by, created_at, last_hit_at)
+VALUES (?1, ?2, ?3, COALESCE(NULLIF(?4, ''), ?5), ?6, ?7, ?8)
+ON CONFLICT (subnet) DO UPDATE SET
+    expires_at = CASE WHEN excluded.expires_at > 0 THEN excluded.expires_at ELSE expires_at END,
+    comment = COALESCE(NULLIF(excluded.comment, ''), comment),
+    source = COALESCE(NULLIF(?4, ''), source),
+    last_hit_at = MAX(last_hit_at, excluded.last_hit_at)
 `
 
-func (q *Queries) UpsertWhiteListSubnet(ctx context.Context, db DBTX, subnet string) error {
-	_, err := db.ExecContext(ctx, upsertWhiteListSubnet, subnet)
+type UpsertWhiteListSubnetParams struct {
+	Subnet        string `json:"subnet"`
+	ExpiresAt     int64  `json:"expires_at"`
+	Comment       string `json:"comment"`
+	Source        string `json:"source"`
+	DefaultSource string `json:"default_source"`
+	CreatedBy     string `json:"created_by"`
+	CreatedAt     int64  `json:"created_at"`
+	LastHitAt     int64  `json:"last_hit_at"`
+}
+
+func (q *Queries) UpsertWhiteListSubnet(ctx context.Context, db DBTX, arg *UpsertWhiteListSubnetParams) error {
+	_, err := db.ExecContext(ctx, upsertWhiteListSubnet,
+		arg.Subnet,
+		arg.ExpiresAt,
+		arg.Comment,
+		arg.Source,
+		arg.DefaultSource,
+		arg.CreatedBy,
+		arg.CreatedAt,
+		arg.LastHitAt,
+	)
 	return err
 }
@@ -9,6 +9,12 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/cnaize/meds/src/api"
+	"github.com/cnaize/meds/src/config"
+	"github.com/cnaize/meds/src/core"
+	"github.com/cnaize/meds/src/core/filter"
+	"github.com/cnaize/meds/src/core/logger"
+	"github.com/cnaize/meds/src/core/stats"
+	"github.com/cnaize/meds/src/core/stream"
 	"github.com/cnaize/meds/src/database"
 	"github.com/cnaize/meds/src/types"
 )
@@ -16,6 +22,10 @@ import (
 type Server struct {
 	router *gin.Engine
 	server *http.Server
+	reaper *api.Reaper
+	hub    *stream.Hub
+	// ends the long-lived requests (e.g. event streams)
+	cancel context.CancelFunc
 }
 
 func NewServer(
@@ -23,16 +33,35 @@ func NewServer(
 	username,
 	password string,
 	db *database.Database,
+	logger *logger.Logger,
+	reloadFn func(ctx context.Context) (config.Diff, error),
+	rebuildFn func(ctx context.Context) error,
+	statusFn func() []core.FilterStatus,
+	updateFn func(typ filter.FilterType, name string) error,
+	enableFn func(typ filter.FilterType, name string, enabled bool) error,
+	rollbackFn func(ctx context.Context, typ filter.FilterType, name string) error,
+	explainFn func(packet *types.Packet) core.Explanation,
 	subnetWhiteList *types.SubnetList,
 	subnetBlackList *types.SubnetList,
 	domainWhiteList *types.DomainList,
 	domainBlackList *types.DomainList,
+	countryWhiteList *types.CountryList,
 	countryBlackList *types.CountryList,
+	asnList *types.ASNList,
+	asnWhiteList *types.ASNumberList,
+	asnBlackList *types.ASNumberList,
+	ja3WhiteList *types.JA3List,
+	ja3BlackList *types.JA3List,
+	ja3Observed *types.TopCounter,
+	top *stats.Top,
+	hub *stream.Hub,
 ) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+
 	r := gin.New()
 	r.Use(gin.BasicAuth(gin.Accounts{username: password}), gin.Recovery())
 
-	api.Register(r, db, subnetWhiteList, subnetBlackList, domainWhiteList, domainBlackList, countryBlackList)
+	api.Register(ctx, r, db, reloadFn, rebuildFn, statusFn, updateFn, enableFn, rollbackFn, explainFn, subnetWhiteList, subnetBlackList, domainWhiteList, domainBlackList, countryWhiteList, countryBlackList, asnList, asnWhiteList, asnBlackList, ja3WhiteList, ja3BlackList, ja3Observed, top, hub)
 
 	return &Server{
 		router: r,
@@ -40,10 +69,16 @@ func NewServer(
 			Addr:    addr,
 			Handler: r,
 		},
+		reaper: api.NewReaper(db, logger, subnetWhiteList, subnetBlackList, domainWhiteList, domainBlackList, countryWhiteList, countryBlackList, asnWhiteList, asnBlackList, ja3WhiteList, ja3BlackList),
+		hub:    hub,
+		cancel: cancel,
 	}
 }
 
 func (s *Server) Run(ctx context.Context) error {
+	// remove expired list entries
+	go s.reaper.Run(ctx)
+
 	if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		return err
 	}
@@ -52,6 +87,10 @@ func (s *Server) Run(ctx context.Context) error {
 }
 
 func (s *Server) Close() error {
+	// NOTE: shutdown waits for the active requests, so the streams are closed first
+	s.hub.Close()
+	s.cancel()
+
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
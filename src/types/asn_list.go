@@ -5,6 +5,8 @@ import (
 	"sync/atomic"
 
 	"github.com/gaissmai/bart"
+
+	"github.com/cnaize/meds/lib/util/get"
 )
 
 type ASN struct {
@@ -14,11 +16,14 @@ type ASN struct {
 
 type ASNList struct {
 	list atomic.Pointer[bart.Table[ASN]]
+	// organization names by asn
+	names atomic.Pointer[map[uint32]string]
 }
 
 func NewASNList() *ASNList {
 	var l ASNList
 	l.list.Store(new(bart.Table[ASN]))
+	l.names.Store(get.Ptr(make(map[uint32]string)))
 
 	return &l
 }
@@ -34,3 +39,12 @@ func (l *ASNList) Load() *bart.Table[ASN] {
 func (l *ASNList) Store(list *bart.Table[ASN]) {
 	l.list.Store(list)
 }
+
+// Name returns the asn organization name (if known)
+func (l *ASNList) Name(asn uint32) string {
+	return (*l.names.Load())[asn]
+}
+
+func (l *ASNList) StoreNames(names map[uint32]string) {
+	l.names.Store(&names)
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"sync/atomic"
+
+	"github.com/cnaize/meds/lib/util/get"
+)
+
+var ErrInvalidASN = errors.New("invalid asn")
+
+// ASNumberList is a list of autonomous system numbers
+type ASNumberList struct {
+	list atomic.Pointer[map[uint32]bool]
+	hits Hits[uint32]
+}
+
+func NewASNumberList() *ASNumberList {
+	var l ASNumberList
+	l.list.Store(get.Ptr(make(map[uint32]bool)))
+
+	return &l
+}
+
+func (l *ASNumberList) GetAll() []uint32 {
+	return slices.Sorted(maps.Keys(*l.list.Load()))
+}
+
+func (l *ASNumberList) Lookup(asn uint32) bool {
+	return (*l.list.Load())[asn]
+}
+
+// Hit looks up the asn and records the entry hit
+func (l *ASNumberList) Hit(asn uint32) bool {
+	ok := (*l.list.Load())[asn]
+	if ok {
+		l.hits.Touch(asn)
+	}
+
+	return ok
+}
+
+// FlushHits returns the entries hit since the previous flush
+func (l *ASNumberList) FlushHits() map[uint32]int64 {
+	return l.hits.Flush()
+}
+
+func (l *ASNumberList) Upsert(asns []uint32) error {
+	list := maps.Clone(*l.list.Load())
+	for _, asn := range asns {
+		if asn < 1 {
+			return ErrInvalidASN
+		}
+
+		list[asn] = true
+	}
+
+	l.list.Store(&list)
+
+	return nil
+}
+
+func (l *ASNumberList) Remove(asns []uint32) error {
+	list := maps.Clone(*l.list.Load())
+	for _, asn := range asns {
+		delete(list, asn)
+	}
+
+	l.list.Store(&list)
+
+	return nil
+}
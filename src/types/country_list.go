@@ -10,6 +10,7 @@ import (
 
 type CountryList struct {
 	list atomic.Pointer[map[string]bool]
+	hits Hits[string]
 }
 
 func NewCountryList() *CountryList {
@@ -29,10 +30,30 @@ func (l *CountryList) GetAll() []string {
 	return coutries
 }
 
+func (l *CountryList) Len() int {
+	return len(*l.list.Load())
+}
+
 func (l *CountryList) Lookup(country string) bool {
 	return (*l.list.Load())[strings.ToLower(country)]
 }
 
+// Hit looks up the country and records the entry hit
+func (l *CountryList) Hit(country string) bool {
+	country = strings.ToLower(country)
+	ok := (*l.list.Load())[country]
+	if ok {
+		l.hits.Touch(country)
+	}
+
+	return ok
+}
+
+// FlushHits returns the entries hit since the previous flush
+func (l *CountryList) FlushHits() map[string]int64 {
+	return l.hits.Flush()
+}
+
 func (l *CountryList) Upsert(coutries []string) error {
 	list := maps.Clone(*l.list.Load())
 	for _, country := range coutries {
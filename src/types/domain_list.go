@@ -10,6 +10,7 @@ import (
 
 type DomainList struct {
 	list atomic.Pointer[radix.Tree]
+	hits Hits[string]
 }
 
 func NewDomainList() *DomainList {
@@ -38,6 +39,32 @@ func (l *DomainList) Lookup(domain string) bool {
 	return false
 }
 
+// Match returns the list entry matching the domain or its parent
+func (l *DomainList) Match(domain string) (string, bool) {
+	match, _, ok := l.list.Load().LongestPrefix(get.ReversedDomain(domain))
+	if !ok {
+		return "", false
+	}
+
+	return get.ReversedDomain(match), true
+}
+
+// Hit looks up the domain and records the matched entry hit
+func (l *DomainList) Hit(domain string) bool {
+	domain = get.ReversedDomain(domain)
+	match, _, ok := l.list.Load().LongestPrefix(domain)
+	if ok {
+		l.hits.Touch(get.ReversedDomain(match))
+	}
+
+	return ok
+}
+
+// FlushHits returns the entries hit since the previous flush
+func (l *DomainList) FlushHits() map[string]int64 {
+	return l.hits.Flush()
+}
+
 func (l *DomainList) Upsert(domains []string) error {
 	list := radix.New()
 	// clone list
@@ -62,7 +89,7 @@ func (l *DomainList) Remove(domains []string) error {
 	}
 	// delete provided items
 	for _, domain := range domains {
-		list.DeletePrefix(get.ReversedDomain(domain))
+		list.Delete(get.ReversedDomain(domain))
 	}
 
 	l.list.Store(list)
@@ -0,0 +1,40 @@
+package types
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Hits keeps the last hit time (unix) of the list entries
+type Hits[K comparable] struct {
+	hits sync.Map // K -> *atomic.Int64
+}
+
+func (h *Hits[K]) Touch(key K) {
+	now := time.Now().Unix()
+	if hit, ok := h.hits.Load(key); ok {
+		hit.(*atomic.Int64).Store(now)
+		return
+	}
+
+	hit, _ := h.hits.LoadOrStore(key, new(atomic.Int64))
+	hit.(*atomic.Int64).Store(now)
+}
+
+// Flush returns the entries hit since the previous flush
+func (h *Hits[K]) Flush() map[K]int64 {
+	hits := make(map[K]int64)
+	h.hits.Range(func(key, hit any) bool {
+		if at := hit.(*atomic.Int64).Swap(0); at > 0 {
+			hits[key.(K)] = at
+		} else {
+			// NOTE: forget not hit entries (they may be removed from the list)
+			h.hits.CompareAndDelete(key, hit)
+		}
+
+		return true
+	})
+
+	return hits
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"encoding/hex"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cnaize/meds/lib/util/get"
+)
+
+var ErrInvalidJA3 = errors.New("invalid ja3")
+
+// JA3List is a list of JA3 hashes (md5 hex)
+type JA3List struct {
+	list atomic.Pointer[map[string]bool]
+	hits Hits[string]
+}
+
+func NewJA3List() *JA3List {
+	var l JA3List
+	l.list.Store(get.Ptr(make(map[string]bool)))
+
+	return &l
+}
+
+func (l *JA3List) GetAll() []string {
+	return slices.Sorted(maps.Keys(*l.list.Load()))
+}
+
+func (l *JA3List) Lookup(hash string) bool {
+	return (*l.list.Load())[strings.ToLower(hash)]
+}
+
+// Hit looks up the hash and records the entry hit
+func (l *JA3List) Hit(hash string) bool {
+	hash = strings.ToLower(hash)
+	ok := (*l.list.Load())[hash]
+	if ok {
+		l.hits.Touch(hash)
+	}
+
+	return ok
+}
+
+// FlushHits returns the entries hit since the previous flush
+func (l *JA3List) FlushHits() map[string]int64 {
+	return l.hits.Flush()
+}
+
+func (l *JA3List) Upsert(hashes []string) error {
+	list := maps.Clone(*l.list.Load())
+	for _, hash := range hashes {
+		if !IsJA3(hash) {
+			return ErrInvalidJA3
+		}
+
+		list[strings.ToLower(hash)] = true
+	}
+
+	l.list.Store(&list)
+
+	return nil
+}
+
+func (l *JA3List) Remove(hashes []string) error {
+	list := maps.Clone(*l.list.Load())
+	for _, hash := range hashes {
+		delete(list, strings.ToLower(hash))
+	}
+
+	l.list.Store(&list)
+
+	return nil
+}
+
+// IsJA3 reports whether the hash is a valid JA3 hash (md5 hex)
+func IsJA3(hash string) bool {
+	_, err := hex.DecodeString(hash)
+	return err == nil && len(hash) == 32
+}
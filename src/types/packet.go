@@ -1,6 +1,9 @@
 package types
 
 import (
+	"errors"
+	"fmt"
+	"net"
 	"net/netip"
 
 	"github.com/dreadl0ck/ja3"
@@ -11,6 +14,9 @@ import (
 	"github.com/cnaize/meds/lib/util/get"
 )
 
+// ErrNotIPv4 means the packet can't be filtered, only ipv4 traffic is captured (iptables, not ip6tables)
+var ErrNotIPv4 = errors.New("only ipv4 packets are filtered")
+
 type tls struct {
 	sni string
 	ja3 string
@@ -38,6 +44,45 @@ func NewPacket(payload []byte) (*Packet, error) {
 	}, nil
 }
 
+// NewLookupPacket builds an inbound TCP packet with the provided TLS data,
+// it's used to explain the filters verdicts (ipv4 only, see ErrNotIPv4)
+func NewLookupPacket(srcIP netip.Addr, dstPort uint16, sni, hash string) (*Packet, error) {
+	if !srcIP.Is4() {
+		return nil, fmt.Errorf("%w: %s", ErrNotIPv4, srcIP)
+	}
+
+	ip4 := layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		Protocol: layers.IPProtocolTCP,
+		SrcIP:    srcIP.AsSlice(),
+		DstIP:    net.IPv4zero.To4(),
+	}
+	tcp := layers.TCP{
+		SrcPort: 65535,
+		DstPort: layers.TCPPort(dstPort),
+		SYN:     true,
+		Window:  65535,
+	}
+	if err := tcp.SetNetworkLayerForChecksum(&ip4); err != nil {
+		return nil, fmt.Errorf("set network layer: %w", err)
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}, &ip4, &tcp); err != nil {
+		return nil, fmt.Errorf("serialize: %w", err)
+	}
+
+	packet, err := NewPacket(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: the parsed client hello is cached, so the filters see the provided data
+	packet.tls = &tls{sni: sni, ja3: hash}
+
+	return packet, nil
+}
+
 func (p *Packet) Trusted() bool {
 	tcp, ok := p.packet.Layer(layers.LayerTypeTCP).(*layers.TCP)
 	if !ok {
@@ -111,8 +156,9 @@ func (p *Packet) GetReversedDomains() []string {
 // NOTE: pass nil as ASNList to get ASN from cache
 func (p *Packet) GetASN(asnlist *ASNList) (ASN, bool) {
 	// get from cache
-	if asnlist == nil || p.asn.ASN > 0 {
-		return p.asn, p.asn.ASN > 0
+	// NOTE: mmdb may provide a country without asn
+	if asnlist == nil || p.asn != (ASN{}) {
+		return p.asn, p.asn != (ASN{})
 	}
 
 	srcIP, ok := p.GetSrcIP()
@@ -0,0 +1,8 @@
+package types
+
+// white/black list entry sources
+const (
+	SourceAPI    = "api"
+	SourceAuto   = "auto"
+	SourceImport = "import"
+)
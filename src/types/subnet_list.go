@@ -9,6 +9,7 @@ import (
 
 type SubnetList struct {
 	list atomic.Pointer[bart.Lite]
+	hits Hits[netip.Prefix]
 }
 
 func NewSubnetList() *SubnetList {
@@ -20,8 +21,8 @@ func NewSubnetList() *SubnetList {
 
 func (l *SubnetList) GetAll() []netip.Prefix {
 	list := l.list.Load()
-	subnets := make([]netip.Prefix, 0, list.Size4())
-	for subnet := range list.All4() {
+	subnets := make([]netip.Prefix, 0, list.Size())
+	for subnet := range list.All() {
 		subnets = append(subnets, subnet)
 	}
 
@@ -32,6 +33,26 @@ func (l *SubnetList) Lookup(subnet netip.Prefix) bool {
 	return l.list.Load().OverlapsPrefix(subnet)
 }
 
+// Match returns the longest list entry containing the ip
+func (l *SubnetList) Match(ip netip.Addr) (netip.Prefix, bool) {
+	return l.list.Load().LookupPrefixLPM(netip.PrefixFrom(ip, ip.BitLen()))
+}
+
+// Hit looks up the ip and records the matched entry hit
+func (l *SubnetList) Hit(ip netip.Addr) bool {
+	match, ok := l.Match(ip)
+	if ok {
+		l.hits.Touch(match)
+	}
+
+	return ok
+}
+
+// FlushHits returns the entries hit since the previous flush
+func (l *SubnetList) FlushHits() map[netip.Prefix]int64 {
+	return l.hits.Flush()
+}
+
 func (l *SubnetList) Upsert(subnets []netip.Prefix) error {
 	list := l.list.Load().Clone()
 	for _, subnet := range subnets {
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"net/netip"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSubnetListMatch(t *testing.T) {
+	list := NewSubnetList()
+	subnets := []netip.Prefix{
+		netip.MustParsePrefix("1.2.3.0/24"),
+		netip.MustParsePrefix("1.2.3.4/32"),
+		netip.MustParsePrefix("2001:db8::/32"),
+		netip.MustParsePrefix("2001:db8::1/128"),
+	}
+	if err := list.Upsert(subnets); err != nil {
+		t.Fatalf("upsert: %s", err)
+	}
+
+	tests := []struct {
+		ip    string
+		match string
+	}{
+		{ip: "1.2.3.4", match: "1.2.3.4/32"},
+		{ip: "1.2.3.5", match: "1.2.3.0/24"},
+		{ip: "1.2.4.1"},
+		{ip: "2001:db8::1", match: "2001:db8::1/128"},
+		{ip: "2001:db8::2", match: "2001:db8::/32"},
+		{ip: "2001:db9::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			match, ok := list.Match(netip.MustParseAddr(tt.ip))
+			if ok != (tt.match != "") || (ok && match.String() != tt.match) {
+				t.Errorf("match %s %t, want %q", match, ok, tt.match)
+			}
+			if hit := list.Hit(netip.MustParseAddr(tt.ip)); hit != ok {
+				t.Errorf("hit %t, want %t", hit, ok)
+			}
+		})
+	}
+
+	hits := list.FlushHits()
+	if len(hits) != 4 {
+		t.Errorf("hits %v, want every matched entry", hits)
+	}
+
+	all := list.GetAll()
+	byString := func(a, b netip.Prefix) int { return strings.Compare(a.String(), b.String()) }
+	slices.SortFunc(all, byString)
+	slices.SortFunc(subnets, byString)
+	if !slices.Equal(all, subnets) {
+		t.Errorf("all %v, want %v", all, subnets)
+	}
+}
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+	"sync"
+)
+
+type Count struct {
+	Key   string
+	Count uint64
+}
+
+// TopCounter approximately counts the most frequent keys in bounded memory
+// (space-saving: a new key replaces the least frequent one when full)
+type TopCounter struct {
+	mu     sync.Mutex
+	size   int
+	counts countHeap
+}
+
+func NewTopCounter(size int) *TopCounter {
+	size = max(1, size)
+
+	return &TopCounter{
+		size: size,
+		counts: countHeap{
+			items: make([]Count, 0, size),
+			index: make(map[string]int, size),
+		},
+	}
+}
+
+func (c *TopCounter) Observe(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if i, ok := c.counts.index[key]; ok {
+		c.counts.items[i].Count++
+		heap.Fix(&c.counts, i)
+		return
+	}
+
+	if c.counts.Len() < c.size {
+		heap.Push(&c.counts, Count{Key: key, Count: 1})
+		return
+	}
+
+	// replace the least frequent key
+	least := c.counts.items[0]
+	delete(c.counts.index, least.Key)
+	c.counts.items[0] = Count{Key: key, Count: least.Count + 1}
+	c.counts.index[key] = 0
+	heap.Fix(&c.counts, 0)
+}
+
+// Top returns up to "n" most frequent keys
+func (c *TopCounter) Top(n int) []Count {
+	c.mu.Lock()
+	counts := slices.Clone(c.counts.items)
+	c.mu.Unlock()
+
+	slices.SortFunc(counts, func(a, b Count) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Key, b.Key))
+	})
+
+	return counts[:min(max(0, n), len(counts))]
+}
+
+// countHeap is the min-heap of counts with the keys positions
+type countHeap struct {
+	items []Count
+	index map[string]int
+}
+
+func (h *countHeap) Len() int {
+	return len(h.items)
+}
+
+func (h *countHeap) Less(i, j int) bool {
+	return h.items[i].Count < h.items[j].Count
+}
+
+func (h *countHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.index[h.items[i].Key] = i
+	h.index[h.items[j].Key] = j
+}
+
+func (h *countHeap) Push(x any) {
+	count := x.(Count)
+	h.index[count.Key] = len(h.items)
+	h.items = append(h.items, count)
+}
+
+func (h *countHeap) Pop() any {
+	count := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	delete(h.index, count.Key)
+
+	return count
+}
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestTopCounter(t *testing.T) {
+	counter := NewTopCounter(3)
+	for key, count := range map[string]int{"a": 5, "b": 3, "c": 3} {
+		for range count {
+			counter.Observe(key)
+		}
+	}
+
+	want := []Count{{Key: "a", Count: 5}, {Key: "b", Count: 3}, {Key: "c", Count: 3}}
+	if got := counter.Top(10); !slices.Equal(got, want) {
+		t.Errorf("top %v, want %v", got, want)
+	}
+	if got := counter.Top(1); !slices.Equal(got, want[:1]) {
+		t.Errorf("top 1 %v, want %v", got, want[:1])
+	}
+	if got := counter.Top(-1); len(got) != 0 {
+		t.Errorf("top -1 %v, want none", got)
+	}
+
+	// NOTE: a new key replaces the least frequent one inheriting its count
+	counter.Observe("d")
+	got := counter.Top(10)
+	if len(got) != 3 || got[0] != want[0] || got[1].Count != 4 || got[1].Key != "d" {
+		t.Errorf("top %v after the replacement, want d with 4", got)
+	}
+}
+
+func TestTopCounterHeavyHitters(t *testing.T) {
+	counter := NewTopCounter(10)
+
+	// the heavy hitters among many rare keys are always kept
+	for i := range 10_000 {
+		counter.Observe(fmt.Sprintf("rare-%d", i))
+		if i%4 == 0 {
+			counter.Observe("heavy-1")
+		}
+		if i%8 == 0 {
+			counter.Observe("heavy-2")
+		}
+	}
+
+	top := counter.Top(2)
+	if len(top) != 2 || top[0].Key != "heavy-1" || top[1].Key != "heavy-2" {
+		t.Fatalf("top %v, want the heavy hitters", top)
+	}
+	// space-saving never underestimates
+	if top[0].Count < 2_500 || top[1].Count < 1_250 {
+		t.Errorf("top %v underestimated", top)
+	}
+}
+
+func TestTopCounterConcurrent(t *testing.T) {
+	counter := NewTopCounter(0)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 1_000 {
+				counter.Observe("key")
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := counter.Top(10); !slices.Equal(got, []Count{{Key: "key", Count: 8_000}}) {
+		t.Errorf("top %v, want key with 8000", got)
+	}
+}